@@ -0,0 +1,79 @@
+// Copyright © 2024 SIL International
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/silinternational/tfc-ops/v4/output"
+)
+
+var autoApprove bool
+
+var applyCmd = &cobra.Command{
+	Use:   "apply",
+	Short: "Make workspaces match a manifest",
+	Long: `Create and update workspaces, variables, variable sets, team access, remote state consumers and run
+triggers to match a manifest. The changes are listed first, as in 'plan', and must be confirmed unless
+--auto-approve is given. Anything in a workspace that is not listed in the manifest is left unchanged.`,
+	Args: cobra.ExactArgs(0),
+	Run: func(cmd *cobra.Command, args []string) {
+		runApply(cmd.Context())
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(applyCmd)
+	addGlobalFlags(applyCmd)
+	addManifestFlag(applyCmd)
+	applyCmd.Flags().BoolVar(&autoApprove, "auto-approve", false,
+		"Make the changes without asking for confirmation")
+}
+
+func runApply(ctx context.Context) {
+	plan := planManifest(ctx)
+	if len(plan.Changes) == 0 {
+		fmt.Println("No changes. The workspaces match the manifest.")
+		return
+	}
+	writePlan(output.FormatTable, plan)
+	fmt.Println()
+
+	if readOnlyMode {
+		fmt.Println("Read only mode enabled. No changes will be made.")
+		return
+	}
+
+	if !autoApprove {
+		fmt.Printf("Do you want to make these %d changes?\n\n", len(plan.Changes))
+		yes, err := awaitUserResponse()
+		if err != nil {
+			errLog.Fatalln(err)
+		}
+		if !yes {
+			fmt.Println("No changes made.")
+			return
+		}
+	}
+
+	if err := client.ApplyManifestPlanContext(ctx, plan, os.Stdout); err != nil {
+		errLog.Fatalln(err)
+	}
+	fmt.Printf("Applied %d changes\n", len(plan.Changes))
+}
@@ -0,0 +1,68 @@
+// Copyright © 2024 SIL International
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var exportOutFile string
+
+var exportHclCmd = &cobra.Command{
+	Use:   "hcl",
+	Short: "Export to tfe provider HCL",
+	Long: `Write Terraform configuration for the tfe provider, with import blocks, describing the workspaces,
+variables, variable sets, team access and run triggers of the organization. Sensitive values can't be read, so
+they are replaced with input variables. Import blocks need Terraform 1.5 or later.`,
+	Args: cobra.ExactArgs(0),
+	Run: func(cmd *cobra.Command, args []string) {
+		runExportHcl(cmd.Context())
+	},
+}
+
+func init() {
+	exportCmd.AddCommand(exportHclCmd)
+	exportHclCmd.Flags().StringVar(&exportOutFile, "out", "",
+		"File to write the configuration to, instead of stdout")
+}
+
+func runExportHcl(ctx context.Context) {
+	var w io.Writer = os.Stdout
+	var f *os.File
+	if exportOutFile != "" {
+		var err error
+		f, err = os.Create(exportOutFile)
+		if err != nil {
+			errLog.Fatalf("error creating %s: %s", exportOutFile, err)
+		}
+		w = f
+	}
+
+	if err := client.ExportHCLContext(ctx, organization, w); err != nil {
+		if f != nil {
+			_ = f.Close()
+		}
+		errLog.Fatalf("error exporting organization %s: %s", organization, err)
+	}
+	if f != nil {
+		if err := f.Close(); err != nil {
+			errLog.Fatalf("error writing %s: %s", exportOutFile, err)
+		}
+	}
+}
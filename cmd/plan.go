@@ -0,0 +1,99 @@
+// Copyright © 2024 SIL International
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/silinternational/tfc-ops/v4/lib"
+	"github.com/silinternational/tfc-ops/v4/output"
+)
+
+var manifestFile string
+
+var planCmd = &cobra.Command{
+	Use:   "plan",
+	Short: "Show changes needed to match a manifest",
+	Long: `Compare a manifest of workspaces with the organization and list the changes that 'apply' would make.
+Anything in a workspace that is not listed in the manifest is left unchanged.`,
+	Args: cobra.ExactArgs(0),
+	Run: func(cmd *cobra.Command, args []string) {
+		runPlan(cmd.Context(), getOutputFormat())
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(planCmd)
+	addGlobalFlags(planCmd)
+	addManifestFlag(planCmd)
+	addOutputFlag(planCmd)
+}
+
+func addManifestFlag(command *cobra.Command) {
+	command.Flags().StringVarP(&manifestFile, "file", "f", "",
+		requiredPrefix+"Manifest file describing the workspaces, in YAML or JSON format")
+	if err := command.MarkFlagRequired("file"); err != nil {
+		errLog.Fatalf("failed to mark 'file' as a required flag on %s: %s", command.Name(), err)
+	}
+}
+
+func runPlan(ctx context.Context, format output.Format) {
+	plan := planManifest(ctx)
+	if format == output.FormatTable && len(plan.Changes) == 0 {
+		fmt.Println("No changes. The workspaces match the manifest.")
+		return
+	}
+	writePlan(format, plan)
+}
+
+// planManifest reads the manifest file and compares it with the organization
+func planManifest(ctx context.Context) *lib.ManifestPlan {
+	f, err := os.Open(manifestFile)
+	if err != nil {
+		errLog.Fatalf("error opening manifest: %s", err)
+	}
+	defer f.Close()
+
+	manifest, err := lib.ReadManifest(f)
+	if err != nil {
+		errLog.Fatalf("error reading %s: %s", manifestFile, err)
+	}
+
+	plan, err := client.PlanManifestContext(ctx, organization, manifest)
+	if err != nil {
+		errLog.Fatalf("error comparing manifest with organization %s: %s", organization, err)
+	}
+	return plan
+}
+
+func writePlan(format output.Format, plan *lib.ManifestPlan) {
+	rows := make([][]string, len(plan.Changes))
+	for i, c := range plan.Changes {
+		rows[i] = []string{c.Workspace, c.Action, c.Resource, c.Name, c.Detail}
+	}
+	records := plan.Changes
+	if records == nil {
+		records = []lib.ManifestChange{}
+	}
+	writeOutput(format, output.Table{
+		Columns: []string{"workspace", "action", "resource", "name", "detail"},
+		Rows:    rows,
+		Records: records,
+	})
+}
@@ -15,19 +15,24 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"runtime"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
 	"github.com/silinternational/tfc-ops/v4/lib"
+	"github.com/silinternational/tfc-ops/v4/output"
 )
 
 const requiredPrefix = "required - "
@@ -35,9 +40,15 @@ const requiredPrefix = "required - "
 var (
 	cfgFile      string
 	organization string
+	hostname     string
+	maxRetries   int
+	parallelism  int
+	timeout      time.Duration
 	readOnlyMode bool
 	debugMode    bool
+	outputFormat string
 	errLog       *log.Logger
+	client       *lib.Client
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -50,8 +61,18 @@ var rootCmd = &cobra.Command{
 
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
+//
+// An interrupt signal (Ctrl-C) cancels the context given to the commands, which stops any API calls in progress.
+// A second interrupt ends the program immediately.
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+
+	if err := rootCmd.ExecuteContext(ctx); err != nil {
 		fmt.Println(err)
 		os.Exit(1)
 	}
@@ -66,47 +87,61 @@ func init() {
 func initRoot(cmd *cobra.Command, args []string) {
 	debugStr := os.Getenv("TFC_OPS_DEBUG")
 	if debugStr == "TRUE" || debugStr == "true" {
-		lib.EnableDebug()
 		debugMode = true
 	}
 
-	if readOnlyMode {
-		lib.EnableReadOnlyMode()
+	if hostname == "" {
+		hostname = os.Getenv("TFC_OPS_HOSTNAME")
+	}
+	if hostname == "" {
+		hostname = lib.DefaultHostname
+	}
+
+	if maxRetries == 0 {
+		// a ClientConfig.MaxRetries of zero selects the default
+		maxRetries = -1
 	}
 
-	getToken()
+	client = lib.NewClient(lib.ClientConfig{
+		Token:       getToken(),
+		Hostname:    hostname,
+		Debug:       debugMode,
+		ReadOnly:    readOnlyMode,
+		MaxRetries:  maxRetries,
+		Timeout:     timeout,
+		Parallelism: parallelism,
+	})
 }
 
+// Credentials is the content of the credentials.tfrc.json file written by `terraform login`. It has one token
+// for each hostname that has been logged into.
 type Credentials struct {
-	Credentials struct {
-		AppTerraformIo struct {
-			Token string `json:"token"`
-		} `json:"app.terraform.io"`
+	Credentials map[string]struct {
+		Token string `json:"token"`
 	} `json:"credentials"`
 }
 
-func getToken() {
+func getToken() string {
 	credentials, err := readTerraformCredentials()
 	if err != nil {
 		errLog.Fatalln("failed to get Terraform credentials:", err)
 	}
 
 	if credentials != nil {
-		token := credentials.Credentials.AppTerraformIo.Token
+		token := credentials.Credentials[hostname].Token
 		if token != "" {
-			lib.SetToken(token)
-			return
+			return token
 		}
 	}
 
 	// fall back to using ATLAS_TOKEN environment variable
 	atlasToken := os.Getenv("ATLAS_TOKEN")
 	if atlasToken != "" {
-		lib.SetToken(atlasToken)
-		return
+		return atlasToken
 	}
 
-	errLog.Fatalln("no credentials found, use 'terraform login' to create a token")
+	errLog.Fatalf("no credentials found for %s, use 'terraform login %s' to create a token\n", hostname, hostname)
+	return ""
 }
 
 func readTerraformCredentials() (*Credentials, error) {
@@ -165,7 +200,7 @@ func initConfig() {
 
 	// If a config file is found, read it in.
 	if err := viper.ReadInConfig(); err == nil {
-		fmt.Println("Using config file:", viper.ConfigFileUsed())
+		fmt.Fprintln(os.Stderr, "Using config file:", viper.ConfigFileUsed())
 	}
 }
 
@@ -174,6 +209,22 @@ func addGlobalFlags(command *cobra.Command) {
 		`read-only mode (e.g. "-r")`,
 	)
 
+	command.PersistentFlags().StringVar(&hostname, "hostname", "",
+		`Terraform Cloud or Enterprise hostname, defaults to $TFC_OPS_HOSTNAME or "`+lib.DefaultHostname+`"`,
+	)
+
+	command.PersistentFlags().IntVar(&maxRetries, "max-retries", lib.DefaultMaxRetries,
+		"Number of times to retry an API call after a rate limit, server or network error",
+	)
+
+	command.PersistentFlags().IntVar(&parallelism, "parallelism", lib.DefaultParallelism,
+		"Number of workspaces to process at once in operations on many workspaces",
+	)
+
+	command.PersistentFlags().DurationVar(&timeout, "request-timeout", lib.DefaultTimeout,
+		"Time limit for each API call",
+	)
+
 	command.PersistentFlags().StringVarP(&organization, "organization",
 		"o", "", requiredPrefix+"Name of Terraform Cloud Organization")
 	if err := command.MarkPersistentFlagRequired("organization"); err != nil {
@@ -181,18 +232,92 @@ func addGlobalFlags(command *cobra.Command) {
 	}
 }
 
+// addOutputFlag adds the --output flag to a command that lists things
+func addOutputFlag(command *cobra.Command) {
+	command.Flags().StringVar(&outputFormat, "output", string(output.FormatTable),
+		"Output format, one of: "+strings.Join(output.Formats, ", "),
+	)
+}
+
+// getOutputFormat returns the format given by the --output flag, exiting if it is not valid
+func getOutputFormat() output.Format {
+	format, err := output.ParseFormat(outputFormat)
+	if err != nil {
+		errLog.Fatalln(err)
+	}
+	return format
+}
+
+// writeOutput writes a list of records to stdout in the given format
+func writeOutput(format output.Format, table output.Table) {
+	if err := format.Write(os.Stdout, table); err != nil {
+		errLog.Fatalln("error writing output:", err)
+	}
+}
+
+// selectWorkspaces returns the workspace given by the --workspace flag or the workspaces matching the
+// --workspace-filter flag, as a map of IDs to names. It exits if neither flag is set or no workspace is found.
+func selectWorkspaces(ctx context.Context) map[string]string {
+	if workspace == "" && workspaceFilter == "" {
+		errLog.Fatalln("Either --workspace or --workspace-filter must be specified.")
+	}
+
+	if workspace != "" {
+		w, err := client.GetWorkspaceByNameContext(ctx, organization, workspace)
+		if err != nil {
+			errLog.Fatalf("error getting workspace %q from Terraform: %s", workspace, err)
+		}
+		return map[string]string{w.ID: workspace}
+	}
+
+	workspaces, err := client.FindWorkspacesContext(ctx, organization, workspaceFilter)
+	if err != nil {
+		errLog.Fatalf("error searching for workspaces: %s", err)
+	}
+	if len(workspaces) == 0 {
+		errLog.Fatalf("no workspaces match the filter '%s'", workspaceFilter)
+	}
+	return workspaces
+}
+
+// stringMapToSlice splits a map into a list of keys and a list of values, ordered by value
 func stringMapToSlice(m map[string]string) ([]string, []string) {
-	keys := make([]string, len(m))
-	values := make([]string, len(m))
-	i := 0
-	for k, v := range m {
-		keys[i] = k
-		values[i] = v
-		i++
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return m[keys[i]] < m[keys[j]] })
+
+	values := make([]string, len(keys))
+	for i, k := range keys {
+		values[i] = m[k]
 	}
 	return keys, values
 }
 
+// exitIncomplete reports an error from an operation on a list of workspaces and exits. If the error is a
+// *lib.IncompleteError, the workspaces that were and were not processed are listed. The report is written to stderr
+// so that it doesn't get mixed in with any structured output.
+func exitIncomplete(err error) {
+	var incomplete *lib.IncompleteError
+	if errors.As(err, &incomplete) {
+		if errors.Is(err, context.Canceled) {
+			errLog.Println("\nInterrupted")
+		}
+		errLog.Printf("Completed %s\n", workspaceCountList(incomplete.Processed))
+		errLog.Printf("Not completed %s\n", workspaceCountList(incomplete.Remaining))
+		err = incomplete.Err
+	}
+	errLog.Fatalln(err)
+}
+
+func workspaceCountList(wsNames []string) string {
+	if len(wsNames) == 0 {
+		return "0 workspaces"
+	}
+	return fmt.Sprintf("%d %s", len(wsNames), workspaceListToString(wsNames))
+}
+
 func workspaceListToString(wsNames []string) string {
 	if len(wsNames) == 0 {
 		return ""
@@ -0,0 +1,151 @@
+// Copyright © 2024 SIL International
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/spf13/cobra"
+
+	"github.com/silinternational/tfc-ops/v4/lib"
+)
+
+var (
+	runID      string
+	runComment string
+)
+
+// runsCmd represents the top level command for runs
+var runsCmd = &cobra.Command{
+	Use:   "runs",
+	Short: "Queue, list, or act on runs",
+	Long:  "Top level command for creating, listing, showing, applying, discarding or canceling runs",
+	Args:  cobra.MinimumNArgs(1),
+}
+
+func init() {
+	rootCmd.AddCommand(runsCmd)
+	addGlobalFlags(runsCmd)
+}
+
+// addWorkspaceSelectionFlags adds the flags used by selectWorkspaces
+func addWorkspaceSelectionFlags(command *cobra.Command) {
+	command.Flags().StringVarP(&workspace, "workspace", "w", "",
+		"Name of the Workspace in Terraform Cloud")
+	command.Flags().StringVar(&workspaceFilter, "workspace-filter", "",
+		"Partial workspace name to search across all workspaces")
+}
+
+// runAction is an action that can be taken on a run, such as apply
+type runAction struct {
+	name    string // e.g. "apply"
+	allowed func(run lib.Run) bool
+	do      func(ctx context.Context, runID, comment string) error
+	mustAsk bool   // ask for confirmation before acting on the runs of more than one workspace
+	doing   string // e.g. "applying"
+	done    string // e.g. "applied"
+}
+
+// addRunActionFlags adds the flags for an action on a run given by ID, or on the latest run of each workspace
+func addRunActionFlags(command *cobra.Command) {
+	command.Flags().StringVar(&runID, "run", "",
+		"ID of the run, instead of the latest run in each of the selected workspaces")
+	addWorkspaceSelectionFlags(command)
+	command.Flags().StringVar(&runComment, "comment", "",
+		"Comment to add to the run")
+}
+
+func runRunAction(ctx context.Context, action runAction) {
+	if readOnlyMode {
+		fmt.Printf("Read only mode enabled. No runs will be %s.\n", action.done)
+	}
+
+	if runID != "" {
+		fmt.Printf("%s run %s\n", strings.ToUpper(action.doing[:1])+action.doing[1:], runID)
+		if err := action.do(ctx, runID, runComment); err != nil {
+			errLog.Fatalln(err)
+		}
+		return
+	}
+
+	ids, names := stringMapToSlice(selectWorkspaces(ctx))
+	idByName := make(map[string]string, len(ids))
+	for i, id := range ids {
+		idByName[names[i]] = id
+	}
+
+	var mu sync.Mutex
+	runByName := map[string]lib.Run{}
+	err := client.ForEachWorkspace(ctx, names, os.Stdout, func(ctx context.Context, name string, w io.Writer) error {
+		runs, err := client.ListRunsContext(ctx, lib.ListRunsConfig{WorkspaceID: idByName[name], Limit: 1})
+		if err != nil {
+			return err
+		}
+		if len(runs) == 0 {
+			fmt.Fprintf(w, "Workspace %s: no runs\n", name)
+			return nil
+		}
+		run := runs[0]
+		if !action.allowed(run) {
+			fmt.Fprintf(w, "Workspace %s: latest run %s is %s, cannot %s\n",
+				name, run.ID, run.Attributes.Status, action.name)
+			return nil
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		runByName[name] = run
+		return nil
+	})
+	if err != nil {
+		exitIncomplete(err)
+	}
+
+	var targets []string
+	for _, name := range names {
+		if _, ok := runByName[name]; ok {
+			targets = append(targets, name)
+		}
+	}
+	if len(targets) == 0 {
+		fmt.Printf("No runs to %s\n", action.name)
+		return
+	}
+
+	if action.mustAsk && len(targets) > 1 && !autoApprove && !readOnlyMode {
+		fmt.Printf("Do you want to %s the latest run in %s?\n\n", action.name, workspaceCountList(targets))
+		yes, err := awaitUserResponse()
+		if err != nil {
+			errLog.Fatalln(err)
+		}
+		if !yes {
+			fmt.Printf("No runs %s\n", action.done)
+			return
+		}
+	}
+
+	err = client.ForEachWorkspace(ctx, targets, os.Stdout, func(ctx context.Context, name string, w io.Writer) error {
+		run := runByName[name]
+		fmt.Fprintf(w, "Workspace %s: %s run %s\n", name, action.doing, run.ID)
+		return action.do(ctx, run.ID, runComment)
+	})
+	if err != nil {
+		exitIncomplete(err)
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright © 2024 SIL International
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/silinternational/tfc-ops/v4/lib"
+)
+
+var runsApplyCmd = &cobra.Command{
+	Use:   "apply",
+	Short: "Apply a run",
+	Long: `Apply a run that is waiting for confirmation, or the latest run in each selected workspace. Confirmation is
+required to apply runs in more than one workspace unless --auto-approve is given.`,
+	Args: cobra.ExactArgs(0),
+	Run: func(cmd *cobra.Command, args []string) {
+		runRunAction(cmd.Context(), runAction{
+			name:    "apply",
+			allowed: func(run lib.Run) bool { return run.Attributes.Actions.IsConfirmable },
+			do:      client.ApplyRunContext,
+			mustAsk: true,
+			doing:   "applying",
+			done:    "applied",
+		})
+	},
+}
+
+func init() {
+	runsCmd.AddCommand(runsApplyCmd)
+	addRunActionFlags(runsApplyCmd)
+	runsApplyCmd.Flags().BoolVar(&autoApprove, "auto-approve", false,
+		"Apply runs in more than one workspace without asking for confirmation")
+}
@@ -0,0 +1,73 @@
+// Copyright © 2024 SIL International
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/silinternational/tfc-ops/v4/lib"
+)
+
+var runMessage string
+
+var runsCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Queue a plan",
+	Long:  `Queue a run, which starts a plan, in a workspace or in each workspace matching a filter`,
+	Args:  cobra.ExactArgs(0),
+	Run: func(cmd *cobra.Command, args []string) {
+		runRunsCreate(cmd.Context())
+	},
+}
+
+func init() {
+	runsCmd.AddCommand(runsCreateCmd)
+	addWorkspaceSelectionFlags(runsCreateCmd)
+	runsCreateCmd.Flags().StringVarP(&runMessage, "message", "m", "Queued by tfc-ops",
+		"Message to show with the run")
+}
+
+func runRunsCreate(ctx context.Context) {
+	if readOnlyMode {
+		fmt.Println("Read only mode enabled. No runs will be queued.")
+	}
+
+	ids, names := stringMapToSlice(selectWorkspaces(ctx))
+	idByName := make(map[string]string, len(ids))
+	for i, id := range ids {
+		idByName[names[i]] = id
+	}
+
+	err := client.ForEachWorkspace(ctx, names, os.Stdout, func(ctx context.Context, name string, w io.Writer) error {
+		if readOnlyMode {
+			fmt.Fprintf(w, "Workspace %s: queue run\n", name)
+			return nil
+		}
+		run, err := client.CreateRun2Context(ctx, lib.RunConfig{Message: runMessage, WorkspaceID: idByName[name]})
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "Workspace %s: queued run %s\n", name, run.ID)
+		return nil
+	})
+	if err != nil {
+		exitIncomplete(err)
+	}
+}
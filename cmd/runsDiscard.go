@@ -0,0 +1,42 @@
+// Copyright © 2024 SIL International
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/silinternational/tfc-ops/v4/lib"
+)
+
+var runsDiscardCmd = &cobra.Command{
+	Use:   "discard",
+	Short: "Discard a run",
+	Long:  `Discard a run that is waiting for confirmation, or the latest run in each selected workspace`,
+	Args:  cobra.ExactArgs(0),
+	Run: func(cmd *cobra.Command, args []string) {
+		runRunAction(cmd.Context(), runAction{
+			name:    "discard",
+			allowed: func(run lib.Run) bool { return run.Attributes.Actions.IsDiscardable },
+			do:      client.DiscardRunContext,
+			doing:   "discarding",
+			done:    "discarded",
+		})
+	},
+}
+
+func init() {
+	runsCmd.AddCommand(runsDiscardCmd)
+	addRunActionFlags(runsDiscardCmd)
+}
@@ -0,0 +1,106 @@
+// Copyright © 2024 SIL International
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/silinternational/tfc-ops/v4/lib"
+	"github.com/silinternational/tfc-ops/v4/output"
+)
+
+var runsLimit int
+
+var runsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List recent runs",
+	Long:  `List the most recent runs in a workspace or in each workspace matching a filter`,
+	Args:  cobra.ExactArgs(0),
+	Run: func(cmd *cobra.Command, args []string) {
+		runRunsList(cmd.Context(), getOutputFormat())
+	},
+}
+
+func init() {
+	runsCmd.AddCommand(runsListCmd)
+	addWorkspaceSelectionFlags(runsListCmd)
+	runsListCmd.Flags().IntVar(&runsLimit, "limit", 5,
+		"Number of runs to list for each workspace")
+	addOutputFlag(runsListCmd)
+}
+
+// workspaceRun is one run in the output of `runs list`
+type workspaceRun struct {
+	Workspace string    `json:"workspace" yaml:"workspace"`
+	ID        string    `json:"id" yaml:"id"`
+	Status    string    `json:"status" yaml:"status"`
+	CreatedAt time.Time `json:"created-at" yaml:"created-at"`
+	Source    string    `json:"source" yaml:"source"`
+	Message   string    `json:"message" yaml:"message"`
+}
+
+func runRunsList(ctx context.Context, format output.Format) {
+	ids, names := stringMapToSlice(selectWorkspaces(ctx))
+	idByName := make(map[string]string, len(ids))
+	for i, id := range ids {
+		idByName[names[i]] = id
+	}
+
+	var mu sync.Mutex
+	runsByName := map[string][]lib.Run{}
+	err := client.ForEachWorkspace(ctx, names, nil, func(ctx context.Context, name string, w io.Writer) error {
+		runs, err := client.ListRunsContext(ctx, lib.ListRunsConfig{WorkspaceID: idByName[name], Limit: runsLimit})
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		runsByName[name] = runs
+		return nil
+	})
+
+	records := []workspaceRun{}
+	var rows [][]string
+	for _, name := range names {
+		for _, run := range runsByName[name] {
+			r := workspaceRun{
+				Workspace: name,
+				ID:        run.ID,
+				Status:    run.Attributes.Status,
+				CreatedAt: run.Attributes.CreatedAt,
+				Source:    run.Attributes.Source,
+				Message:   run.Attributes.Message,
+			}
+			records = append(records, r)
+			rows = append(rows, []string{
+				r.Workspace, r.ID, r.Status, r.CreatedAt.Format(time.RFC3339), r.Source, r.Message,
+			})
+		}
+	}
+	writeOutput(format, output.Table{
+		Columns: []string{"workspace", "id", "status", "created-at", "source", "message"},
+		Rows:    rows,
+		Records: records,
+	})
+
+	if err != nil {
+		exitIncomplete(err)
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright © 2024 SIL International
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/silinternational/tfc-ops/v4/output"
+)
+
+var runsShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Show a run",
+	Long:  `Show the status of a run and a summary of its plan`,
+	Args:  cobra.ExactArgs(0),
+	Run: func(cmd *cobra.Command, args []string) {
+		runRunsShow(cmd.Context(), getOutputFormat())
+	},
+}
+
+func init() {
+	runsCmd.AddCommand(runsShowCmd)
+	runsShowCmd.Flags().StringVar(&runID, "run", "",
+		requiredPrefix+"ID of the run")
+	if err := runsShowCmd.MarkFlagRequired("run"); err != nil {
+		errLog.Fatalln("failed to mark 'run' as a required flag on runsShowCmd: " + err.Error())
+	}
+	addOutputFlag(runsShowCmd)
+}
+
+// runSummary is the output of `runs show`
+type runSummary struct {
+	ID           string    `json:"id" yaml:"id"`
+	WorkspaceID  string    `json:"workspace-id" yaml:"workspace-id"`
+	Status       string    `json:"status" yaml:"status"`
+	CreatedAt    time.Time `json:"created-at" yaml:"created-at"`
+	Message      string    `json:"message" yaml:"message"`
+	IsDestroy    bool      `json:"is-destroy" yaml:"is-destroy"`
+	PlanStatus   string    `json:"plan-status" yaml:"plan-status"`
+	Additions    int       `json:"additions" yaml:"additions"`
+	Changes      int       `json:"changes" yaml:"changes"`
+	Destructions int       `json:"destructions" yaml:"destructions"`
+}
+
+func runRunsShow(ctx context.Context, format output.Format) {
+	run, err := client.GetRunContext(ctx, runID)
+	if err != nil {
+		errLog.Fatalf("error getting run %s: %s", runID, err)
+	}
+
+	s := runSummary{
+		ID:          run.ID,
+		WorkspaceID: run.Relationships.Workspace.Data.ID,
+		Status:      run.Attributes.Status,
+		CreatedAt:   run.Attributes.CreatedAt,
+		Message:     run.Attributes.Message,
+		IsDestroy:   run.Attributes.IsDestroy,
+	}
+	if planID := run.Relationships.Plan.Data.ID; planID != "" {
+		plan, err := client.GetPlanContext(ctx, planID)
+		if err != nil {
+			errLog.Fatalf("error getting plan %s: %s", planID, err)
+		}
+		s.PlanStatus = plan.Attributes.Status
+		s.Additions = plan.Attributes.ResourceAdditions
+		s.Changes = plan.Attributes.ResourceChanges
+		s.Destructions = plan.Attributes.ResourceDestructions
+	}
+
+	writeOutput(format, output.Table{
+		Columns: []string{
+			"id", "workspace-id", "status", "created-at", "message", "is-destroy",
+			"plan-status", "additions", "changes", "destructions",
+		},
+		Rows: [][]string{{
+			s.ID, s.WorkspaceID, s.Status, s.CreatedAt.Format(time.RFC3339), s.Message, strconv.FormatBool(s.IsDestroy),
+			s.PlanStatus, strconv.Itoa(s.Additions), strconv.Itoa(s.Changes), strconv.Itoa(s.Destructions),
+		}},
+		Records: []runSummary{s},
+	})
+}
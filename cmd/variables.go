@@ -15,7 +15,26 @@
 package cmd
 
 import (
+	"context"
+	"os"
+	"strings"
+
 	"github.com/spf13/cobra"
+
+	"github.com/silinternational/tfc-ops/v4/lib"
+)
+
+var (
+	variableCategory    string
+	variableHcl         bool
+	variableDescription string
+	variableValueFile   string
+
+	keyRegex      string
+	valueRegex    string
+	matchCategory string
+	isSensitive   bool
+	isHcl         bool
 )
 
 // variablesCmd represents the top level command for variables
@@ -33,3 +52,136 @@ func init() {
 		`Name of the Workspace in Terraform Cloud`,
 	)
 }
+
+// addVariableSettingFlags adds the flags that set the category, HCL, description and value file of a variable
+func addVariableSettingFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&variableCategory, "category", "terraform",
+		`optional, the category of the variable: "terraform" or "env". Only a variable in this category matches.`,
+	)
+	cmd.Flags().BoolVar(&variableHcl, "hcl", false,
+		`optional, parse the value as HCL, or with "--hcl=false" as a string. If not given, an existing variable keeps its setting.`,
+	)
+	cmd.Flags().StringVar(&variableDescription, "description", "",
+		`optional, the description of the variable. If not given, an existing variable keeps its description.`,
+	)
+	cmd.Flags().StringVar(&variableValueFile, "value-file", "",
+		`optional, read the value from a file, e.g. a multi-line HCL value. A final newline is removed.`,
+	)
+}
+
+// variableValue returns the value given with the flagName flag of cmd, or read from the --value-file file. Exactly
+// one of them is required.
+func variableValue(cmd *cobra.Command, flagName, value string) string {
+	if variableCategory != "terraform" && variableCategory != "env" {
+		errLog.Fatalf("invalid category '%s', must be 'terraform' or 'env'\n", variableCategory)
+	}
+	if variableValueFile == "" {
+		if !cmd.Flags().Changed(flagName) {
+			errLog.Fatalf("either --%s or --value-file is required\n", flagName)
+		}
+		return value
+	}
+	if cmd.Flags().Changed(flagName) {
+		errLog.Fatalf("--%s may not be used with --value-file\n", flagName)
+	}
+
+	data, err := os.ReadFile(variableValueFile)
+	if err != nil {
+		errLog.Fatalf("failed to read the value file: %s\n", err)
+	}
+	s := strings.TrimSuffix(string(data), "\n")
+	return strings.TrimSuffix(s, "\r")
+}
+
+// addVariableMatchFlags adds the flags that select variables by regular expression, category and setting.
+// matchDefault is the default of --match, "any" or "all". If withCategory is false, the command has its own
+// --category flag.
+func addVariableMatchFlags(cmd *cobra.Command, matchDefault string, withCategory bool) {
+	cmd.Flags().StringVar(&keyRegex, "key-regex", "",
+		`optional, regular expression matching the variable keys, e.g. "(?i)^aws_"`,
+	)
+	cmd.Flags().StringVar(&valueRegex, "value-regex", "",
+		`optional, regular expression matching the variable values`,
+	)
+	cmd.Flags().String("match", matchDefault,
+		`"all" to select variables matching both the key and the value criteria, "any" to select variables matching either`,
+	)
+	if withCategory {
+		cmd.Flags().StringVar(&matchCategory, "category", "",
+			`optional, only select variables in this category: "terraform" or "env"`,
+		)
+	}
+	cmd.Flags().BoolVar(&isSensitive, "is-sensitive", false,
+		`optional, only select variables that are sensitive, or with "--is-sensitive=false" that are not`,
+	)
+	cmd.Flags().BoolVar(&isHcl, "is-hcl", false,
+		`optional, only select HCL variables, or with "--is-hcl=false" variables that are not HCL`,
+	)
+}
+
+// hasVariableMatchFlags returns true if any of the flags added by addVariableMatchFlags, other than --match, is used
+func hasVariableMatchFlags(cmd *cobra.Command) bool {
+	for _, name := range []string{"key-regex", "value-regex", "is-sensitive", "is-hcl"} {
+		if cmd.Flags().Changed(name) {
+			return true
+		}
+	}
+	return matchCategory != ""
+}
+
+// variableMatch returns m with the criteria given by the flags added by addVariableMatchFlags
+func variableMatch(cmd *cobra.Command, m lib.VariableMatch) lib.VariableMatch {
+	matchMode, _ := cmd.Flags().GetString("match")
+	switch matchMode {
+	case "any":
+		m.Any = true
+	case "all":
+		m.Any = false
+	default:
+		errLog.Fatalf("invalid value for --match: '%s', must be 'any' or 'all'\n", matchMode)
+	}
+	m.KeyRegex = keyRegex
+	m.ValueRegex = valueRegex
+	if matchCategory != "" {
+		m.Category = matchCategory
+	}
+	if cmd.Flags().Changed("is-sensitive") {
+		m.Sensitive = &isSensitive
+	}
+	if cmd.Flags().Changed("is-hcl") {
+		m.Hcl = &isHcl
+	}
+	if _, err := lib.FilterVariables(nil, m); err != nil {
+		errLog.Fatalln(err)
+	}
+	return m
+}
+
+// matchingWorkspaces returns the names of the workspaces selected by the --workspace flag: the workspace it names,
+// the workspaces matching it if it is a glob pattern, or all workspaces if it is not set
+func matchingWorkspaces(ctx context.Context) []string {
+	if workspace != "" && !lib.IsWorkspacePattern(workspace) {
+		return []string{workspace}
+	}
+
+	allData, err := client.GetAllWorkspacesContext(ctx, organization)
+	if err != nil {
+		errLog.Fatalln(err)
+	}
+	names := make([]string, len(allData))
+	for i, ws := range allData {
+		names[i] = ws.Attributes.Name
+	}
+	if workspace == "" {
+		return names
+	}
+
+	names, err = lib.MatchWorkspaces(workspace, names)
+	if err != nil {
+		errLog.Fatalln(err)
+	}
+	if len(names) == 0 {
+		errLog.Fatalf("no workspaces match the pattern '%s'\n", workspace)
+	}
+	return names
+}
@@ -15,7 +15,10 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"os"
 
 	"github.com/spf13/cobra"
 
@@ -28,7 +31,8 @@ var variablesAddCmd = &cobra.Command{
 	Long:  `Add variable in matching workspace. Will not update existing variable.`,
 	Args:  cobra.ExactArgs(0),
 	Run: func(cmd *cobra.Command, args []string) {
-		runVariablesAdd()
+		value = variableValue(cmd, "value", value)
+		runVariablesAdd(cmd.Context())
 	},
 }
 
@@ -40,45 +44,52 @@ func init() {
 		errLog.Fatalln("failed to mark 'key' as a required flag on variablesAddCmd")
 	}
 	variablesAddCmd.Flags().StringVarP(&value, "value", "v", "",
-		requiredPrefix+"Terraform variable value")
-	if err := variablesAddCmd.MarkFlagRequired("value"); err != nil {
-		errLog.Fatalln("failed to mark 'value' as a required flag on variablesAddCmd")
-	}
+		"Terraform variable value, required unless --value-file is given")
+	addVariableSettingFlags(variablesAddCmd)
 }
 
-func runVariablesAdd() {
+func runVariablesAdd(ctx context.Context) {
 	if readOnlyMode {
 		fmt.Println("Read only mode enabled. No variables will be added.")
 	}
 
 	if workspace != "" {
-		addWorkspaceVar(organization, workspace, key, value)
+		if err := addWorkspaceVar(ctx, organization, workspace, key, value, os.Stdout); err != nil {
+			errLog.Fatalln(err)
+		}
 		return
 	}
 
 	fmt.Printf("Adding variables with key '%s' and value '%s' to all workspaces...\n", key, value)
-	allWorkspaces, err := lib.GetAllWorkspaces(organization)
+	allWorkspaces, err := client.GetAllWorkspacesContext(ctx, organization)
 	if err != nil {
 		println(err.Error())
 		return
 	}
 
-	for _, w := range allWorkspaces {
-		addWorkspaceVar(organization, w.Attributes.Name, key, value)
+	names := make([]string, len(allWorkspaces))
+	for i, w := range allWorkspaces {
+		names[i] = w.Attributes.Name
+	}
+	err = client.ForEachWorkspace(ctx, names, os.Stdout, func(ctx context.Context, name string, w io.Writer) error {
+		return addWorkspaceVar(ctx, organization, name, key, value, w)
+	})
+	if err != nil {
+		exitIncomplete(err)
 	}
 	return
 }
 
-func addWorkspaceVar(org, ws, key, value string) {
-	if v, err := lib.GetWorkspaceVar(org, ws, key); err != nil {
-		errLog.Fatalf("failure checking for existence of variable '%s' in workspace '%s', %s\n", key, ws, err)
+func addWorkspaceVar(ctx context.Context, org, ws, key, value string, w io.Writer) error {
+	if v, err := client.GetWorkspaceVarInCategoryContext(ctx, org, ws, variableCategory, key); err != nil {
+		return fmt.Errorf("failure checking for existence of variable '%s' in workspace '%s', %w", key, ws, err)
 	} else if v != nil {
-		errLog.Fatalf("'%s' already exists in '%s'. Use 'variable update' command to change the value.\n", key, ws)
+		return fmt.Errorf("'%s' already exists in '%s'. Use 'variable update' command to change the value.", key, ws)
 	}
 
-	fmt.Printf("Workspace %s: Adding variable %s = %s\n", ws, key, value)
+	fmt.Fprintf(w, "Workspace %s: Adding variable %s = %s\n", ws, key, value)
 	if !readOnlyMode {
-		if _, err := lib.AddOrUpdateVariable(lib.UpdateConfig{
+		if _, err := client.AddOrUpdateVariableContext(ctx, lib.UpdateConfig{
 			Organization:          organization,
 			Workspace:             ws,
 			SearchString:          key,
@@ -86,9 +97,12 @@ func addWorkspaceVar(org, ws, key, value string) {
 			AddKeyIfNotFound:      true,
 			SearchOnVariableValue: false,
 			SensitiveVariable:     false,
+			Category:              variableCategory,
+			Hcl:                   &variableHcl,
+			Description:           variableDescription,
 		}); err != nil {
-			errLog.Fatalf("failed to add variable '%s' in workspace '%s', %s\n", key, ws, err)
+			return fmt.Errorf("failed to add variable '%s' in workspace '%s', %w", key, ws, err)
 		}
 	}
-	return
+	return nil
 }
@@ -15,11 +15,15 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"os"
+	"regexp"
 
 	"github.com/spf13/cobra"
 
 	"github.com/silinternational/tfc-ops/v4/lib"
+	"github.com/silinternational/tfc-ops/v4/output"
 )
 
 var key string
@@ -27,23 +31,30 @@ var key string
 var variablesDeleteCmd = &cobra.Command{
 	Use:   "delete",
 	Short: "Delete variable",
-	Long:  `Delete variable in matching workspace having the specified key`,
-	Args:  cobra.ExactArgs(0),
+	Long: `Delete variable in matching workspace having the specified key. Instead, the variables can be selected with
+a regular expression on the key or value, and by category, sensitive and HCL, in the workspace given by --workspace
+or in each workspace matching it if it is a glob pattern (e.g. "app-*"). Then the variables to delete are listed
+first and must be confirmed unless --auto-approve is given.`,
+	Args: cobra.ExactArgs(0),
 	Run: func(cmd *cobra.Command, args []string) {
-		runVariablesDelete()
+		if hasVariableMatchFlags(cmd) || lib.IsWorkspacePattern(workspace) {
+			runVariablesDeleteMatching(cmd)
+			return
+		}
+		runVariablesDelete(cmd.Context())
 	},
 }
 
 func init() {
 	variablesCmd.AddCommand(variablesDeleteCmd)
 	variablesDeleteCmd.Flags().StringVarP(&key, "key", "k", "",
-		requiredPrefix+"Terraform variable key to delete, must match exactly")
-	if err := variablesDeleteCmd.MarkFlagRequired("key"); err != nil {
-		errLog.Fatalln("failed to mark 'key' as a required flag on variablesDeleteCmd: " + err.Error())
-	}
+		"Terraform variable key to delete, must match exactly. Required unless a variable selection flag is given.")
+	addVariableMatchFlags(variablesDeleteCmd, "all", true)
+	variablesDeleteCmd.Flags().BoolVar(&autoApprove, "auto-approve", false,
+		"Delete the selected variables without asking for confirmation")
 }
 
-func runVariablesDelete() {
+func runVariablesDelete(ctx context.Context) {
 	if readOnlyMode {
 		fmt.Println("Read only mode enabled. No variables will be deleted.")
 	}
@@ -51,16 +62,70 @@ func runVariablesDelete() {
 	if workspace == "" {
 		errLog.Fatal("No workspace specified")
 	}
+	if key == "" {
+		errLog.Fatal("Either --key or one of the variable selection flags must be specified")
+	}
 
-	found := deleteWorkspaceVar(organization, workspace, key)
+	found := deleteWorkspaceVar(ctx, organization, workspace, key)
 	if !found {
 		errLog.Fatalf("Variable %s not found in workspace %s\n", key, workspace)
 	}
 	return
 }
 
-func deleteWorkspaceVar(org, ws, key string) bool {
-	v, err := lib.GetWorkspaceVar(org, ws, key)
+// runVariablesDeleteMatching deletes the variables selected by the variable selection flags, after confirmation
+func runVariablesDeleteMatching(cmd *cobra.Command) {
+	ctx := cmd.Context()
+	if workspace == "" {
+		errLog.Fatal("No workspace specified")
+	}
+	if key == "" && !hasVariableMatchFlags(cmd) {
+		errLog.Fatal("Either --key or one of the variable selection flags must be specified")
+	}
+	if key != "" && keyRegex != "" {
+		errLog.Fatal("The --key flag may not be used with --key-regex")
+	}
+	match := variableMatch(cmd, lib.VariableMatch{})
+	if key != "" {
+		match.KeyRegex = "^" + regexp.QuoteMeta(key) + "$"
+	}
+
+	plan, err := client.PlanVariableDeleteContext(ctx, organization, matchingWorkspaces(ctx), match)
+	if err != nil {
+		exitIncomplete(err)
+	}
+	if len(plan.Changes) == 0 {
+		fmt.Println("No variables match. Nothing to delete.")
+		return
+	}
+	writePlan(output.FormatTable, plan)
+	fmt.Println()
+
+	if readOnlyMode {
+		fmt.Println("Read only mode enabled. No variables will be deleted.")
+		return
+	}
+
+	if !autoApprove {
+		fmt.Printf("Do you want to delete these %d variables?\n\n", len(plan.Changes))
+		yes, err := awaitUserResponse()
+		if err != nil {
+			errLog.Fatalln(err)
+		}
+		if !yes {
+			fmt.Println("No variables deleted.")
+			return
+		}
+	}
+
+	if err := client.ApplyManifestPlanContext(ctx, plan, os.Stdout); err != nil {
+		errLog.Fatalln(err)
+	}
+	fmt.Printf("Deleted %d variables\n", len(plan.Changes))
+}
+
+func deleteWorkspaceVar(ctx context.Context, org, ws, key string) bool {
+	v, err := client.GetWorkspaceVarContext(ctx, org, ws, key)
 	if err != nil {
 		println(err.Error())
 		return false
@@ -71,7 +136,9 @@ func deleteWorkspaceVar(org, ws, key string) bool {
 
 	fmt.Printf("Deleting variable %s from workspace %s\n", v.Key, ws)
 	if !readOnlyMode {
-		lib.DeleteVariable(v.ID)
+		if err := client.DeleteVariableContext(ctx, v.ID); err != nil {
+			errLog.Fatalln(err)
+		}
 	}
 	return true
 }
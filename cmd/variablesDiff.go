@@ -0,0 +1,100 @@
+// Copyright © 2018-2022 SIL International
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/silinternational/tfc-ops/v4/lib"
+	"github.com/silinternational/tfc-ops/v4/output"
+)
+
+var (
+	diffFrom string
+	diffTo   string
+)
+
+var variablesDiffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Compare the variables of two workspaces",
+	Long: `Report the variables that are only in one of two workspaces, and the differences in value, category, HCL,
+sensitive and description of the others. The values of sensitive variables can't be read, so they are reported as
+unknown. Exits with status 1 if there are any differences.`,
+	Args: cobra.ExactArgs(0),
+	Run: func(cmd *cobra.Command, args []string) {
+		runVariablesDiff(cmd.Context(), getOutputFormat())
+	},
+}
+
+func init() {
+	variablesCmd.AddCommand(variablesDiffCmd)
+	variablesDiffCmd.Flags().StringVar(&diffFrom, "from", "",
+		requiredPrefix+`Workspace to compare from, as "organization/workspace", or just "workspace" in the organization given by --organization`)
+	variablesDiffCmd.Flags().StringVar(&diffTo, "to", "",
+		requiredPrefix+`Workspace to compare to, as "organization/workspace", or just "workspace" in the organization given by --organization`)
+	for _, flag := range []string{"from", "to"} {
+		if err := variablesDiffCmd.MarkFlagRequired(flag); err != nil {
+			errLog.Fatalf("failed to mark '%s' as a required flag on variables diff: %s", flag, err)
+		}
+	}
+	addOutputFlag(variablesDiffCmd)
+}
+
+func runVariablesDiff(ctx context.Context, format output.Format) {
+	fromOrg, fromWs := splitWorkspacePath(diffFrom)
+	toOrg, toWs := splitWorkspacePath(diffTo)
+
+	diffs, err := client.DiffWorkspaceVariablesContext(ctx, fromOrg, fromWs, toOrg, toWs)
+	if err != nil {
+		errLog.Fatalf("error comparing variables: %s", err)
+	}
+
+	if format == output.FormatTable && len(diffs) == 0 {
+		fmt.Printf("No differences between the variables of %s/%s and %s/%s.\n", fromOrg, fromWs, toOrg, toWs)
+		return
+	}
+
+	rows := make([][]string, len(diffs))
+	for i, d := range diffs {
+		rows[i] = []string{d.Key, d.Category, d.Status, strings.Join(d.Changes, ", ")}
+	}
+	records := diffs
+	if records == nil {
+		records = []lib.VariableDiff{}
+	}
+	writeOutput(format, output.Table{
+		Columns: []string{"key", "category", "status", "changes"},
+		Rows:    rows,
+		Records: records,
+	})
+
+	if lib.HasVariableDifferences(diffs) {
+		os.Exit(1)
+	}
+}
+
+// splitWorkspacePath splits "organization/workspace" into its parts. If there is no organization, the one given by
+// --organization is used.
+func splitWorkspacePath(path string) (string, string) {
+	if org, ws, ok := strings.Cut(path, "/"); ok {
+		return org, ws
+	}
+	return organization, path
+}
@@ -0,0 +1,123 @@
+// Copyright © 2024 SIL International
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/silinternational/tfc-ops/v4/lib"
+	"github.com/silinternational/tfc-ops/v4/output"
+)
+
+var variablesEffectiveCmd = &cobra.Command{
+	Use:   "effective",
+	Short: "Show the variables that runs in a workspace receive",
+	Long: `Merge the variables of the workspace given by --workspace with those of the variable sets applied to it,
+directly, through its project or globally. Each variable is shown with the value that runs receive and where it
+comes from. The values it overrides are listed beneath it, on rows with no key, in order of precedence.
+
+Priority variable sets override workspace variables, which override the other variable sets. Variable sets applied
+to the workspace override those applied to its project, which override global variable sets. Between variable sets
+of the same kind, the one with the lexically first name is used.`,
+	Args: cobra.ExactArgs(0),
+	Run: func(cmd *cobra.Command, args []string) {
+		runVariablesEffective(cmd.Context(), getOutputFormat())
+	},
+}
+
+func init() {
+	variablesCmd.AddCommand(variablesEffectiveCmd)
+	addOutputFlag(variablesEffectiveCmd)
+}
+
+// effectiveValue is one value of a variable in the output of `variables effective`
+type effectiveValue struct {
+	Value     string `json:"value" yaml:"value"`
+	Sensitive bool   `json:"sensitive" yaml:"sensitive"`
+	HCL       bool   `json:"hcl" yaml:"hcl"`
+	Source    string `json:"source" yaml:"source"`
+	Scope     string `json:"scope,omitempty" yaml:"scope,omitempty"`
+	Priority  bool   `json:"priority,omitempty" yaml:"priority,omitempty"`
+}
+
+// effectiveVar is one variable in the output of `variables effective`
+type effectiveVar struct {
+	Key            string `json:"key" yaml:"key"`
+	Category       string `json:"category" yaml:"category"`
+	effectiveValue `yaml:",inline"`
+	Shadowed       []effectiveValue `json:"shadowed,omitempty" yaml:"shadowed,omitempty"`
+}
+
+func runVariablesEffective(ctx context.Context, format output.Format) {
+	if workspace == "" {
+		errLog.Fatal("No workspace specified")
+	}
+
+	vars, err := client.GetEffectiveVariablesContext(ctx, organization, workspace)
+	if err != nil {
+		errLog.Fatalln(err)
+	}
+
+	records := []effectiveVar{}
+	var rows [][]string
+	for _, v := range vars {
+		record := effectiveVar{Key: v.Key, Category: v.Category}
+		for i, value := range v.Values {
+			ev := effectiveValue{
+				Value:     value.Value,
+				Sensitive: value.Sensitive,
+				HCL:       value.Hcl,
+				Source:    value.Source(),
+				Scope:     value.Scope,
+				Priority:  value.Priority,
+			}
+
+			key, category := "", ""
+			if i == 0 {
+				record.effectiveValue = ev
+				key, category = v.Key, v.Category
+			} else {
+				record.Shadowed = append(record.Shadowed, ev)
+			}
+			val := value.Value
+			if value.Sensitive {
+				val = "(sensitive)"
+			}
+			rows = append(rows, []string{key, category, val, ev.Source, effectiveScope(value)})
+		}
+		records = append(records, record)
+	}
+
+	writeOutput(format, output.Table{
+		Columns: []string{"key", "category", "value", "source", "set scope"},
+		Rows:    rows,
+		Records: records,
+	})
+}
+
+// effectiveScope describes the scope of the variable set a value comes from, e.g. "project, priority"
+func effectiveScope(v lib.VariableValue) string {
+	if v.VariableSet == "" {
+		return ""
+	}
+	scope := []string{v.Scope}
+	if v.Priority {
+		scope = append(scope, "priority")
+	}
+	return strings.Join(scope, ", ")
+}
@@ -0,0 +1,87 @@
+// Copyright © 2024 SIL International
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/silinternational/tfc-ops/v4/lib"
+)
+
+var (
+	variableFormat     string
+	variablesOutFile   string
+	exportPlaceholders bool
+)
+
+var variablesExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Write the variables of a workspace to a file",
+	Long: `Write the terraform variables of a workspace as a tfvars file, the environment variables as a dotenv file,
+or all the variables with their settings as JSON. The values of sensitive variables can't be read, so they are
+left out, or given the placeholder "` + lib.PlaceholderValue + `" with --placeholders.`,
+	Args: cobra.ExactArgs(0),
+	Run: func(cmd *cobra.Command, args []string) {
+		runVariablesExport(cmd.Context())
+	},
+}
+
+func init() {
+	variablesCmd.AddCommand(variablesExportCmd)
+	variablesExportCmd.Flags().StringVar(&variableFormat, "format", lib.VariableFormatTFVars,
+		"Format of the file, one of: "+strings.Join(lib.VariableFormats, ", "))
+	variablesExportCmd.Flags().StringVar(&variablesOutFile, "out", "",
+		"File to write the variables to, instead of stdout")
+	variablesExportCmd.Flags().BoolVar(&exportPlaceholders, "placeholders", false,
+		"Write sensitive variables with a placeholder value, instead of as a comment")
+}
+
+func runVariablesExport(ctx context.Context) {
+	if workspace == "" {
+		errLog.Fatal("No workspace specified")
+	}
+
+	vars, err := client.GetVarsFromWorkspaceContext(ctx, organization, workspace)
+	if err != nil {
+		errLog.Fatalln(err)
+	}
+
+	var w io.Writer = os.Stdout
+	var f *os.File
+	if variablesOutFile != "" {
+		f, err = os.Create(variablesOutFile)
+		if err != nil {
+			errLog.Fatalf("error creating %s: %s", variablesOutFile, err)
+		}
+		w = f
+	}
+
+	if err := lib.WriteVariables(w, variableFormat, vars, exportPlaceholders); err != nil {
+		if f != nil {
+			_ = f.Close()
+		}
+		errLog.Fatalf("error exporting variables of workspace %s: %s", workspace, err)
+	}
+	if f != nil {
+		if err := f.Close(); err != nil {
+			errLog.Fatalf("error writing %s: %s", variablesOutFile, err)
+		}
+	}
+}
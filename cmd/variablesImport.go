@@ -0,0 +1,117 @@
+// Copyright © 2024 SIL International
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/silinternational/tfc-ops/v4/lib"
+	"github.com/silinternational/tfc-ops/v4/output"
+)
+
+var (
+	variablesInFile string
+	importFormat    string
+)
+
+var variablesImportCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Create or update the variables of a workspace from a file",
+	Long: `Read variables from a tfvars, dotenv or JSON file, as written by 'variables export', and create or update
+them in a workspace. Variables in a tfvars file are terraform variables, and those in a dotenv file are
+environment variables. Variables in the workspace that are not in the file are left unchanged. The changes are
+listed first and must be confirmed unless --auto-approve is given.`,
+	Args: cobra.ExactArgs(0),
+	Run: func(cmd *cobra.Command, args []string) {
+		runVariablesImport(cmd.Context())
+	},
+}
+
+func init() {
+	variablesCmd.AddCommand(variablesImportCmd)
+	variablesImportCmd.Flags().StringVarP(&variablesInFile, "file", "f", "",
+		requiredPrefix+"File to read the variables from")
+	if err := variablesImportCmd.MarkFlagRequired("file"); err != nil {
+		errLog.Fatalf("failed to mark 'file' as a required flag on variables import: %s", err)
+	}
+	variablesImportCmd.Flags().StringVar(&importFormat, "format", "",
+		"Format of the file, one of: "+strings.Join(lib.VariableFormats, ", ")+
+			`. By default, the format is given by the file extension, e.g. ".tfvars"`)
+	variablesImportCmd.Flags().BoolVar(&autoApprove, "auto-approve", false,
+		"Make the changes without asking for confirmation")
+}
+
+func runVariablesImport(ctx context.Context) {
+	if workspace == "" {
+		errLog.Fatal("No workspace specified")
+	}
+
+	format := importFormat
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(variablesInFile)), ".")
+	}
+
+	f, err := os.Open(variablesInFile)
+	if err != nil {
+		errLog.Fatalf("error opening variables file: %s", err)
+	}
+	vars, err := lib.ReadVariables(f, format)
+	_ = f.Close()
+	if err != nil {
+		errLog.Fatalf("error reading %s: %s", variablesInFile, err)
+	}
+
+	plan, noValue, err := client.PlanVariableImportContext(ctx, organization, workspace, format, vars)
+	if err != nil {
+		errLog.Fatalln(err)
+	}
+	if len(noValue) > 0 {
+		fmt.Printf("Variables with no value in %s, which are not imported: %s\n\n", variablesInFile, strings.Join(noValue, ", "))
+	}
+	if len(plan.Changes) == 0 {
+		fmt.Printf("No changes. The variables of %s match the file.\n", workspace)
+		return
+	}
+	writePlan(output.FormatTable, plan)
+	fmt.Println()
+
+	if readOnlyMode {
+		fmt.Println("Read only mode enabled. No changes will be made.")
+		return
+	}
+
+	if !autoApprove {
+		fmt.Printf("Do you want to make these %d changes?\n\n", len(plan.Changes))
+		yes, err := awaitUserResponse()
+		if err != nil {
+			errLog.Fatalln(err)
+		}
+		if !yes {
+			fmt.Println("No changes made.")
+			return
+		}
+	}
+
+	if err := client.ApplyManifestPlanContext(ctx, plan, os.Stdout); err != nil {
+		errLog.Fatalln(err)
+	}
+	fmt.Printf("Applied %d changes\n", len(plan.Changes))
+}
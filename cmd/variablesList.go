@@ -15,14 +15,15 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
-	"strings"
-	"text/tabwriter"
+	"strconv"
 
 	"github.com/spf13/cobra"
 
 	api "github.com/silinternational/tfc-ops/v4/lib"
+	"github.com/silinternational/tfc-ops/v4/output"
 )
 
 var (
@@ -34,29 +35,51 @@ var (
 var variablesListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "Report on variables",
-	Long:  `Show the values of variables with a key or value containing a certain string`,
-	Args:  cobra.ExactArgs(0),
+	Long: `Show the values of variables with a key or value containing a certain string, or matching a regular
+expression, in the workspace given by --workspace, in the workspaces matching it if it is a glob pattern (e.g.
+"app-*"), or in all workspaces. The variables can also be selected by category, sensitive and HCL.`,
+	Args: cobra.ExactArgs(0),
 	Run: func(cmd *cobra.Command, args []string) {
-		if len(keyContains) == 0 && len(valueContains) == 0 {
-			fmt.Println("Error: Either the 'key_contains' flag or 'value_contains flag must be set")
+		if len(keyContains) == 0 && len(valueContains) == 0 && !hasVariableMatchFlags(cmd) {
+			fmt.Println("Error: Either the 'key_contains' flag, 'value_contains' flag or one of the other variable selection flags must be set")
 			fmt.Println("")
 			os.Exit(1)
 		}
+		match := variableMatch(cmd, api.VariableMatch{KeyContains: keyContains, ValueContains: valueContains})
 
 		if tabularCSV {
-			fmt.Println("workspace,key,value")
-		} else {
+			outputFormat = string(output.FormatCSV)
+		}
+		format := getOutputFormat()
+
+		if format == output.FormatTable {
 			keyMsg := ""
 			valMsg := ""
 
 			if keyContains != "" {
 				keyMsg = " key containing " + keyContains
 			}
+			if keyRegex != "" {
+				if keyMsg != "" {
+					keyMsg += " and"
+				}
+				keyMsg += " key matching " + keyRegex
+			}
 
 			if valueContains != "" {
 				valMsg = " value containing " + valueContains
-				if keyContains != "" {
-					valMsg = " or value containing " + valueContains
+			}
+			if valueRegex != "" {
+				if valMsg != "" {
+					valMsg += " and"
+				}
+				valMsg += " value matching " + valueRegex
+			}
+			if keyMsg != "" && valMsg != "" {
+				if match.Any {
+					valMsg = " or" + valMsg
+				} else {
+					valMsg = " and" + valMsg
 				}
 			}
 
@@ -64,11 +87,9 @@ var variablesListCmd = &cobra.Command{
 			if wsMsg == "" {
 				wsMsg = "all workspaces"
 			}
-			if !tabularCSV {
-				fmt.Printf("Getting variables from %s with%s%s\n", wsMsg, keyMsg, valMsg)
-			}
+			fmt.Printf("Getting variables from %s with%s%s\n", wsMsg, keyMsg, valMsg)
 		}
-		runVariablesList()
+		runVariablesList(cmd.Context(), format, match)
 	},
 }
 
@@ -80,73 +101,67 @@ func init() {
 		"required if key_contains is blank - string contained in the Terraform variable values to report on")
 	variablesListCmd.Flags().BoolVar(&tabularCSV, "csv", false,
 		"output variable list in CSV format")
+	_ = variablesListCmd.Flags().MarkDeprecated("csv", "use --output csv instead")
+	addVariableMatchFlags(variablesListCmd, "any", true)
+	addOutputFlag(variablesListCmd)
+}
+
+// workspaceVar is one variable in the output of `variables list`
+type workspaceVar struct {
+	Workspace string `json:"workspace" yaml:"workspace"`
+	Key       string `json:"key" yaml:"key"`
+	Value     string `json:"value" yaml:"value"`
+	Sensitive bool   `json:"sensitive" yaml:"sensitive"`
+	Category  string `json:"category" yaml:"category"`
+	HCL       bool   `json:"hcl" yaml:"hcl"`
 }
 
-func runVariablesList() {
-	if workspace != "" {
-		vars, err := api.SearchVariables(organization, workspace, keyContains, valueContains)
+func runVariablesList(ctx context.Context, format output.Format, match api.VariableMatch) {
+	names := matchingWorkspaces(ctx)
+	if len(names) == 1 && workspace != "" {
+		vars, err := client.FindVariablesContext(ctx, organization, names[0], match)
 		if err != nil {
-			println(err.Error())
-			return
+			errLog.Fatalln(err)
 		}
-		printWorkspaceVars(workspace, vars)
-		return
-	}
-	allData, err := api.GetAllWorkspaces(organization)
-	if err != nil {
-		println(err.Error())
+		writeWorkspaceVars(format, names, map[string][]api.Var{names[0]: vars})
 		return
 	}
 
-	wsVars, err := api.SearchVarsInAllWorkspaces(allData, organization, keyContains, valueContains)
+	wsVars, err := client.FindVarsInWorkspacesContext(ctx, organization, names, match)
+	writeWorkspaceVars(format, names, wsVars)
 	if err != nil {
-		println(err.Error())
-		return
-	}
-
-	for ws, vs := range wsVars {
-		printWorkspaceVars(ws, vs)
+		exitIncomplete(err)
 	}
-	println()
-	return
 }
 
-func printWorkspaceVars(ws string, vs []api.Var) {
-	if len(vs) == 0 {
-		return
-	}
-	if tabularCSV {
-		printWorkspaceVarsCSV(ws, vs)
-		return
-	}
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', tabwriter.Debug)
-	println()
-	fmt.Printf("Workspace: %s has %v matching variable(s)\n", ws, len(vs))
-	fmt.Fprintln(w, "Key \t Value \t Sensitive")
-	for _, v := range vs {
-		sens := ""
-		if v.Sensitive {
-			sens = "(sensitive)"
-		}
-		fmt.Fprintf(w, "%s \t %s \t %s\n", v.Key, v.Value, sens)
-	}
-	println()
-	w.Flush()
-}
-
-func printWorkspaceVarsCSV(ws string, vs []api.Var) {
-	for _, v := range vs {
-		val := v.Value
-		if v.Sensitive {
-			val = "(sensitive)"
+// writeWorkspaceVars writes the variables of each of the named workspaces, in order
+func writeWorkspaceVars(format output.Format, wsNames []string, wsVars map[string][]api.Var) {
+	records := []workspaceVar{}
+	var rows [][]string
+	for _, ws := range wsNames {
+		for _, v := range wsVars[ws] {
+			records = append(records, workspaceVar{
+				Workspace: ws,
+				Key:       v.Key,
+				Value:     v.Value,
+				Sensitive: v.Sensitive,
+				Category:  v.Category,
+				HCL:       v.Hcl,
+			})
+
+			val := v.Value
+			if v.Sensitive {
+				val = "(sensitive)"
+			}
+			rows = append(rows, []string{
+				ws, v.Key, val, strconv.FormatBool(v.Sensitive), v.Category, strconv.FormatBool(v.Hcl),
+			})
 		}
-		fmt.Printf(`"%s","%s","%s"`+"\n", escapeString(ws), escapeString(v.Key), escapeString(val))
 	}
-}
 
-// escapeString escapes characters for CSV encoding, adding a backslash before a double-quote, and converting
-// a newline to `\n`
-func escapeString(s string) string {
-	tmp := strings.Replace(s, `"`, `\"`, -1)
-	return strings.Replace(tmp, "\n", `\n`, -1)
+	writeOutput(format, output.Table{
+		Columns: []string{"workspace", "key", "value", "sensitive", "category", "hcl"},
+		Rows:    rows,
+		Records: records,
+	})
 }
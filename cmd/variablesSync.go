@@ -0,0 +1,121 @@
+// Copyright © 2018-2022 SIL International
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/silinternational/tfc-ops/v4/lib"
+	"github.com/silinternational/tfc-ops/v4/output"
+)
+
+var syncConfig lib.VariableSyncConfig
+
+var variablesSyncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Make the variables of workspaces match another workspace",
+	Long: `Create and update variables in the target workspace, or in each workspace matching --workspace-filter, so
+that they match the variables of the source workspace. With --prune, variables that are not in the source are
+deleted. The changes are listed first and must be confirmed unless --auto-approve is given. The values of
+sensitive variables can't be read, so they are not copied.`,
+	Args: cobra.ExactArgs(0),
+	Run: func(cmd *cobra.Command, args []string) {
+		runVariablesSync(cmd.Context())
+	},
+}
+
+func init() {
+	variablesCmd.AddCommand(variablesSyncCmd)
+	variablesSyncCmd.Flags().StringVar(&diffFrom, "from", "",
+		requiredPrefix+`Source workspace, as "organization/workspace", or just "workspace" in the organization given by --organization`)
+	if err := variablesSyncCmd.MarkFlagRequired("from"); err != nil {
+		errLog.Fatalf("failed to mark 'from' as a required flag on variables sync: %s", err)
+	}
+	variablesSyncCmd.Flags().StringVar(&diffTo, "to", "",
+		`Target workspace in the organization given by --organization, required if --workspace-filter is not given`)
+	variablesSyncCmd.Flags().StringVar(&workspaceFilter, "workspace-filter", "",
+		"Partial workspace name to search across all workspaces, to use every matching workspace as a target")
+	variablesSyncCmd.Flags().StringSliceVar(&syncConfig.Only, "only", nil,
+		`Only sync variables with a key matching one of these glob patterns (e.g. "aws_*")`)
+	variablesSyncCmd.Flags().StringSliceVar(&syncConfig.Exclude, "exclude", nil,
+		"Don't sync variables with a key matching one of these glob patterns")
+	variablesSyncCmd.Flags().BoolVar(&syncConfig.Prune, "prune", false,
+		"Delete variables in the targets that are not in the source")
+	variablesSyncCmd.Flags().BoolVar(&autoApprove, "auto-approve", false,
+		"Make the changes without asking for confirmation")
+}
+
+func runVariablesSync(ctx context.Context) {
+	if diffTo == "" && workspaceFilter == "" {
+		errLog.Fatalln("Either --to or --workspace-filter must be specified.")
+	}
+	sourceOrg, source := splitWorkspacePath(diffFrom)
+
+	var targets []string
+	if diffTo != "" {
+		targets = []string{diffTo}
+	} else {
+		_, names := stringMapToSlice(selectWorkspaces(ctx))
+		for _, name := range names {
+			if sourceOrg != organization || name != source {
+				targets = append(targets, name)
+			}
+		}
+	}
+
+	plan, skipped, err := client.PlanVariableSyncContext(ctx, sourceOrg, source, organization, targets, syncConfig)
+	if err != nil {
+		exitIncomplete(err)
+	}
+	if len(skipped) > 0 {
+		fmt.Println("Sensitive variables that can't be copied, because their values can't be read:")
+		for _, s := range skipped {
+			fmt.Println("  " + s)
+		}
+		fmt.Println()
+	}
+	if len(plan.Changes) == 0 {
+		fmt.Println("No changes. The variables match the source workspace.")
+		return
+	}
+	writePlan(output.FormatTable, plan)
+	fmt.Println()
+
+	if readOnlyMode {
+		fmt.Println("Read only mode enabled. No changes will be made.")
+		return
+	}
+
+	if !autoApprove {
+		fmt.Printf("Do you want to make these %d changes?\n\n", len(plan.Changes))
+		yes, err := awaitUserResponse()
+		if err != nil {
+			errLog.Fatalln(err)
+		}
+		if !yes {
+			fmt.Println("No changes made.")
+			return
+		}
+	}
+
+	if err := client.ApplyManifestPlanContext(ctx, plan, os.Stdout); err != nil {
+		errLog.Fatalln(err)
+	}
+	fmt.Printf("Applied %d changes\n", len(plan.Changes))
+}
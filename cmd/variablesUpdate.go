@@ -1,9 +1,10 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
-	"strings"
 
 	"github.com/manifoldco/promptui"
 	"github.com/spf13/cobra"
@@ -24,26 +25,56 @@ var (
 var updateCmd = &cobra.Command{
 	Use:   "update",
 	Short: "Update/add a variable in a Workspace",
-	Long:  `Update or add a variable in a Terraform Cloud Workspace based on a complete case-insensitive match`,
-	Args:  cobra.ExactArgs(0),
+	Long: `Update or add a variable in a Terraform Cloud Workspace based on a complete case-insensitive match. Instead
+of a search string, the variables can be selected with a regular expression on the key or value, and by category,
+sensitive and HCL; then every matching variable is updated. The --workspace flag may be a glob pattern (e.g.
+"app-*") to update the variables of each matching workspace.`,
+	Args: cobra.ExactArgs(0),
 	Run: func(cmd *cobra.Command, args []string) {
 		if addKeyIfNotFound && searchOnVariableValue {
 			fmt.Println("Error: The 'add-key-if-not-found' flag may not be used with the 'search-on-variable-value' flag")
 			os.Exit(1)
 		}
+		hasMatch := hasVariableMatchFlags(cmd)
+		if variableSearchString == "" && !hasMatch {
+			fmt.Println("Error: Either the 'variable-search-string' flag or one of the variable selection flags must be set")
+			os.Exit(1)
+		}
+		if variableSearchString != "" && hasMatch {
+			fmt.Println("Error: The 'variable-search-string' flag may not be used with the variable selection flags")
+			os.Exit(1)
+		}
+		if addKeyIfNotFound && hasMatch {
+			fmt.Println("Error: The 'add-key-if-not-found' flag may not be used with the variable selection flags")
+			os.Exit(1)
+		}
 		config := lib.UpdateConfig{
 			Organization:          organization,
 			Workspace:             workspace,
 			SearchString:          variableSearchString,
-			NewValue:              newVariableValue,
+			NewValue:              variableValue(cmd, "new-variable-value", newVariableValue),
 			SearchOnVariableValue: searchOnVariableValue,
 			AddKeyIfNotFound:      addKeyIfNotFound,
 			SensitiveVariable:     sensitiveVariable,
+			Category:              variableCategory,
+			Description:           variableDescription,
+		}
+		if cmd.Flags().Changed("hcl") {
+			config.Hcl = &variableHcl
 		}
-		if workspace == "" {
-			runVariablesUpdateAll(config)
-		} else {
-			runVariablesUpdate(config)
+		if hasMatch {
+			// --category defaults to "terraform", so it only restricts the selection if it is given
+			var m lib.VariableMatch
+			if cmd.Flags().Changed("category") {
+				m.Category = variableCategory
+			}
+			match := variableMatch(cmd, m)
+			config.Match = &match
+		}
+		if workspace == "" || lib.IsWorkspacePattern(workspace) {
+			runVariablesUpdateAll(cmd.Context(), config)
+		} else if err := runVariablesUpdate(cmd.Context(), config); err != nil {
+			errLog.Fatalln(err)
 		}
 	},
 }
@@ -55,14 +86,14 @@ func init() {
 		"variable-search-string",
 		"s",
 		"",
-		requiredPrefix+`The string to match in the current variables (either in the Key or Value - see other flags)`,
+		`The string to match in the current variables (either in the Key or Value - see other flags), required unless a variable selection flag is given`,
 	)
 	updateCmd.Flags().StringVarP(
 		&newVariableValue,
 		"new-variable-value",
 		"n",
 		"",
-		requiredPrefix+`The desired new value of the variable`,
+		`The desired new value of the variable, required unless --value-file is given`,
 	)
 	updateCmd.Flags().BoolVarP(
 		&addKeyIfNotFound,
@@ -85,19 +116,14 @@ func init() {
 		false,
 		`optional (e.g. "-x=true") make the variable sensitive.`,
 	)
-	if err := updateCmd.MarkFlagRequired("variable-search-string"); err != nil {
-		errLog.Fatalln(err)
-	}
-	if err := updateCmd.MarkFlagRequired("new-variable-value"); err != nil {
-		errLog.Fatalln(err)
-	}
+	addVariableSettingFlags(updateCmd)
+	addVariableMatchFlags(updateCmd, "all", false)
 }
 
-func runVariablesUpdate(cfg lib.UpdateConfig) {
+func runVariablesUpdate(ctx context.Context, cfg lib.UpdateConfig) error {
 	if cfg.AddKeyIfNotFound {
 		if cfg.SearchOnVariableValue {
-			println("update variable aborted. Because addKeyIfNotFound was true, searchOnVariableValue must be set to false")
-			return
+			return errors.New("update variable aborted. Because addKeyIfNotFound was true, searchOnVariableValue must be set to false")
 		}
 		cfg.SearchOnVariableValue = false
 	}
@@ -108,46 +134,51 @@ func runVariablesUpdate(cfg lib.UpdateConfig) {
 	fmt.Printf("update variable called using %s, %s, search string: %s, new value: %s, add-key-if-not-found: %t, search-on-variable-value: %t\n",
 		cfg.Organization, cfg.Workspace, cfg.SearchString, cfg.NewValue, cfg.AddKeyIfNotFound, cfg.SearchOnVariableValue)
 
-	message, err := lib.AddOrUpdateVariable(cfg)
+	message, err := client.AddOrUpdateVariableContext(ctx, cfg)
 	if err != nil {
-		fmt.Println(err.Error())
-		return
+		return err
 	}
 
 	println("\n  **** Completed Updating ****")
 	println(message)
+	return nil
 }
 
-func runVariablesUpdateAll(cfg lib.UpdateConfig) {
-	allData, err := lib.GetAllWorkspaces(organization)
-	for _, ws := range allData {
-		value, err := ws.AttributeByLabel(strings.Trim("name", " "))
-		fmt.Printf("Do you want to update the variable %s across the workspace: %s\n\n", variableSearchString, value)
-		if awaitUserResponse() {
-			cfg.Workspace = value
-			runVariablesUpdate(cfg)
-
-			if err != nil {
-				fmt.Println("\n", err.Error())
-				return
-			}
-		}
+func runVariablesUpdateAll(ctx context.Context, cfg lib.UpdateConfig) {
+	names := matchingWorkspaces(ctx)
+	search := variableSearchString
+	if cfg.Match != nil {
+		search = "matching the selection flags"
 	}
 
-	if err != nil {
-		fmt.Println(err.Error())
-		return
+	for i, name := range names {
+		fmt.Printf("Do you want to update the variable %s across the workspace: %s\n\n", search, name)
+		yes, err := awaitUserResponse()
+		if err == nil && yes {
+			cfg.Workspace = name
+			err = runVariablesUpdate(ctx, cfg)
+		}
+		if err == nil {
+			err = ctx.Err()
+		}
+		if err != nil {
+			exitIncomplete(&lib.IncompleteError{Processed: names[:i], Remaining: names[i:], Err: err})
+		}
 	}
 }
 
-func awaitUserResponse() bool {
+// awaitUserResponse prompts for a Yes or No answer. Interrupting the prompt returns context.Canceled.
+func awaitUserResponse() (bool, error) {
 	prompt := promptui.Select{
 		Label: "Select[Yes/No]",
 		Items: []string{"No", "Yes"},
 	}
 	_, result, err := prompt.Run()
+	if errors.Is(err, promptui.ErrInterrupt) {
+		return false, context.Canceled
+	}
 	if err != nil {
 		errLog.Fatalf("Prompt failed %v\n", err)
 	}
-	return result == "Yes"
+	return result == "Yes", nil
 }
@@ -15,16 +15,27 @@
 package cmd
 
 import (
+	"context"
+
 	"github.com/spf13/cobra"
+
+	"github.com/silinternational/tfc-ops/v4/lib"
 )
 
-var variableSet string
+var (
+	variableSet       string
+	varsetNewName     string
+	varsetDescription string
+	varsetGlobal      bool
+	varsetPriority    bool
+	varsetProject     string
+)
 
 // varsetsCmd represents the top level command for varsets
 var varsetsCmd = &cobra.Command{
 	Use:   "varsets",
 	Short: "Commands for Variable Sets",
-	Long:  "Top level command for actions on Variable Sets",
+	Long:  "Top level command for creating, changing, showing and applying Variable Sets",
 	Args:  cobra.MinimumNArgs(1),
 }
 
@@ -32,3 +43,69 @@ func init() {
 	rootCmd.AddCommand(varsetsCmd)
 	addGlobalFlags(varsetsCmd)
 }
+
+// addVariableSetNameFlag adds the required --set flag, which names a variable set
+func addVariableSetNameFlag(command *cobra.Command) {
+	command.Flags().StringVarP(&variableSet, "set", "s", "",
+		requiredPrefix+"Name of the variable set")
+	if err := command.MarkFlagRequired("set"); err != nil {
+		errLog.Fatalf("failed to mark 'set' as a required flag on %s: %s", command.Name(), err)
+	}
+}
+
+// addVariableSetFlags adds the flags that set the attributes of a variable set
+func addVariableSetFlags(command *cobra.Command) {
+	command.Flags().StringVar(&varsetDescription, "description", "",
+		"Description of the variable set")
+	command.Flags().BoolVar(&varsetGlobal, "global", false,
+		`Apply the variable set to all workspaces in the organization (e.g. "--global=false" to stop)`)
+	command.Flags().BoolVar(&varsetPriority, "priority", false,
+		"Let the variables in the set override workspace variables with the same key")
+}
+
+// variableSetConfig returns the attributes of a variable set given by the flags of command that are set
+func variableSetConfig(command *cobra.Command) lib.VariableSetConfig {
+	var cfg lib.VariableSetConfig
+	if command.Flags().Changed("description") {
+		cfg.Description = &varsetDescription
+	}
+	if command.Flags().Changed("global") {
+		cfg.Global = &varsetGlobal
+	}
+	if command.Flags().Changed("priority") {
+		cfg.Priority = &varsetPriority
+	}
+	return cfg
+}
+
+// getVariableSet returns the variable set with the given name in the organization. It exits if there is none.
+func getVariableSet(ctx context.Context, name string) *lib.VariableSet {
+	vs, err := client.GetVariableSetContext(ctx, organization, name)
+	if err != nil {
+		errLog.Fatalf("Error retrieving variable set: %s", err)
+	}
+	if vs == nil {
+		errLog.Fatalf("No variable set matches the name given (%s)", name)
+	}
+	return vs
+}
+
+// addVariableSetTargetFlags adds the flags that select the workspaces or project a variable set is applied to or
+// removed from
+func addVariableSetTargetFlags(command *cobra.Command) {
+	command.Flags().StringVarP(&workspace, "workspace", "w", "",
+		"Name of the Workspace in Terraform Cloud")
+	command.Flags().StringVar(&workspaceFilter, "workspace-filter", "",
+		"Partial workspace name to search across all workspaces")
+	command.Flags().StringVar(&varsetProject, "project", "",
+		"Name of a project, to act on all of its workspaces")
+}
+
+// getProjectID returns the ID of the project given by the --project flag. It exits if there is none.
+func getProjectID(ctx context.Context) string {
+	id, err := client.GetProjectIDContext(ctx, organization, varsetProject)
+	if err != nil {
+		errLog.Fatalf("error getting project %q: %s", varsetProject, err)
+	}
+	return id
+}
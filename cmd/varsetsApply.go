@@ -15,20 +15,19 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/spf13/cobra"
-
-	"github.com/silinternational/tfc-ops/v4/lib"
 )
 
 var varsetsApplyCmd = &cobra.Command{
 	Use:   "apply",
 	Short: "Apply Variable Set to Workspaces",
-	Long:  `Apply an existing variable set to workspaces`,
+	Long:  `Apply an existing variable set to workspaces, or to all the workspaces in a project`,
 	Args:  cobra.ExactArgs(0),
 	Run: func(cmd *cobra.Command, args []string) {
-		runVarsetsApply(variableSet)
+		runVarsetsApply(cmd.Context(), variableSet)
 	},
 }
 
@@ -41,53 +40,36 @@ func init() {
 		errLog.Fatalln("failed to mark 'set' as a required flag on varsetsApplyCmd")
 	}
 
-	varsetsApplyCmd.Flags().StringVarP(&workspace, "workspace", "w", "",
-		"Name of the Workspace in Terraform Cloud")
-
-	varsetsApplyCmd.Flags().StringVar(&workspaceFilter, "workspace-filter", "",
-		"Partial workspace name to search across all workspaces")
+	addVariableSetTargetFlags(varsetsApplyCmd)
 }
 
-func runVarsetsApply(name string) {
+func runVarsetsApply(ctx context.Context, name string) {
 	if readOnlyMode {
 		fmt.Println("Read only mode enabled. No variable set will be applied.")
 	}
 
-	if workspace == "" && workspaceFilter == "" {
-		errLog.Fatalln("Either --workspace or --workspace-filter must be specified.")
-	}
-
-	var workspaceNames map[string]string
-	if workspace != "" {
-		w, err := lib.GetWorkspaceByName(organization, workspace)
-		if err != nil {
-			errLog.Fatalf("error getting workspace from Terraform: %s", err)
+	if varsetProject != "" {
+		vs := getVariableSet(ctx, name)
+		fmt.Printf("Applying variable set '%s' to project '%s'\n", vs.Attributes.Name, varsetProject)
+		if err := client.ApplyVariableSetToProjectsContext(ctx, vs.ID, []string{getProjectID(ctx)}); err != nil {
+			errLog.Fatalf("Error while applying variable set: %s", err)
 		}
-		workspaceNames = map[string]string{w.ID: workspace}
-	} else {
-		workspaceNames = lib.FindWorkspaces(organization, workspaceFilter)
-		if len(workspaceNames) == 0 {
-			errLog.Fatalf("no workspaces match the filter '%s'", workspaceFilter)
+		if workspace == "" && workspaceFilter == "" {
+			return
 		}
 	}
 
-	_ = applyVariableSet(organization, name, workspaceNames)
+	_ = applyVariableSet(ctx, name, selectWorkspaces(ctx))
 	return
 }
 
-func applyVariableSet(org, vsName string, workspaceNames map[string]string) bool {
-	vs, err := lib.GetVariableSet(org, vsName)
-	if err != nil {
-		errLog.Fatalf("Error retrieving variable set: %s", err)
-	}
-	if vs == nil {
-		errLog.Fatalf("No variable set matches the name given (%s)", vsName)
-	}
+func applyVariableSet(ctx context.Context, vsName string, workspaceNames map[string]string) bool {
+	vs := getVariableSet(ctx, vsName)
 
 	wsIDs, wsNames := stringMapToSlice(workspaceNames)
 
 	fmt.Printf("Applying variable set '%s' to %s\n", vs.Attributes.Name, workspaceListToString(wsNames))
-	if err = lib.ApplyVariableSet(vs.ID, wsIDs); err != nil {
+	if err := client.ApplyVariableSetContext(ctx, vs.ID, wsIDs); err != nil {
 		errLog.Fatalf("Error while applying variable set: %s", err)
 	}
 	return true
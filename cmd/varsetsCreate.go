@@ -0,0 +1,60 @@
+// Copyright © 2024 SIL International
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var varsetsCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a Variable Set",
+	Long:  `Create an empty variable set. Use 'varsets variables add' to add variables and 'varsets apply' to apply it.`,
+	Args:  cobra.ExactArgs(0),
+	Run: func(cmd *cobra.Command, args []string) {
+		runVarsetsCreate(cmd.Context(), cmd)
+	},
+}
+
+func init() {
+	varsetsCmd.AddCommand(varsetsCreateCmd)
+	addVariableSetNameFlag(varsetsCreateCmd)
+	addVariableSetFlags(varsetsCreateCmd)
+}
+
+func runVarsetsCreate(ctx context.Context, cmd *cobra.Command) {
+	if vs, err := client.GetVariableSetContext(ctx, organization, variableSet); err != nil {
+		errLog.Fatalf("Error retrieving variable set: %s", err)
+	} else if vs != nil {
+		errLog.Fatalf("A variable set named '%s' already exists", variableSet)
+	}
+
+	fmt.Printf("Creating variable set '%s'\n", variableSet)
+	if readOnlyMode {
+		fmt.Println("Read only mode enabled. No variable set will be created.")
+		return
+	}
+
+	cfg := variableSetConfig(cmd)
+	cfg.Name = &variableSet
+	vs, err := client.CreateVariableSetContext(ctx, organization, cfg)
+	if err != nil {
+		errLog.Fatalf("Error creating variable set: %s", err)
+	}
+	fmt.Printf("Created variable set '%s' (%s)\n", vs.Attributes.Name, vs.ID)
+}
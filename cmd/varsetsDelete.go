@@ -0,0 +1,67 @@
+// Copyright © 2024 SIL International
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var varsetsDeleteCmd = &cobra.Command{
+	Use:   "delete",
+	Short: "Delete a Variable Set",
+	Long: `Delete a variable set and all the variables in it. It is removed from every workspace it is applied to. The
+deletion must be confirmed unless --auto-approve is given.`,
+	Args: cobra.ExactArgs(0),
+	Run: func(cmd *cobra.Command, args []string) {
+		runVarsetsDelete(cmd.Context())
+	},
+}
+
+func init() {
+	varsetsCmd.AddCommand(varsetsDeleteCmd)
+	addVariableSetNameFlag(varsetsDeleteCmd)
+	varsetsDeleteCmd.Flags().BoolVar(&autoApprove, "auto-approve", false,
+		"Delete the variable set without asking for confirmation")
+}
+
+func runVarsetsDelete(ctx context.Context) {
+	vs := getVariableSet(ctx, variableSet)
+	fmt.Printf("Deleting variable set '%s', which has %d variables and is applied to %d workspaces\n",
+		vs.Attributes.Name, vs.Attributes.VarCount, vs.Attributes.WorkspaceCount)
+	if readOnlyMode {
+		fmt.Println("Read only mode enabled. The variable set will not be deleted.")
+		return
+	}
+
+	if !autoApprove {
+		fmt.Printf("Do you want to delete the variable set %s?\n\n", vs.Attributes.Name)
+		yes, err := awaitUserResponse()
+		if err != nil {
+			errLog.Fatalln(err)
+		}
+		if !yes {
+			fmt.Println("Variable set not deleted.")
+			return
+		}
+	}
+
+	if err := client.DeleteVariableSetContext(ctx, vs.ID); err != nil {
+		errLog.Fatalln(err)
+	}
+	fmt.Println("Deleted variable set")
+}
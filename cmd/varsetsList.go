@@ -15,11 +15,13 @@
 package cmd
 
 import (
-	"fmt"
+	"context"
+	"io"
+	"sync"
 
 	"github.com/spf13/cobra"
 
-	"github.com/silinternational/tfc-ops/v4/lib"
+	"github.com/silinternational/tfc-ops/v4/output"
 )
 
 var varsetsListCmd = &cobra.Command{
@@ -28,7 +30,7 @@ var varsetsListCmd = &cobra.Command{
 	Long:  `List variable sets applied to a workspace`,
 	Args:  cobra.ExactArgs(0),
 	Run: func(cmd *cobra.Command, args []string) {
-		runVarsetsList()
+		runVarsetsList(cmd.Context(), getOutputFormat())
 	},
 }
 
@@ -40,35 +42,58 @@ func init() {
 
 	varsetsListCmd.Flags().StringVar(&workspaceFilter, "workspace-filter", "",
 		"Partial workspace name to search across all workspaces")
+
+	addOutputFlag(varsetsListCmd)
 }
 
-func runVarsetsList() {
-	if workspace == "" && workspaceFilter == "" {
-		errLog.Fatalln("Either --workspace or --workspace-filter must be specified.")
-	}
+// workspaceVarsets is the list of variable sets applied to one workspace in the output of `varsets list`
+type workspaceVarsets struct {
+	Workspace    string   `json:"workspace" yaml:"workspace"`
+	VariableSets []string `json:"variable-sets" yaml:"variable-sets"`
+}
 
-	var workspaces map[string]string
-	if workspace != "" {
-		w, err := lib.GetWorkspaceByName(organization, workspace)
+func runVarsetsList(ctx context.Context, format output.Format) {
+	ids, names := stringMapToSlice(selectWorkspaces(ctx))
+	idByName := make(map[string]string, len(ids))
+	for i, id := range ids {
+		idByName[names[i]] = id
+	}
+	var mu sync.Mutex
+	setsByName := make(map[string][]string, len(names))
+	err := client.ForEachWorkspace(ctx, names, nil, func(ctx context.Context, name string, w io.Writer) error {
+		sets, err := client.ListWorkspaceVariableSetsContext(ctx, idByName[name])
 		if err != nil {
-			errLog.Fatalf("error getting workspace %q from Terraform: %s", workspace, err)
+			return err
 		}
-		workspaces = map[string]string{w.ID: workspace}
-	} else {
-		workspaces = lib.FindWorkspaces(organization, workspaceFilter)
-		if len(workspaces) == 0 {
-			errLog.Fatalf("no workspaces match the filter '%s'", workspaceFilter)
+		setNames := make([]string, len(sets.Data))
+		for i, set := range sets.Data {
+			setNames[i] = set.Attributes.Name
 		}
-	}
+		mu.Lock()
+		defer mu.Unlock()
+		setsByName[name] = setNames
+		return nil
+	})
 
-	for id, name := range workspaces {
-		sets, err := lib.ListWorkspaceVariableSets(id)
-		if err != nil {
-			return
+	records := []workspaceVarsets{}
+	var rows [][]string
+	for _, name := range names {
+		setNames, ok := setsByName[name]
+		if !ok {
+			continue
 		}
-		fmt.Printf("Workspace %s has the following variable sets:\n", name)
-		for _, set := range sets.Data {
-			fmt.Printf("  %s\n", set.Attributes.Name)
+		records = append(records, workspaceVarsets{Workspace: name, VariableSets: setNames})
+		for _, set := range setNames {
+			rows = append(rows, []string{name, set})
 		}
 	}
+	writeOutput(format, output.Table{
+		Columns: []string{"workspace", "variable-set"},
+		Rows:    rows,
+		Records: records,
+	})
+
+	if err != nil {
+		exitIncomplete(err)
+	}
 }
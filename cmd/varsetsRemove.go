@@ -0,0 +1,61 @@
+// Copyright © 2024 SIL International
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var varsetsRemoveCmd = &cobra.Command{
+	Use:   "remove",
+	Short: "Remove Variable Set from Workspaces",
+	Long:  `Remove a variable set from workspaces, or from a project, undoing 'varsets apply'`,
+	Args:  cobra.ExactArgs(0),
+	Run: func(cmd *cobra.Command, args []string) {
+		runVarsetsRemove(cmd.Context(), variableSet)
+	},
+}
+
+func init() {
+	varsetsCmd.AddCommand(varsetsRemoveCmd)
+	addVariableSetNameFlag(varsetsRemoveCmd)
+	addVariableSetTargetFlags(varsetsRemoveCmd)
+}
+
+func runVarsetsRemove(ctx context.Context, name string) {
+	if readOnlyMode {
+		fmt.Println("Read only mode enabled. No variable set will be removed.")
+	}
+
+	vs := getVariableSet(ctx, name)
+	if varsetProject != "" {
+		fmt.Printf("Removing variable set '%s' from project '%s'\n", vs.Attributes.Name, varsetProject)
+		if err := client.RemoveVariableSetFromProjectsContext(ctx, vs.ID, []string{getProjectID(ctx)}); err != nil {
+			errLog.Fatalf("Error while removing variable set: %s", err)
+		}
+		if workspace == "" && workspaceFilter == "" {
+			return
+		}
+	}
+
+	wsIDs, wsNames := stringMapToSlice(selectWorkspaces(ctx))
+	fmt.Printf("Removing variable set '%s' from %s\n", vs.Attributes.Name, workspaceListToString(wsNames))
+	if err := client.RemoveVariableSetContext(ctx, vs.ID, wsIDs); err != nil {
+		errLog.Fatalf("Error while removing variable set: %s", err)
+	}
+}
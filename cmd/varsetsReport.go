@@ -0,0 +1,134 @@
+// Copyright © 2024 SIL International
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/silinternational/tfc-ops/v4/lib"
+	"github.com/silinternational/tfc-ops/v4/output"
+)
+
+var varsetsReportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Report on the usage of Variable Sets",
+	Long: `List all the variable sets in the organization with their workspace and variable counts, and flag their
+issues: sets that are not global and not applied to any workspace or project ("unused"), keys that are defined in
+more than one set applied to the same workspace ("overlap"), and set variables that are overridden by a workspace
+variable ("shadowed"). Each issue is listed beneath its variable set, on rows with no name.`,
+	Args: cobra.ExactArgs(0),
+	Run: func(cmd *cobra.Command, args []string) {
+		runVarsetsReport(cmd.Context(), getOutputFormat())
+	},
+}
+
+func init() {
+	varsetsCmd.AddCommand(varsetsReportCmd)
+	addOutputFlag(varsetsReportCmd)
+}
+
+// varsetUsage is one variable set in the output of `varsets report`
+type varsetUsage struct {
+	ID             string                 `json:"id" yaml:"id"`
+	Name           string                 `json:"name" yaml:"name"`
+	Global         bool                   `json:"global" yaml:"global"`
+	Priority       bool                   `json:"priority" yaml:"priority"`
+	WorkspaceCount int                    `json:"workspace-count" yaml:"workspace-count"`
+	VariableCount  int                    `json:"variable-count" yaml:"variable-count"`
+	Issues         []lib.VariableSetIssue `json:"issues" yaml:"issues"`
+}
+
+func runVarsetsReport(ctx context.Context, format output.Format) {
+	report, err := client.GetVariableSetReportContext(ctx, organization)
+	var incomplete *lib.IncompleteError
+	if err != nil && !errors.As(err, &incomplete) {
+		errLog.Fatalf("Error retrieving variable sets: %s", err)
+	}
+
+	issues := map[string][]lib.VariableSetIssue{}
+	for _, issue := range report.Issues {
+		issues[issue.VariableSet] = append(issues[issue.VariableSet], issue)
+	}
+
+	sets := report.VariableSets
+	sort.SliceStable(sets, func(i, j int) bool {
+		return sets[i].Attributes.Name < sets[j].Attributes.Name
+	})
+
+	records := []varsetUsage{}
+	var rows [][]string
+	for _, vs := range sets {
+		usage := varsetUsage{
+			ID:             vs.ID,
+			Name:           vs.Attributes.Name,
+			Global:         vs.Attributes.Global,
+			Priority:       vs.Attributes.Priority,
+			WorkspaceCount: vs.Attributes.WorkspaceCount,
+			VariableCount:  vs.Attributes.VarCount,
+			Issues:         issues[vs.Attributes.Name],
+		}
+		if usage.Issues == nil {
+			usage.Issues = []lib.VariableSetIssue{}
+		}
+		records = append(records, usage)
+
+		row := []string{
+			usage.Name, strconv.FormatBool(usage.Global), strconv.FormatBool(usage.Priority),
+			strconv.Itoa(usage.WorkspaceCount), strconv.Itoa(usage.VariableCount), "",
+		}
+		for i, issue := range usage.Issues {
+			if i > 0 {
+				row = []string{"", "", "", "", "", ""}
+			}
+			row[5] = describeVariableSetIssue(issue)
+			rows = append(rows, row)
+		}
+		if len(usage.Issues) == 0 {
+			rows = append(rows, row)
+		}
+	}
+
+	writeOutput(format, output.Table{
+		Columns: []string{"name", "global", "priority", "workspaces", "variables", "issue"},
+		Rows:    rows,
+		Records: records,
+	})
+
+	if err != nil {
+		exitIncomplete(err)
+	}
+}
+
+// describeVariableSetIssue returns a one-line description of an issue, for table and CSV output
+func describeVariableSetIssue(issue lib.VariableSetIssue) string {
+	switch issue.Kind {
+	case lib.VariableSetUnused:
+		return "unused: not applied to any workspace or project"
+	case lib.VariableSetOverlap:
+		return fmt.Sprintf("overlap: %s (%s) is also in %s, in %s", issue.Key, issue.Category,
+			strings.Join(issue.OtherSets, ", "), strings.Join(issue.Workspaces, ", "))
+	case lib.VariableSetShadowed:
+		return fmt.Sprintf("shadowed: %s (%s) is overridden by the workspace variable in %s", issue.Key,
+			issue.Category, strings.Join(issue.Workspaces, ", "))
+	}
+	return issue.Kind
+}
@@ -0,0 +1,106 @@
+// Copyright © 2024 SIL International
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/silinternational/tfc-ops/v4/output"
+)
+
+var varsetsShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Show a Variable Set",
+	Long:  `Show the settings of a variable set, the keys of its variables and the workspaces it is applied to`,
+	Args:  cobra.ExactArgs(0),
+	Run: func(cmd *cobra.Command, args []string) {
+		runVarsetsShow(cmd.Context(), getOutputFormat())
+	},
+}
+
+func init() {
+	varsetsCmd.AddCommand(varsetsShowCmd)
+	addVariableSetNameFlag(varsetsShowCmd)
+	addOutputFlag(varsetsShowCmd)
+}
+
+// varsetSummary is the output of `varsets show`
+type varsetSummary struct {
+	ID          string   `json:"id" yaml:"id"`
+	Name        string   `json:"name" yaml:"name"`
+	Description string   `json:"description" yaml:"description"`
+	Global      bool     `json:"global" yaml:"global"`
+	Priority    bool     `json:"priority" yaml:"priority"`
+	Variables   []string `json:"variables" yaml:"variables"`
+	Workspaces  []string `json:"workspaces" yaml:"workspaces"`
+}
+
+func runVarsetsShow(ctx context.Context, format output.Format) {
+	vs, err := client.GetVariableSetByIDContext(ctx, getVariableSet(ctx, variableSet).ID)
+	if err != nil {
+		errLog.Fatalf("Error retrieving variable set: %s", err)
+	}
+	vars, err := client.ListVariableSetVariablesContext(ctx, vs.ID)
+	if err != nil {
+		errLog.Fatalf("Error retrieving the variables of variable set %s: %s", vs.Attributes.Name, err)
+	}
+
+	s := varsetSummary{
+		ID:          vs.ID,
+		Name:        vs.Attributes.Name,
+		Description: vs.Attributes.Description,
+		Global:      vs.Attributes.Global,
+		Priority:    vs.Attributes.Priority,
+		Variables:   []string{},
+		Workspaces:  []string{},
+	}
+	for _, v := range vars {
+		s.Variables = append(s.Variables, v.Key)
+	}
+	sort.Strings(s.Variables)
+
+	if len(vs.Relationships.Workspaces.Data) > 0 {
+		allWorkspaces, err := client.GetAllWorkspacesContext(ctx, organization)
+		if err != nil {
+			errLog.Fatalln(err)
+		}
+		names := make(map[string]string, len(allWorkspaces))
+		for _, ws := range allWorkspaces {
+			names[ws.ID] = ws.Attributes.Name
+		}
+		for _, ws := range vs.Relationships.Workspaces.Data {
+			name, ok := names[ws.ID]
+			if !ok {
+				name = ws.ID
+			}
+			s.Workspaces = append(s.Workspaces, name)
+		}
+		sort.Strings(s.Workspaces)
+	}
+
+	writeOutput(format, output.Table{
+		Columns: []string{"id", "name", "description", "global", "priority", "variables", "workspaces"},
+		Rows: [][]string{{
+			s.ID, s.Name, s.Description, strconv.FormatBool(s.Global), strconv.FormatBool(s.Priority),
+			strings.Join(s.Variables, " "), strings.Join(s.Workspaces, " "),
+		}},
+		Records: []varsetSummary{s},
+	})
+}
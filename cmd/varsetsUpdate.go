@@ -0,0 +1,65 @@
+// Copyright © 2024 SIL International
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/silinternational/tfc-ops/v4/lib"
+)
+
+var varsetsUpdateCmd = &cobra.Command{
+	Use:   "update",
+	Short: "Update a Variable Set",
+	Long: `Change the name, description, global or priority setting of a variable set. Settings that are not given are
+not changed.`,
+	Args: cobra.ExactArgs(0),
+	Run: func(cmd *cobra.Command, args []string) {
+		runVarsetsUpdate(cmd.Context(), cmd)
+	},
+}
+
+func init() {
+	varsetsCmd.AddCommand(varsetsUpdateCmd)
+	addVariableSetNameFlag(varsetsUpdateCmd)
+	varsetsUpdateCmd.Flags().StringVar(&varsetNewName, "name", "",
+		"New name of the variable set")
+	addVariableSetFlags(varsetsUpdateCmd)
+}
+
+func runVarsetsUpdate(ctx context.Context, cmd *cobra.Command) {
+	cfg := variableSetConfig(cmd)
+	if varsetNewName != "" {
+		cfg.Name = &varsetNewName
+	}
+	if cfg == (lib.VariableSetConfig{}) {
+		errLog.Fatalln("Nothing to update. Give at least one of --name, --description, --global or --priority.")
+	}
+
+	vs := getVariableSet(ctx, variableSet)
+	fmt.Printf("Updating variable set '%s'\n", vs.Attributes.Name)
+	if readOnlyMode {
+		fmt.Println("Read only mode enabled. The variable set will not be updated.")
+		return
+	}
+
+	if _, err := client.UpdateVariableSetContext(ctx, vs.ID, cfg); err != nil {
+		errLog.Fatalf("Error updating variable set: %s", err)
+	}
+	fmt.Println("Updated variable set")
+}
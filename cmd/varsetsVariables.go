@@ -0,0 +1,68 @@
+// Copyright © 2024 SIL International
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+
+	"github.com/silinternational/tfc-ops/v4/lib"
+)
+
+// varsetsVariablesCmd represents the command group for the variables in a variable set
+var varsetsVariablesCmd = &cobra.Command{
+	Use:   "variables",
+	Short: "Commands for the variables in a Variable Set",
+	Long:  "Top level command to list, add, update or delete the variables in a Variable Set",
+	Args:  cobra.MinimumNArgs(1),
+}
+
+func init() {
+	varsetsCmd.AddCommand(varsetsVariablesCmd)
+	varsetsVariablesCmd.PersistentFlags().StringVarP(&variableSet, "set", "s", "",
+		requiredPrefix+"Name of the variable set")
+	if err := varsetsVariablesCmd.MarkPersistentFlagRequired("set"); err != nil {
+		errLog.Fatalf("failed to mark 'set' as a required flag on varsets variables: %s", err)
+	}
+}
+
+// addVarsetVariableKeyFlag adds the required --key flag, which names a variable in a variable set
+func addVarsetVariableKeyFlag(command *cobra.Command) {
+	command.Flags().StringVarP(&key, "key", "k", "",
+		requiredPrefix+"Variable key, must match exactly")
+	if err := command.MarkFlagRequired("key"); err != nil {
+		errLog.Fatalf("failed to mark 'key' as a required flag on %s: %s", command.Name(), err)
+	}
+}
+
+// findVarsetVariable returns the variable with the given key and category in a variable set, or nil if there is none
+func findVarsetVariable(ctx context.Context, vs *lib.VariableSet, key, category string) *lib.Var {
+	vars, err := client.ListVariableSetVariablesContext(ctx, vs.ID)
+	if err != nil {
+		errLog.Fatalf("Error retrieving the variables of variable set %s: %s", vs.Attributes.Name, err)
+	}
+	for _, v := range vars {
+		vCategory := v.Category
+		if vCategory == "" {
+			vCategory = "terraform"
+		}
+		if v.Key == key && vCategory == category {
+			found := v
+			return &found
+		}
+	}
+	return nil
+}
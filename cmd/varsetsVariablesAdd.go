@@ -0,0 +1,71 @@
+// Copyright © 2024 SIL International
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/silinternational/tfc-ops/v4/lib"
+)
+
+var varsetsVariablesAddCmd = &cobra.Command{
+	Use:   "add",
+	Short: "Add a variable to a Variable Set",
+	Long:  `Add a variable to a variable set. Will not update an existing variable.`,
+	Args:  cobra.ExactArgs(0),
+	Run: func(cmd *cobra.Command, args []string) {
+		value = variableValue(cmd, "value", value)
+		runVarsetsVariablesAdd(cmd.Context())
+	},
+}
+
+func init() {
+	varsetsVariablesCmd.AddCommand(varsetsVariablesAddCmd)
+	addVarsetVariableKeyFlag(varsetsVariablesAddCmd)
+	varsetsVariablesAddCmd.Flags().StringVarP(&value, "value", "v", "",
+		"Variable value, required unless --value-file is given")
+	varsetsVariablesAddCmd.Flags().BoolVarP(&sensitiveVariable, "sensitive-variable", "x", false,
+		`optional (e.g. "-x=true") make the variable sensitive.`)
+	addVariableSettingFlags(varsetsVariablesAddCmd)
+}
+
+func runVarsetsVariablesAdd(ctx context.Context) {
+	vs := getVariableSet(ctx, variableSet)
+	if v := findVarsetVariable(ctx, vs, key, variableCategory); v != nil {
+		errLog.Fatalf("'%s' already exists in variable set '%s'. Use 'varsets variables update' to change it.",
+			key, vs.Attributes.Name)
+	}
+
+	fmt.Printf("Variable set %s: Adding variable %s = %s\n", vs.Attributes.Name, key, value)
+	if readOnlyMode {
+		fmt.Println("Read only mode enabled. No variable will be added.")
+		return
+	}
+
+	_, err := client.CreateVariableSetVariableContext(ctx, vs.ID, lib.Var{
+		Key:         key,
+		Value:       value,
+		Description: variableDescription,
+		Category:    variableCategory,
+		Hcl:         variableHcl,
+		Sensitive:   sensitiveVariable,
+	})
+	if err != nil {
+		errLog.Fatalln(err)
+	}
+}
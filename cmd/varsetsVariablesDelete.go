@@ -0,0 +1,56 @@
+// Copyright © 2024 SIL International
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var varsetsVariablesDeleteCmd = &cobra.Command{
+	Use:   "delete",
+	Short: "Delete a variable from a Variable Set",
+	Long:  `Delete the variable with the specified key and category from a variable set`,
+	Args:  cobra.ExactArgs(0),
+	Run: func(cmd *cobra.Command, args []string) {
+		runVarsetsVariablesDelete(cmd.Context())
+	},
+}
+
+func init() {
+	varsetsVariablesCmd.AddCommand(varsetsVariablesDeleteCmd)
+	addVarsetVariableKeyFlag(varsetsVariablesDeleteCmd)
+	varsetsVariablesDeleteCmd.Flags().StringVar(&variableCategory, "category", "terraform",
+		`optional, the category of the variable: "terraform" or "env"`)
+}
+
+func runVarsetsVariablesDelete(ctx context.Context) {
+	vs := getVariableSet(ctx, variableSet)
+	v := findVarsetVariable(ctx, vs, key, variableCategory)
+	if v == nil {
+		errLog.Fatalf("Variable %s (%s) not found in variable set %s\n", key, variableCategory, vs.Attributes.Name)
+	}
+
+	fmt.Printf("Deleting variable %s from variable set %s\n", v.Key, vs.Attributes.Name)
+	if readOnlyMode {
+		fmt.Println("Read only mode enabled. The variable will not be deleted.")
+		return
+	}
+	if err := client.DeleteVariableSetVariableContext(ctx, vs.ID, v.ID); err != nil {
+		errLog.Fatalln(err)
+	}
+}
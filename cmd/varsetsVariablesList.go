@@ -0,0 +1,84 @@
+// Copyright © 2024 SIL International
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/silinternational/tfc-ops/v4/output"
+)
+
+var varsetsVariablesListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the variables in a Variable Set",
+	Long:  `List the variables in a variable set. The values of sensitive variables can't be read.`,
+	Args:  cobra.ExactArgs(0),
+	Run: func(cmd *cobra.Command, args []string) {
+		runVarsetsVariablesList(cmd.Context(), getOutputFormat())
+	},
+}
+
+func init() {
+	varsetsVariablesCmd.AddCommand(varsetsVariablesListCmd)
+	addOutputFlag(varsetsVariablesListCmd)
+}
+
+// varsetVar is one variable in the output of `varsets variables list`
+type varsetVar struct {
+	Key         string `json:"key" yaml:"key"`
+	Value       string `json:"value" yaml:"value"`
+	Description string `json:"description" yaml:"description"`
+	Category    string `json:"category" yaml:"category"`
+	HCL         bool   `json:"hcl" yaml:"hcl"`
+	Sensitive   bool   `json:"sensitive" yaml:"sensitive"`
+}
+
+func runVarsetsVariablesList(ctx context.Context, format output.Format) {
+	vs := getVariableSet(ctx, variableSet)
+	vars, err := client.ListVariableSetVariablesContext(ctx, vs.ID)
+	if err != nil {
+		errLog.Fatalf("Error retrieving the variables of variable set %s: %s", vs.Attributes.Name, err)
+	}
+
+	records := []varsetVar{}
+	var rows [][]string
+	for _, v := range vars {
+		records = append(records, varsetVar{
+			Key:         v.Key,
+			Value:       v.Value,
+			Description: v.Description,
+			Category:    v.Category,
+			HCL:         v.Hcl,
+			Sensitive:   v.Sensitive,
+		})
+
+		val := v.Value
+		if v.Sensitive {
+			val = "(sensitive)"
+		}
+		rows = append(rows, []string{
+			v.Key, val, v.Description, v.Category, strconv.FormatBool(v.Hcl), strconv.FormatBool(v.Sensitive),
+		})
+	}
+
+	writeOutput(format, output.Table{
+		Columns: []string{"key", "value", "description", "category", "hcl", "sensitive"},
+		Rows:    rows,
+		Records: records,
+	})
+}
@@ -0,0 +1,71 @@
+// Copyright © 2024 SIL International
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var varsetsVariablesUpdateCmd = &cobra.Command{
+	Use:   "update",
+	Short: "Update a variable in a Variable Set",
+	Long: `Change the value and settings of a variable in a variable set. The variable keeps its description and HCL
+setting unless --description or --hcl is given, and a sensitive variable stays sensitive.`,
+	Args: cobra.ExactArgs(0),
+	Run: func(cmd *cobra.Command, args []string) {
+		value = variableValue(cmd, "value", value)
+		runVarsetsVariablesUpdate(cmd.Context(), cmd.Flags().Changed("hcl"))
+	},
+}
+
+func init() {
+	varsetsVariablesCmd.AddCommand(varsetsVariablesUpdateCmd)
+	addVarsetVariableKeyFlag(varsetsVariablesUpdateCmd)
+	varsetsVariablesUpdateCmd.Flags().StringVarP(&value, "value", "v", "",
+		"New value of the variable, required unless --value-file is given")
+	varsetsVariablesUpdateCmd.Flags().BoolVarP(&sensitiveVariable, "sensitive-variable", "x", false,
+		`optional (e.g. "-x=true") make the variable sensitive.`)
+	addVariableSettingFlags(varsetsVariablesUpdateCmd)
+}
+
+func runVarsetsVariablesUpdate(ctx context.Context, setHcl bool) {
+	vs := getVariableSet(ctx, variableSet)
+	v := findVarsetVariable(ctx, vs, key, variableCategory)
+	if v == nil {
+		errLog.Fatalf("Variable %s (%s) not found in variable set %s\n", key, variableCategory, vs.Attributes.Name)
+	}
+
+	fmt.Printf("Variable set %s: Replacing the value of %s from %s to %s\n", vs.Attributes.Name, key, v.Value, value)
+	if readOnlyMode {
+		fmt.Println("Read only mode enabled. The variable will not be updated.")
+		return
+	}
+
+	updated := *v
+	updated.Value = value
+	if setHcl {
+		updated.Hcl = variableHcl
+	}
+	updated.Sensitive = v.Sensitive || sensitiveVariable
+	if variableDescription != "" {
+		updated.Description = variableDescription
+	}
+	if err := client.UpdateVariableSetVariableContext(ctx, vs.ID, v.ID, updated); err != nil {
+		errLog.Fatalln(err)
+	}
+}
@@ -15,8 +15,10 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 
@@ -32,6 +34,13 @@ var (
 	sourceWorkspace             string
 	newWorkspace                string
 	newVCSTokenID               string
+	secretsFile                 string
+	cloneExclude                []string
+	cloneJournalPath            string
+	cloneResume                 string
+	keepOnFailure               bool
+	clonePlanFile               string
+	cloneResultsFile            string
 )
 
 // cloneCmd represents the clone command
@@ -41,7 +50,20 @@ var cloneCmd = &cobra.Command{
 	Long:  `Clone a Terraform Cloud Workspace`,
 	Args:  cobra.ExactArgs(0),
 	Run: func(cmd *cobra.Command, args []string) {
-		if differentDestinationAccount {
+		if cloneResume != "" {
+			journal, err := cloner.ReadCloneJournal(cloneResume)
+			if err != nil {
+				errLog.Fatalln(err)
+			}
+			runClone(cmd.Context(), journal)
+			return
+		}
+
+		if clonePlanFile == "" && (sourceWorkspace == "" || newWorkspace == "") {
+			errLog.Fatalln("Error: The 'source-workspace' '-s' and 'new-workspace' '-n' flags are required.")
+		}
+
+		if differentDestinationAccount && clonePlanFile == "" {
 
 			if newOrganization == "" {
 				fmt.Println("Error: The 'new-organization' '-p' flag is required for a different destination account.")
@@ -63,9 +85,18 @@ var cloneCmd = &cobra.Command{
 			CopyVariables:               copyVariables,
 			ApplyVariableSets:           applyVariableSets,
 			DifferentDestinationAccount: differentDestinationAccount,
+			Exclude:                     cloneExclude,
 		}
 
-		runClone(config)
+		if clonePlanFile != "" {
+			runClonePlan(cmd.Context(), config)
+			return
+		}
+
+		if cloneJournalPath == "" {
+			cloneJournalPath = "tfc-ops-clone-" + newWorkspace + ".json"
+		}
+		runClone(cmd.Context(), cloner.NewCloneJournal(config, cloneJournalPath))
 	},
 }
 
@@ -104,7 +135,7 @@ func init() {
 		"copyState",
 		"t",
 		false,
-		`optional (e.g. "-t=true") whether to copy the state of the Source Workspace (only possible if copying to a new account).`,
+		`optional (e.g. "-t=true") whether to copy the state of the Source Workspace.`,
 	)
 	cloneCmd.Flags().BoolVarP(
 		&copyVariables,
@@ -119,6 +150,18 @@ func init() {
 		false,
 		`optional, whether to apply the same variable sets to the new workspace (only for same-account clone).`,
 	)
+	cloneCmd.Flags().StringVar(
+		&secretsFile,
+		"secrets-file",
+		"",
+		`optional, file of "key=value" lines with values for sensitive variables (e.g. "db_password=${DB_PASSWORD}" to use an environment variable).`,
+	)
+	cloneCmd.Flags().StringSliceVar(
+		&cloneExclude,
+		"exclude",
+		nil,
+		`optional, configuration not to copy, one or more of: `+strings.Join(cloner.CloneCategories, ", "),
+	)
 	cloneCmd.Flags().BoolVarP(
 		&differentDestinationAccount,
 		"differentDestinationAccount",
@@ -126,41 +169,107 @@ func init() {
 		false,
 		`optional (e.g. "-d=true") whether to clone to a different TF account.`,
 	)
-	if err := cloneCmd.MarkFlagRequired("source-workspace"); err != nil {
-		errLog.Fatalln(err)
-	}
-	if err := cloneCmd.MarkFlagRequired("new-workspace"); err != nil {
-		errLog.Fatalln(err)
-	}
+	cloneCmd.Flags().StringVar(
+		&cloneJournalPath,
+		"journal",
+		"",
+		`optional, file to record the progress of the clone in, default "tfc-ops-clone-<new-workspace>.json"`,
+	)
+	cloneCmd.Flags().BoolVar(
+		&keepOnFailure,
+		"keep-on-failure",
+		false,
+		`optional, if the clone fails, keep the new workspace and the journal so that the clone can be finished with --resume, instead of deleting the workspace`,
+	)
+	cloneCmd.Flags().StringVar(
+		&cloneResume,
+		"resume",
+		"",
+		`optional, journal of a clone to finish. The other flags of the clone are read from the journal, except --secrets-file.`,
+	)
+	cloneCmd.Flags().StringVar(
+		&clonePlanFile,
+		"plan",
+		"",
+		`optional, CSV or YAML file listing workspaces to clone, instead of --source-workspace and --new-workspace. Each row has the source and new organization and name, and optionally a terraform version, repo, branch and directory for the new workspace, and a VCS token ID for the new organization.`,
+	)
+	cloneCmd.Flags().StringVar(
+		&cloneResultsFile,
+		"results",
+		"clone-results.csv",
+		`optional, CSV file to write the result of each row of --plan to`,
+	)
 }
 
-func runClone(cfg cloner.CloneConfig) {
+func runClone(ctx context.Context, journal *cloner.CloneJournal) {
+	cfg := &journal.Config
 	if readOnlyMode {
 		fmt.Println("read-only mode enabled, no workspace will be created")
 	}
 
-	cfg.AtlasTokenDestination = os.Getenv("ATLAS_TOKEN_DESTINATION")
-	if cfg.AtlasTokenDestination == "" {
-		cfg.AtlasTokenDestination = cloner.GetToken()
-		fmt.Print("Info: ATLAS_TOKEN_DESTINATION is not set, using primary credential for destination account.\n\n")
-	}
+	setCloneSecrets(cfg)
 
 	fmt.Printf("clone called using %s, %s, %s, copyState: %t, copyVariables: %t, "+
 		"applyVariableSets: %t, differentDestinationAccount: %t\n",
 		cfg.Organization, cfg.SourceWorkspace, cfg.NewWorkspace, cfg.CopyState, cfg.CopyVariables,
 		cfg.ApplyVariableSets, cfg.DifferentDestinationAccount)
+	if journal.WorkspaceID != "" {
+		fmt.Printf("Resuming the clone of %s from %s\n", cfg.SourceWorkspace, journal.Path)
+	}
 
-	sensitiveVars, err := cloner.CloneWorkspace(cfg)
+	needValues, err := client.CloneWithJournalContext(ctx, journal)
 	if err != nil {
-		fmt.Println(err.Error())
-		return
+		errLog.Println(err)
+		if journal.WorkspaceID == "" {
+			os.Exit(1)
+		}
+		if keepOnFailure {
+			errLog.Fatalf("The clone is incomplete. To finish it, run: tfc-ops workspaces clone -o %s --resume %s\n",
+				cfg.Organization, journal.Path)
+		}
+
+		fmt.Printf("Rolling back: deleting workspace %s\n", cfg.NewWorkspace)
+		// roll back even if ctx was canceled
+		if err := client.RollbackCloneContext(context.Background(), journal); err != nil {
+			errLog.Fatalf("Rollback failed: %s\nTo finish the clone instead, run: tfc-ops workspaces clone -o %s --resume %s\n",
+				err, cfg.Organization, journal.Path)
+		}
+		errLog.Fatalln("The clone was rolled back.")
+	}
+	if err := journal.Remove(); err != nil {
+		errLog.Println(err)
 	}
 
 	println("\n  **** Completed Cloning ****")
-	if len(sensitiveVars) > 0 {
-		fmt.Printf("Sensitive variables for %s:%s\n", cfg.Organization, cfg.NewWorkspace)
-		for _, nextVar := range sensitiveVars {
+	if len(needValues) > 0 {
+		newOrg := cfg.Organization
+		if cfg.DifferentDestinationAccount {
+			newOrg = cfg.NewOrganization
+		}
+		fmt.Printf("Variables in %s:%s that need a value (currently \"REPLACE_THIS_VALUE\"):\n", newOrg, cfg.NewWorkspace)
+		for _, nextVar := range needValues {
 			println(nextVar)
 		}
 	}
 }
+
+// setCloneSecrets sets the destination token and the values from the secrets file in cfg
+func setCloneSecrets(cfg *cloner.CloneConfig) {
+	cfg.AtlasTokenDestination = os.Getenv("ATLAS_TOKEN_DESTINATION")
+	if cfg.AtlasTokenDestination == "" {
+		cfg.AtlasTokenDestination = client.Token()
+		fmt.Print("Info: ATLAS_TOKEN_DESTINATION is not set, using primary credential for destination account.\n\n")
+	}
+
+	if secretsFile != "" {
+		f, err := os.Open(secretsFile)
+		if err != nil {
+			errLog.Fatalf("error opening secrets file: %s", err)
+		}
+		cfg.Secrets, err = cloner.ReadSecrets(f)
+		_ = f.Close()
+		if err != nil {
+			errLog.Fatalf("error reading secrets file %s: %s", secretsFile, err)
+		}
+	}
+}
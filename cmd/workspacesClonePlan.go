@@ -0,0 +1,91 @@
+// Copyright © 2018-2022 SIL International
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	cloner "github.com/silinternational/tfc-ops/v4/lib"
+	"github.com/silinternational/tfc-ops/v4/output"
+)
+
+// runClonePlan clones every row of the plan file, with the options in cfg, and writes the results to the screen
+// and to the results file
+func runClonePlan(ctx context.Context, cfg cloner.CloneConfig) {
+	rows := readClonePlan()
+	setCloneSecrets(&cfg)
+	if readOnlyMode {
+		fmt.Println("read-only mode enabled, no workspace will be created")
+	}
+
+	if err := client.ValidateClonePlanContext(ctx, cfg, rows); err != nil {
+		errLog.Fatalf("error in %s: %s", clonePlanFile, err)
+	}
+
+	fmt.Printf("Cloning %d workspaces from %s\n", len(rows), clonePlanFile)
+	results := client.ClonePlanContext(ctx, cfg, rows, os.Stdout)
+
+	table := output.Table{Columns: cloner.ClonePlanResultColumns()}
+	failed := 0
+	for i := range results {
+		table.Rows = append(table.Rows, results[i].AsArray())
+		if results[i].Status != cloner.ClonePlanCloned {
+			failed++
+		}
+	}
+	fmt.Println()
+	writeOutput(output.FormatTable, table)
+
+	f, err := os.Create(cloneResultsFile)
+	if err != nil {
+		errLog.Fatalf("error creating results file: %s", err)
+	}
+	if err := output.FormatCSV.Write(f, table); err != nil {
+		errLog.Fatalf("error writing results file %s: %s", cloneResultsFile, err)
+	}
+	if err := f.Close(); err != nil {
+		errLog.Fatalf("error writing results file %s: %s", cloneResultsFile, err)
+	}
+	fmt.Printf("\nResults written to %s\n", cloneResultsFile)
+
+	if failed > 0 {
+		errLog.Fatalf("%d of %d workspaces were not cloned", failed, len(results))
+	}
+}
+
+// readClonePlan reads the plan file, in YAML format if its name ends in .yaml or .yml, or else in CSV format
+func readClonePlan() []cloner.OpsConfig {
+	f, err := os.Open(clonePlanFile)
+	if err != nil {
+		errLog.Fatalf("error opening plan file: %s", err)
+	}
+	defer f.Close()
+
+	var rows []cloner.OpsConfig
+	switch strings.ToLower(filepath.Ext(clonePlanFile)) {
+	case ".yaml", ".yml":
+		rows, err = cloner.ReadClonePlanYAML(f)
+	default:
+		rows, err = cloner.ReadClonePlanCSV(f)
+	}
+	if err != nil {
+		errLog.Fatalf("error reading %s: %s", clonePlanFile, err)
+	}
+	return rows
+}
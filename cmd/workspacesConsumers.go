@@ -15,13 +15,12 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strings"
 
 	"github.com/spf13/cobra"
-
-	"github.com/silinternational/tfc-ops/v4/lib"
 )
 
 const (
@@ -37,7 +36,7 @@ func addConsumersCommand(parentCommand *cobra.Command) {
 		Long:  `Add to workspace remote state consumers. (Possible future capability: list, replace, delete)`,
 		Args:  cobra.ExactArgs(0),
 		Run: func(cmd *cobra.Command, args []string) {
-			runWorkspaceConsumers(consumers)
+			runWorkspaceConsumers(cmd.Context(), consumers)
 		},
 	}
 
@@ -57,8 +56,8 @@ func addConsumersCommand(parentCommand *cobra.Command) {
 	}
 }
 
-func runWorkspaceConsumers(consumers string) {
-	workspaceData, err := lib.GetWorkspaceData(organization, workspace)
+func runWorkspaceConsumers(ctx context.Context, consumers string) {
+	workspaceData, err := client.GetWorkspaceDataContext(ctx, organization, workspace)
 	if err != nil {
 		log.Fatalln("workspace consumers", err)
 	}
@@ -66,7 +65,7 @@ func runWorkspaceConsumers(consumers string) {
 	consumersList := strings.Split(consumers, ",")
 	consumerIDs := make([]string, len(consumersList))
 	for i, consumer := range consumersList {
-		consumerData, err := lib.GetWorkspaceData(organization, consumer)
+		consumerData, err := client.GetWorkspaceDataContext(ctx, organization, consumer)
 		if err != nil {
 			log.Fatalln("workspace consumers", err)
 		}
@@ -75,7 +74,7 @@ func runWorkspaceConsumers(consumers string) {
 
 	fmt.Printf("Adding to %s: %s", workspace, consumers)
 	if !readOnlyMode {
-		if err := lib.AddRemoteStateConsumers(workspaceData.Data.ID, consumerIDs); err != nil {
+		if err := client.AddRemoteStateConsumersContext(ctx, workspaceData.Data.ID, consumerIDs); err != nil {
 			log.Fatalln("workspace consumers", err)
 		}
 	}
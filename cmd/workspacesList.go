@@ -15,12 +15,13 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
 	"github.com/spf13/cobra"
 
-	"github.com/silinternational/tfc-ops/v4/lib"
+	"github.com/silinternational/tfc-ops/v4/output"
 )
 
 var attributes string
@@ -32,8 +33,7 @@ var listCmd = &cobra.Command{
 	Long:  `Lists the TF workspaces with (some of) their attributes`,
 	Args:  cobra.ExactArgs(0),
 	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("Getting list of workspaces ...")
-		runList()
+		runList(cmd.Context(), getOutputFormat())
 	},
 }
 
@@ -43,18 +43,22 @@ func init() {
 	listCmd.Flags().StringVarP(&attributes, flagAttributes, "a", "",
 		requiredPrefix+"Workspace attributes to list, use Terraform Cloud API workspace attribute names")
 	_ = listCmd.MarkFlagRequired(flagAttributes)
+	addOutputFlag(listCmd)
 }
 
-func runList() {
+func runList(ctx context.Context, format output.Format) {
+	if format == output.FormatTable {
+		fmt.Println("Getting list of workspaces ...")
+	}
+
 	allAttrs := strings.Split(attributes, ",")
-	allData, err := lib.GetWorkspaceAttributes(organization, allAttrs)
+	for i := range allAttrs {
+		allAttrs[i] = strings.TrimSpace(allAttrs[i])
+	}
+	allData, err := client.GetWorkspaceAttributesContext(ctx, organization, allAttrs)
 	if err != nil {
-		fmt.Println(err.Error())
-		return
+		errLog.Fatalln(err)
 	}
 
-	fmt.Println(strings.Join(allAttrs, ", "))
-	for _, ws := range allData {
-		fmt.Println(strings.Join(ws, ", "))
-	}
+	writeOutput(format, output.Table{Columns: allAttrs, Rows: allData})
 }
@@ -15,8 +15,8 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
-	"log"
 
 	"github.com/spf13/cobra"
 
@@ -43,7 +43,7 @@ var workspaceUpdateCmd = &cobra.Command{
 	Args:  cobra.ExactArgs(0),
 	Run: func(cmd *cobra.Command, args []string) {
 		fmt.Println("Updating workspaces ...")
-		runWorkspaceUpdate()
+		runWorkspaceUpdate(cmd.Context())
 	},
 }
 
@@ -65,13 +65,13 @@ func init() {
 	}
 }
 
-func runWorkspaceUpdate() {
-	if err := lib.UpdateWorkspace(lib.WorkspaceUpdateParams{
+func runWorkspaceUpdate(ctx context.Context) {
+	if err := client.UpdateWorkspaceContext(ctx, lib.WorkspaceUpdateParams{
 		Organization:    organization,
 		WorkspaceFilter: workspaceFilter,
 		Attribute:       attribute,
 		Value:           value,
 	}); err != nil {
-		log.Fatalln(err.Error())
+		exitIncomplete(err)
 	}
 }
@@ -1,46 +1,297 @@
 package lib
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io"
+	"math/rand"
+	"net"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 )
 
+const (
+	// DefaultMaxRetries is the number of times a failed request is retried if ClientConfig.MaxRetries is not set
+	DefaultMaxRetries = 5
+
+	// DefaultTimeout is the time limit for one request if ClientConfig.Timeout is not set
+	DefaultTimeout = time.Minute
+
+	defaultRetryWaitMin = 500 * time.Millisecond
+	defaultRetryWaitMax = 30 * time.Second
+
+	headerRetryAfter         = "Retry-After"
+	headerRateLimitRemaining = "X-RateLimit-Remaining"
+	headerRateLimitReset     = "X-RateLimit-Reset"
+)
+
+// Client makes calls to the Terraform Cloud API. Each Client holds its own token, hostname and settings, so
+// several clients can be used at the same time, including from different goroutines.
+type Client struct {
+	token        string
+	hostname     string
+	httpClient   *http.Client
+	debug        bool
+	readOnly     bool
+	maxRetries   int
+	retryWaitMin time.Duration
+	retryWaitMax time.Duration
+	parallelism  int
+	orgs         *orgCache
+}
+
+// ClientConfig holds the settings for a new Client
+type ClientConfig struct {
+	Token      string
+	Hostname   string        // defaults to app.terraform.io
+	HTTPClient *http.Client  // defaults to a new http.Client with the given Timeout
+	Timeout    time.Duration // time limit for each request, defaults to DefaultTimeout. Ignored if HTTPClient is set.
+	Debug      bool          // print request details
+	ReadOnly   bool          // skip any call that would make changes
+
+	// MaxRetries is the number of times a request is retried after a rate limit response, a server error, or a
+	// network error. Zero selects DefaultMaxRetries; a negative number disables retries.
+	MaxRetries int
+
+	// Parallelism is the number of workspaces processed at once by functions that operate on many workspaces.
+	// Defaults to DefaultParallelism.
+	Parallelism int
+}
+
+// NewClient creates a Client with the given settings
+func NewClient(cfg ClientConfig) *Client {
+	c := &Client{
+		token:        cfg.Token,
+		hostname:     cfg.Hostname,
+		httpClient:   cfg.HTTPClient,
+		debug:        cfg.Debug,
+		readOnly:     cfg.ReadOnly,
+		maxRetries:   cfg.MaxRetries,
+		retryWaitMin: defaultRetryWaitMin,
+		retryWaitMax: defaultRetryWaitMax,
+		parallelism:  cfg.Parallelism,
+		orgs:         newOrgCache(),
+	}
+	if c.parallelism < 1 {
+		c.parallelism = DefaultParallelism
+	}
+	if c.maxRetries == 0 {
+		c.maxRetries = DefaultMaxRetries
+	}
+	if c.maxRetries < 0 {
+		c.maxRetries = 0
+	}
+	if c.hostname == "" {
+		c.hostname = DefaultHostname
+	}
+	if c.httpClient == nil {
+		timeout := cfg.Timeout
+		if timeout == 0 {
+			timeout = DefaultTimeout
+		}
+		c.httpClient = &http.Client{Timeout: timeout}
+	}
+	return c
+}
+
+// WithToken returns a copy of the Client that uses a different token, e.g. to reach a second account
+func (c *Client) WithToken(token string) *Client {
+	clone := *c
+	clone.token = token
+	clone.orgs = newOrgCache()
+	return &clone
+}
+
+// Token returns the token used by the Client
+func (c *Client) Token() string {
+	return c.token
+}
+
+// Hostname returns the hostname of the API used by the Client
+func (c *Client) Hostname() string {
+	return c.hostname
+}
+
+// ReadOnly returns true if the Client is in read-only mode
+func (c *Client) ReadOnly() bool {
+	return c.readOnly
+}
+
 // callAPI creates a http.Request object, attaches headers to it and makes the
-// requested api call.
-func callAPI(method, url, postData string, headers map[string]string) (*http.Response, error) {
+// requested api call. Requests that fail because of rate limiting, a server error, or a network error are retried
+// up to the client's retry limit, as long as it is safe to repeat the request. Canceling ctx stops the request and
+// any further retries. If the final response has an error status, the error is an *APIError.
+func (c *Client) callAPI(ctx context.Context, method, url, postData string, headers map[string]string) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		req, err := c.newRequest(ctx, method, url, postData, headers)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			if attempt < c.maxRetries && isRetryableError(method, err) {
+				if err := c.waitToRetry(ctx, c.backoff(attempt), err.Error()); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			return nil, err
+		}
+
+		if resp.StatusCode < 300 {
+			if err := c.waitForRateLimit(ctx, resp); err != nil {
+				_ = resp.Body.Close()
+				return nil, err
+			}
+			return resp, nil
+		}
+
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if attempt < c.maxRetries && isRetryableStatus(method, resp.StatusCode) {
+			if err := c.waitToRetry(ctx, c.retryDelay(resp, attempt), resp.Status); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		return nil, newAPIError(method, url, resp, bodyBytes)
+	}
+}
+
+func (c *Client) newRequest(ctx context.Context, method, url, postData string, headers map[string]string) (*http.Request, error) {
 	var err error
 	var req *http.Request
 
 	if postData != "" {
-		req, err = http.NewRequest(method, url, strings.NewReader(postData))
+		req, err = http.NewRequestWithContext(ctx, method, url, strings.NewReader(postData))
 	} else {
-		req, err = http.NewRequest(method, url, nil)
+		req, err = http.NewRequestWithContext(ctx, method, url, nil)
 	}
 
 	if err != nil {
 		return nil, err
 	}
 
-	req.Header.Set("Authorization", "Bearer "+config.token)
+	req.Header.Set("Authorization", "Bearer "+c.token)
 	req.Header.Set("Content-Type", "application/vnd.api+json")
 
 	for key, val := range headers {
 		req.Header.Set(key, val)
 	}
+	return req, nil
+}
 
-	client := &http.Client{}
+// isIdempotent returns true if a request with the given method has the same effect whether it is made once or
+// several times. The API uses PATCH to set attributes to given values, so it is treated as idempotent.
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
 
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, err
-	} else if resp.StatusCode >= 300 {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf(
-			"API returned an error.\n\tMethod: %s\n\tURL: %s\n\tCode: %v\n\tStatus: %s\n\tRequest Body: %s\n\tResponse Body: %s",
-			method, url, resp.StatusCode, resp.Status, postData, bodyBytes)
+// isRetryableStatus returns true if a response status indicates the request may succeed if it is made again. A
+// rate-limited request (429) was not processed, so it can be retried whatever the method.
+func isRetryableStatus(method string, status int) bool {
+	if status == http.StatusTooManyRequests {
+		return true
+	}
+	return status >= 500 && status != http.StatusNotImplemented && isIdempotent(method)
+}
+
+// isRetryableError returns true if a transport error may not happen again. Non-idempotent requests are only
+// retried if the connection could not be made, since the server cannot have received the request.
+func isRetryableError(method string, err error) bool {
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+	return isIdempotent(method)
+}
+
+// retryDelay returns the time to wait before retrying a failed request, using the Retry-After or X-RateLimit-Reset
+// header if the server provided one
+func (c *Client) retryDelay(resp *http.Response, attempt int) time.Duration {
+	if d, ok := parseRetryAfter(resp.Header.Get(headerRetryAfter)); ok {
+		return d
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		if d, ok := parseRateLimitReset(resp.Header.Get(headerRateLimitReset)); ok {
+			return d
+		}
+	}
+	return c.backoff(attempt)
+}
+
+// backoff returns an exponentially increasing delay with random jitter, so that concurrent clients don't retry in
+// lockstep
+func (c *Client) backoff(attempt int) time.Duration {
+	d := c.retryWaitMin << uint(attempt)
+	if d <= 0 || d > c.retryWaitMax {
+		d = c.retryWaitMax
+	}
+	half := d / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// waitForRateLimit pauses after a successful response if it used the last request allowed by the rate limit
+func (c *Client) waitForRateLimit(ctx context.Context, resp *http.Response) error {
+	if resp.Header.Get(headerRateLimitRemaining) != "0" {
+		return nil
+	}
+	if d, ok := parseRateLimitReset(resp.Header.Get(headerRateLimitReset)); ok {
+		return c.waitToRetry(ctx, d, "rate limit reached")
+	}
+	return nil
+}
+
+// waitToRetry pauses for the given time, returning early with an error if ctx is canceled
+func (c *Client) waitToRetry(ctx context.Context, d time.Duration, reason string) error {
+	if c.debug {
+		fmt.Printf("waiting %s before next request: %s\n", d, reason)
 	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// parseRetryAfter reads a Retry-After header, which is either a number of seconds or an HTTP date
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		d := time.Until(t)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
 
-	return resp, nil
+// parseRateLimitReset reads an X-RateLimit-Reset header, which is the number of seconds, with a fractional part,
+// until the rate limit resets
+func parseRateLimitReset(value string) (time.Duration, bool) {
+	seconds, err := strconv.ParseFloat(value, 64)
+	if err != nil || seconds < 0 {
+		return 0, false
+	}
+	return time.Duration(seconds * float64(time.Second)), true
 }
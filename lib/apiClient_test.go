@@ -0,0 +1,171 @@
+package lib
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestClient returns a Client that sends its requests to the given test server
+func newTestClient(server *httptest.Server, token string) *Client {
+	return NewClient(ClientConfig{
+		Token:      token,
+		Hostname:   strings.TrimPrefix(server.URL, "https://"),
+		HTTPClient: server.Client(),
+	})
+}
+
+func TestClient_callAPI(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.Header.Get("Authorization")))
+	}))
+	defer server.Close()
+
+	clients := []*Client{newTestClient(server, "token-one"), newTestClient(server, "token-two")}
+	clients = append(clients, clients[0].WithToken("token-three"))
+
+	var wg sync.WaitGroup
+	for _, c := range clients {
+		wg.Add(1)
+		go func(c *Client) {
+			defer wg.Done()
+			for i := 0; i < 10; i++ {
+				u := c.NewTfcUrl("/ping")
+				resp, err := c.callAPI(context.Background(), http.MethodGet, u.String(), "", nil)
+				if !assert.NoError(t, err) {
+					return
+				}
+				body := make([]byte, 64)
+				n, _ := resp.Body.Read(body)
+				_ = resp.Body.Close()
+				assert.Equal(t, "Bearer "+c.Token(), string(body[:n]))
+			}
+		}(c)
+	}
+	wg.Wait()
+}
+
+func TestClient_callAPIRetry(t *testing.T) {
+	tests := []struct {
+		name      string
+		method    string
+		responses []int
+		header    http.Header
+		wantCalls int
+		wantErr   bool
+	}{
+		{
+			name:      "rate limited POST",
+			method:    http.MethodPost,
+			responses: []int{http.StatusTooManyRequests, http.StatusTooManyRequests, http.StatusCreated},
+			header:    http.Header{headerRetryAfter: {"0"}},
+			wantCalls: 3,
+		},
+		{
+			name:      "rate limit reset",
+			method:    http.MethodGet,
+			responses: []int{http.StatusTooManyRequests, http.StatusOK},
+			header:    http.Header{headerRateLimitReset: {"0.001"}},
+			wantCalls: 2,
+		},
+		{
+			name:      "server error GET",
+			method:    http.MethodGet,
+			responses: []int{http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusOK},
+			wantCalls: 3,
+		},
+		{
+			name:      "server error POST",
+			method:    http.MethodPost,
+			responses: []int{http.StatusBadGateway, http.StatusCreated},
+			wantCalls: 1,
+			wantErr:   true,
+		},
+		{
+			name:      "client error",
+			method:    http.MethodGet,
+			responses: []int{http.StatusNotFound, http.StatusOK},
+			wantCalls: 1,
+			wantErr:   true,
+		},
+		{
+			name:      "retries exhausted",
+			method:    http.MethodGet,
+			responses: []int{500, 500, 500, 500, 500, 500, 500},
+			wantCalls: DefaultMaxRetries + 1,
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				for k, v := range tt.header {
+					w.Header()[k] = v
+				}
+				w.WriteHeader(tt.responses[calls])
+				calls++
+			}))
+			defer server.Close()
+
+			c := newTestClient(server, "token")
+			c.retryWaitMin = time.Millisecond
+			u := c.NewTfcUrl("/test")
+			_, err := c.callAPI(context.Background(), tt.method, u.String(), `{"data":{}}`, nil)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tt.wantCalls, calls)
+		})
+	}
+}
+
+func TestClient_callAPICanceled(t *testing.T) {
+	calls := 0
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(headerRetryAfter, "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+		calls++
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	c := newTestClient(server, "token")
+	u := c.NewTfcUrl("/test")
+	start := time.Now()
+	_, err := c.callAPI(ctx, http.MethodGet, u.String(), "", nil)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Less(t, time.Since(start), 10*time.Second)
+	require.Equal(t, 1, calls)
+}
+
+func Test_parseRetryAfter(t *testing.T) {
+	d, ok := parseRetryAfter("")
+	require.False(t, ok)
+
+	d, ok = parseRetryAfter("3")
+	require.True(t, ok)
+	require.Equal(t, 3*time.Second, d)
+
+	d, ok = parseRetryAfter(time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat))
+	require.True(t, ok)
+	require.Equal(t, time.Duration(0), d)
+
+	d, ok = parseRateLimitReset("0.25")
+	require.True(t, ok)
+	require.Equal(t, 250*time.Millisecond, d)
+
+	_, ok = parseRateLimitReset("")
+	require.False(t, ok)
+}
@@ -16,16 +16,18 @@ package lib
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
-	"log"
 	"net/http"
 	"os"
 	"os/exec"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"text/tabwriter"
 	"time"
 
@@ -37,9 +39,16 @@ type UpdateConfig struct {
 	Workspace             string
 	SearchString          string //  must be an exact case-insensitive match (i.e. not a partial match)
 	NewValue              string
-	AddKeyIfNotFound      bool // If true, then SearchOnVariableValue will be treated as false
-	SearchOnVariableValue bool // If false, then will filter on variable key
-	SensitiveVariable     bool // Whether to mark the variable as sensitive
+	AddKeyIfNotFound      bool   // If true, then SearchOnVariableValue will be treated as false
+	SearchOnVariableValue bool   // If false, then will filter on variable key
+	SensitiveVariable     bool   // Whether to mark the variable as sensitive. A sensitive variable stays sensitive.
+	Category              string // "terraform" or "env", only variables in this category match. Empty means "terraform".
+	Hcl                   *bool  // Whether to parse the value as HCL. If nil, an existing variable keeps its setting.
+	Description           string // If empty, an existing variable keeps its description
+
+	// Match, if not nil, selects the variables to update instead of SearchString, SearchOnVariableValue and Category.
+	// Every matching variable is updated, rather than only the first one.
+	Match *VariableMatch
 }
 
 type CloneConfig struct {
@@ -48,21 +57,37 @@ type CloneConfig struct {
 	SourceWorkspace             string
 	NewWorkspace                string
 	NewVCSTokenID               string
-	AtlasTokenDestination       string
+	AtlasTokenDestination       string `json:"-"` // not saved in a CloneJournal
 	CopyState                   bool
 	CopyVariables               bool
-	ApplyVariableSets           bool
+	ApplyVariableSets           bool // apply the source workspace's variable sets to the new one, only in the same account
 	DifferentDestinationAccount bool
+
+	// Exclude lists categories of workspace configuration, from CloneCategories, that are not copied
+	Exclude []string
+
+	// TerraformVersion, RepoID, Branch and Directory are used for the new workspace instead of the source
+	// workspace's settings, if they are not empty
+	TerraformVersion string
+	RepoID           string
+	Branch           string
+	Directory        string
+
+	// Secrets holds values for variables, by key, that are used instead of the values in the source workspace.
+	// The values of sensitive variables can't be read, so they are taken from here or set to a placeholder. They
+	// are not saved in a CloneJournal.
+	Secrets map[string]string `json:"-"`
 }
 
 // Var is what is returned by the api for one variable
 type Var struct {
-	ID        string `json:"-"`
-	Key       string `json:"key"`
-	Value     string `json:"value"`
-	Sensitive bool   `json:"sensitive"`
-	Category  string `json:"category"`
-	Hcl       bool   `json:"hcl"`
+	ID          string `json:"-"`
+	Key         string `json:"key"`
+	Value       string `json:"value"`
+	Description string `json:"description"`
+	Sensitive   bool   `json:"sensitive"`
+	Category    string `json:"category"` // "terraform" or "env", an empty string is treated as "terraform"
+	Hcl         bool   `json:"hcl"`
 }
 
 // VarsResponse is what is returned by the api when requesting the variables of a workspace
@@ -237,6 +262,9 @@ type WorkspaceUpdateParams struct {
 
 // ConvertHCLVariable changes a Var struct in place by escaping
 // the double quotes and line endings in the Value attribute
+//
+// Deprecated: GetCreateVariablePayload and GetUpdateVariablePayload JSON-encode the value, so it no longer needs
+// escaping. Using ConvertHCLVariable before building a payload escapes the value twice.
 func ConvertHCLVariable(tfVar *Var) {
 	if !tfVar.Hcl {
 		return
@@ -247,16 +275,23 @@ func ConvertHCLVariable(tfVar *Var) {
 }
 
 // GetCreateVariablePayload returns the json needed to make a Post to the
-// Terraform vars api
+// Terraform vars api. The value and description are JSON-encoded, so they don't need escaping.
 func GetCreateVariablePayload(organization, workspaceName string, tfVar Var) string {
+	category := tfVar.Category
+	if category == "" {
+		category = "terraform"
+	}
+	value, _ := json.Marshal(tfVar.Value)
+	description, _ := json.Marshal(tfVar.Description)
 	return fmt.Sprintf(`
 {
   "data": {
     "type":"vars",
     "attributes": {
       "key":"%s",
-      "value":"%s",
-      "category":"terraform",
+      "value":%s,
+      "description":%s,
+      "category":"%s",
       "hcl":%t,
       "sensitive":%t
     }
@@ -270,12 +305,18 @@ func GetCreateVariablePayload(organization, workspaceName string, tfVar Var) str
     }
   }
 }
-`, tfVar.Key, tfVar.Value, tfVar.Hcl, tfVar.Sensitive, organization, workspaceName)
+`, tfVar.Key, value, description, category, tfVar.Hcl, tfVar.Sensitive, organization, workspaceName)
 }
 
-// GetUpdateVariablePayload returns the json needed to make a Post to the
-// Terraform vars api
+// GetUpdateVariablePayload returns the json needed to make a Patch to the
+// Terraform vars api. The value and description are JSON-encoded, so they don't need escaping.
 func GetUpdateVariablePayload(organization, workspaceName, variableID string, tfVar Var) string {
+	category := tfVar.Category
+	if category == "" {
+		category = "terraform"
+	}
+	value, _ := json.Marshal(tfVar.Value)
+	description, _ := json.Marshal(tfVar.Description)
 	return fmt.Sprintf(`
 {
   "data": {
@@ -283,9 +324,9 @@ func GetUpdateVariablePayload(organization, workspaceName, variableID string, tf
     "type":"vars",
     "attributes": {
       "key":"%s",
-      "value":"%s",
-      "category":"terraform",
-      "description":"",
+      "value":%s,
+      "category":"%s",
+      "description":%s,
       "hcl":%t,
       "sensitive":%t
     }
@@ -299,17 +340,29 @@ func GetUpdateVariablePayload(organization, workspaceName, variableID string, tf
     }
   }
 }
-`, variableID, tfVar.Key, tfVar.Value, tfVar.Hcl, tfVar.Sensitive, organization, workspaceName)
+`, variableID, tfVar.Key, value, category, description, tfVar.Hcl, tfVar.Sensitive, organization, workspaceName)
 }
 
-// OrganizationExists returns whether an organization with the given name exists
-func OrganizationExists(organization string) (bool, error) {
+// OrganizationExists returns whether an organization with the given name exists. An organization that is found is
+// remembered, so later calls for it don't need to make an API call.
+func (c *Client) OrganizationExists(organization string) (bool, error) {
+	return c.OrganizationExistsContext(context.Background(), organization)
+}
+
+// OrganizationExistsContext is like OrganizationExists but uses ctx for its API calls
+func (c *Client) OrganizationExistsContext(ctx context.Context, organization string) (bool, error) {
 	if organization == "" {
 		return false, fmt.Errorf("OrganizationExists: organization is required")
 	}
-	u := NewTfcUrl("/organizations/" + organization)
+	if c.orgs.get(organization) {
+		return true, nil
+	}
+	u := c.NewTfcUrl("/organizations/" + organization)
 
-	resp, err := callAPI(http.MethodGet, u.String(), "", nil)
+	resp, err := c.callAPI(ctx, http.MethodGet, u.String(), "", nil)
+	if errors.Is(err, ErrNotFound) {
+		return false, nil
+	}
 	if err != nil {
 		return false, err
 	}
@@ -317,60 +370,51 @@ func OrganizationExists(organization string) (bool, error) {
 	defer resp.Body.Close()
 
 	// Status 200 indicates the organization exists
-	return resp.StatusCode == http.StatusOK, nil
+	if resp.StatusCode != http.StatusOK {
+		return false, nil
+	}
+	c.orgs.set(organization)
+	return true, nil
 }
 
 // GetAllWorkspaces retrieves all workspaces from Terraform Cloud and returns a list of Workspace objects
-func GetAllWorkspaces(organization string) ([]Workspace, error) {
-	u := NewTfcUrl(fmt.Sprintf("/organizations/%s/workspaces", organization))
-	u.SetParam(paramPageSize, strconv.Itoa(pageSize))
-
-	allWsData := []Workspace{}
+func (c *Client) GetAllWorkspaces(organization string) ([]Workspace, error) {
+	return c.GetAllWorkspacesContext(context.Background(), organization)
+}
 
-	for page := 1; ; page++ {
-		u.SetParam(paramPageNumber, strconv.Itoa(page))
-		nextWsData, err := getWorkspacePage(u.String())
-		if err != nil {
-			return nil, fmt.Errorf("error getting workspace data for %s: %s", organization, err)
-		}
-		allWsData = append(allWsData, nextWsData.Data...)
+// GetAllWorkspacesContext is like GetAllWorkspaces but uses ctx for its API calls
+func (c *Client) GetAllWorkspacesContext(ctx context.Context, organization string) ([]Workspace, error) {
+	u := c.NewTfcUrl(fmt.Sprintf("/organizations/%s/workspaces", organization))
 
-		// If there isn't a whole page of contents, then we're on the last one.
-		if len(nextWsData.Data) < pageSize {
-			break
-		}
+	allWsData, err := getAllPages[Workspace](ctx, c, u)
+	if err != nil {
+		return nil, fmt.Errorf("error getting workspace data for %s: %s", organization, err)
+	}
+	if allWsData == nil {
+		allWsData = []Workspace{}
 	}
 	return allWsData, nil
 }
 
-func getWorkspacePage(url string) (WorkspaceList, error) {
-	resp, err := callAPI(http.MethodGet, url, "", nil)
-	if err != nil {
-		return WorkspaceList{}, err
-	}
-	defer resp.Body.Close()
-
-	var nextWsData WorkspaceList
-	if err := json.NewDecoder(resp.Body).Decode(&nextWsData); err != nil {
-		return WorkspaceList{}, fmt.Errorf("json decode error: %s", err)
-	}
-	return nextWsData, nil
+func (c *Client) GetWorkspaceData(organization, workspaceName string) (WorkspaceJSON, error) {
+	return c.GetWorkspaceDataContext(context.Background(), organization, workspaceName)
 }
 
-func GetWorkspaceData(organization, workspaceName string) (WorkspaceJSON, error) {
+// GetWorkspaceDataContext is like GetWorkspaceData but uses ctx for its API calls
+func (c *Client) GetWorkspaceDataContext(ctx context.Context, organization, workspaceName string) (WorkspaceJSON, error) {
 	if organization == "" {
 		return WorkspaceJSON{}, fmt.Errorf("GetWorkspaceData: organization is required")
 	}
 	if workspaceName == "" {
 		return WorkspaceJSON{}, fmt.Errorf("GetWorkspaceData: workspace is required")
 	}
-	u := NewTfcUrl(fmt.Sprintf(
+	u := c.NewTfcUrl(fmt.Sprintf(
 		"/organizations/%s/workspaces/%s",
 		organization,
 		workspaceName,
 	))
 
-	resp, err := callAPI(http.MethodGet, u.String(), "", nil)
+	resp, err := c.callAPI(ctx, http.MethodGet, u.String(), "", nil)
 	if err != nil {
 		return WorkspaceJSON{}, err
 	}
@@ -385,8 +429,13 @@ func GetWorkspaceData(organization, workspaceName string) (WorkspaceJSON, error)
 }
 
 // GetWorkspaceVar retrieves the variables from a Workspace and returns the Var that matches the given key
-func GetWorkspaceVar(organization, wsName, key string) (*Var, error) {
-	vars, err := GetVarsFromWorkspace(organization, wsName)
+func (c *Client) GetWorkspaceVar(organization, wsName, key string) (*Var, error) {
+	return c.GetWorkspaceVarContext(context.Background(), organization, wsName, key)
+}
+
+// GetWorkspaceVarContext is like GetWorkspaceVar but uses ctx for its API calls
+func (c *Client) GetWorkspaceVarContext(ctx context.Context, organization, wsName, key string) (*Var, error) {
+	vars, err := c.GetVarsFromWorkspaceContext(ctx, organization, wsName)
 	if err != nil {
 		return nil, fmt.Errorf("Error getting variables for %s:%s\n%w", organization, wsName, err)
 	}
@@ -400,9 +449,37 @@ func GetWorkspaceVar(organization, wsName, key string) (*Var, error) {
 	return nil, nil
 }
 
+// GetWorkspaceVarInCategory is like GetWorkspaceVar but only matches a variable in the given category, "terraform" or
+// "env". An empty category means "terraform".
+func (c *Client) GetWorkspaceVarInCategory(organization, wsName, category, key string) (*Var, error) {
+	return c.GetWorkspaceVarInCategoryContext(context.Background(), organization, wsName, category, key)
+}
+
+// GetWorkspaceVarInCategoryContext is like GetWorkspaceVarInCategory but uses ctx for its API calls
+func (c *Client) GetWorkspaceVarInCategoryContext(ctx context.Context, organization, wsName, category, key string) (*Var, error) {
+	vars, err := c.GetVarsFromWorkspaceContext(ctx, organization, wsName)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting variables for %s:%s\n%w", organization, wsName, err)
+	}
+
+	category = varCategory(Var{Category: category})
+	for _, v := range vars {
+		if v.Key == key && varCategory(v) == category {
+			found := v
+			return &found, nil
+		}
+	}
+	return nil, nil
+}
+
 // GetVarsFromWorkspace returns a list of Terraform variables for a given workspace
-func GetVarsFromWorkspace(organization, workspaceName string) ([]Var, error) {
-	orgExists, err := OrganizationExists(organization)
+func (c *Client) GetVarsFromWorkspace(organization, workspaceName string) ([]Var, error) {
+	return c.GetVarsFromWorkspaceContext(context.Background(), organization, workspaceName)
+}
+
+// GetVarsFromWorkspaceContext is like GetVarsFromWorkspace but uses ctx for its API calls
+func (c *Client) GetVarsFromWorkspaceContext(ctx context.Context, organization, workspaceName string) ([]Var, error) {
+	orgExists, err := c.OrganizationExistsContext(ctx, organization)
 	if err != nil {
 		return nil, fmt.Errorf("GetVarsFromWorkspace: organization is required: %w", err)
 	} else if !orgExists {
@@ -413,100 +490,115 @@ func GetVarsFromWorkspace(organization, workspaceName string) ([]Var, error) {
 		return nil, fmt.Errorf("GetVarsFromWorkspace: workspace is required")
 	}
 
-	u := NewTfcUrl("/vars")
+	u := c.NewTfcUrl("/vars")
 	u.SetParam(paramFilterOrganizationName, organization)
 	u.SetParam(paramFilterWorkspaceName, workspaceName)
 
-	resp, err := callAPI(http.MethodGet, u.String(), "", nil)
+	variables := []Var{}
+	err = c.forEachPage(ctx, u, func(page []byte) error {
+		var varsResp VarsResponse
+		if err := json.Unmarshal(page, &varsResp); err != nil {
+			return fmt.Errorf("Error getting variables for %s:%s ...\n%s", organization, workspaceName, err.Error())
+		}
+		for _, data := range varsResp.Data {
+			data.Variable.ID = data.ID // push the ID down into the Variable for future reference
+			variables = append(variables, data.Variable)
+		}
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-
-	var varsResp VarsResponse
-	if err := json.NewDecoder(resp.Body).Decode(&varsResp); err != nil {
-		return nil, fmt.Errorf("Error getting variables for %s:%s ...\n%s", organization, workspaceName, err.Error())
-	}
-
-	variables := []Var{}
-	for _, data := range varsResp.Data {
-		data.Variable.ID = data.ID // push the ID down into the Variable for future reference
-		variables = append(variables, data.Variable)
-	}
 
 	return variables, nil
 }
 
 // DeleteVariable deletes a variable from a workspace
-func DeleteVariable(variableID string) {
-	u := NewTfcUrl("/vars/" + variableID)
+func (c *Client) DeleteVariable(variableID string) error {
+	return c.DeleteVariableContext(context.Background(), variableID)
+}
 
-	resp, err := callAPI(http.MethodDelete, u.String(), "", nil)
+// DeleteVariableContext is like DeleteVariable but uses ctx for its API calls
+func (c *Client) DeleteVariableContext(ctx context.Context, variableID string) error {
+	u := c.NewTfcUrl("/vars/" + variableID)
+
+	resp, err := c.callAPI(ctx, http.MethodDelete, u.String(), "", nil)
 	if err != nil {
-		log.Fatalln(err)
+		return fmt.Errorf("failed to delete variable %s: %w", variableID, err)
 	}
-	_ = resp.Body.Close()
+	return resp.Body.Close()
 }
 
 // SearchVarsInAllWorkspaces returns all the variables that match the search terms 'keyContains' and 'valueContains'
 // in all workspaces given. The return value is a map of variable lists with the workspace name as the key.
-func SearchVarsInAllWorkspaces(wsData []Workspace, organization, keyContains, valueContains string) (map[string][]Var, error) {
-	allVars := map[string][]Var{}
+// Workspaces are searched in parallel. If the search stops early, the variables found so far are returned with an
+// *IncompleteError.
+func (c *Client) SearchVarsInAllWorkspaces(wsData []Workspace, organization, keyContains, valueContains string) (map[string][]Var, error) {
+	return c.SearchVarsInAllWorkspacesContext(context.Background(), wsData, organization, keyContains, valueContains)
+}
 
-	for _, ws := range wsData {
-		wsName := ws.Attributes.Name
+// SearchVarsInAllWorkspacesContext is like SearchVarsInAllWorkspaces but uses ctx for its API calls
+func (c *Client) SearchVarsInAllWorkspacesContext(ctx context.Context, wsData []Workspace, organization, keyContains, valueContains string) (map[string][]Var, error) {
+	allVars := map[string][]Var{}
+	var mu sync.Mutex
 
-		wsVars, err := SearchVariables(organization, wsName, keyContains, valueContains)
+	err := c.ForEachWorkspace(ctx, workspaceNames(wsData), nil, func(ctx context.Context, wsName string, _ io.Writer) error {
+		wsVars, err := c.SearchVariablesContext(ctx, organization, wsName, keyContains, valueContains)
 		if err != nil {
-			return nil, err
+			return err
 		}
+		mu.Lock()
+		defer mu.Unlock()
 		allVars[wsName] = wsVars
-	}
+		return nil
+	})
+	return allVars, err
+}
 
-	return allVars, nil
+func workspaceNames(workspaces []Workspace) []string {
+	names := make([]string, len(workspaces))
+	for i, ws := range workspaces {
+		names[i] = ws.Attributes.Name
+	}
+	return names
 }
 
 // SearchVariables returns a list of variables in the given workspace that match the search terms
 // 'keyContains' and 'valueContains'
-func SearchVariables(organization, wsName, keyContains, valueContains string) ([]Var, error) {
-	vars, err := GetVarsFromWorkspace(organization, wsName)
+func (c *Client) SearchVariables(organization, wsName, keyContains, valueContains string) ([]Var, error) {
+	return c.SearchVariablesContext(context.Background(), organization, wsName, keyContains, valueContains)
+}
+
+// SearchVariablesContext is like SearchVariables but uses ctx for its API calls
+func (c *Client) SearchVariablesContext(ctx context.Context, organization, wsName, keyContains, valueContains string) ([]Var, error) {
+	vars, err := c.GetVarsFromWorkspaceContext(ctx, organization, wsName)
 	if err != nil {
 		err := fmt.Errorf("Error getting variables for %s:%s\n%s", organization, wsName, err.Error())
 		return nil, err
 	}
 
-	var wsVars []Var
-
-	for _, v := range vars {
-		if keyContains != "" && strings.Contains(v.Key, keyContains) {
-			wsVars = append(wsVars, v)
-			continue
-		}
-		if valueContains != "" && strings.Contains(v.Value, valueContains) {
-			wsVars = append(wsVars, v)
-		}
+	if keyContains == "" && valueContains == "" {
+		return nil, nil
 	}
-
-	return wsVars, nil
+	return FilterVariables(vars, VariableMatch{KeyContains: keyContains, ValueContains: valueContains, Any: true})
 }
 
 // GetTeamAccessFrom returns the team access data from an existing workspace
-func GetTeamAccessFrom(workspaceID string) (AllTeamWorkspaceData, error) {
-	u := NewTfcUrl("/team-workspaces")
+func (c *Client) GetTeamAccessFrom(workspaceID string) (AllTeamWorkspaceData, error) {
+	return c.GetTeamAccessFromContext(context.Background(), workspaceID)
+}
+
+// GetTeamAccessFromContext is like GetTeamAccessFrom but uses ctx for its API calls
+func (c *Client) GetTeamAccessFromContext(ctx context.Context, workspaceID string) (AllTeamWorkspaceData, error) {
+	u := c.NewTfcUrl("/team-workspaces")
 	u.SetParam(paramFilterWorkspaceID, workspaceID)
 
-	resp, err := callAPI(http.MethodGet, u.String(), "", nil)
+	data, err := getAllPages[TeamWorkspaceData](ctx, c, u)
 	if err != nil {
-		return AllTeamWorkspaceData{}, err
-	}
-	defer resp.Body.Close()
-
-	var allTeamData AllTeamWorkspaceData
-	if err := json.NewDecoder(resp.Body).Decode(&allTeamData); err != nil {
 		return AllTeamWorkspaceData{}, fmt.Errorf("Error getting team workspace data for %s\n%s", workspaceID, err.Error())
 	}
 
-	return allTeamData, nil
+	return AllTeamWorkspaceData{Data: data}, nil
 }
 
 func getAssignTeamAccessPayload(accessLevel, workspaceID, teamID string) string {
@@ -537,46 +629,143 @@ func getAssignTeamAccessPayload(accessLevel, workspaceID, teamID string) string
 }
 
 // AssignTeamAccess assigns the requested team access to a workspace on Terraform Cloud
-func AssignTeamAccess(workspaceID string, allTeamData AllTeamWorkspaceData) {
-	url := fmt.Sprintf(baseURL + "/team-workspaces")
+func (c *Client) AssignTeamAccess(workspaceID string, allTeamData AllTeamWorkspaceData) error {
+	return c.AssignTeamAccessContext(context.Background(), workspaceID, allTeamData)
+}
 
+// AssignTeamAccessContext is like AssignTeamAccess but uses ctx for its API calls
+func (c *Client) AssignTeamAccessContext(ctx context.Context, workspaceID string, allTeamData AllTeamWorkspaceData) error {
 	for _, teamData := range allTeamData.Data {
-		postData := getAssignTeamAccessPayload(
-			teamData.Attributes.Access,
-			workspaceID,
-			teamData.Relationships.Team.Data.ID,
-		)
+		if _, err := c.assignTeamAccess(ctx, workspaceID, teamData.Relationships.Team.Data.ID, teamData.Attributes.Access); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// assignTeamAccess gives a team access to a workspace and returns the ID of the team access object
+func (c *Client) assignTeamAccess(ctx context.Context, workspaceID, teamID, accessLevel string) (string, error) {
+	u := c.NewTfcUrl("/team-workspaces")
+	postData := getAssignTeamAccessPayload(accessLevel, workspaceID, teamID)
+
+	resp, err := c.callAPI(ctx, http.MethodPost, u.String(), postData, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to assign team access for team %s: %w", teamID, err)
+	}
+	defer resp.Body.Close()
+
+	return parseCreatedID(resp.Body)
+}
+
+// UpdateTeamAccess changes the access level of an existing team access object, as found in the data returned by
+// GetTeamAccessFrom
+func (c *Client) UpdateTeamAccess(teamWorkspaceID, accessLevel string) error {
+	return c.UpdateTeamAccessContext(context.Background(), teamWorkspaceID, accessLevel)
+}
+
+// UpdateTeamAccessContext is like UpdateTeamAccess but uses ctx for its API calls
+func (c *Client) UpdateTeamAccessContext(ctx context.Context, teamWorkspaceID, accessLevel string) error {
+	u := c.NewTfcUrl("/team-workspaces/" + teamWorkspaceID)
+
+	jsonObj := gabs.New()
+	_, _ = jsonObj.SetP(accessLevel, "data.attributes.access")
+
+	resp, err := c.callAPI(ctx, http.MethodPatch, u.String(), jsonObj.String(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to update team access %s: %w", teamWorkspaceID, err)
+	}
+	return resp.Body.Close()
+}
+
+// Team is a team in an organization
+type Team struct {
+	ID   string
+	Name string
+}
+
+// ListTeams returns all the teams in an organization
+func (c *Client) ListTeams(organization string) ([]Team, error) {
+	return c.ListTeamsContext(context.Background(), organization)
+}
 
-		resp, err := callAPI(http.MethodPost, url, postData, nil)
+// ListTeamsContext is like ListTeams but uses ctx for its API calls
+func (c *Client) ListTeamsContext(ctx context.Context, organization string) ([]Team, error) {
+	u := c.NewTfcUrl(fmt.Sprintf("/organizations/%s/teams", organization))
+
+	var teams []Team
+	err := c.forEachPage(ctx, u, func(page []byte) error {
+		parsed, err := gabs.ParseJSON(page)
 		if err != nil {
-			log.Fatalln(err)
+			return fmt.Errorf("failed to parse team list: %w", err)
+		}
+
+		for _, t := range parsed.S("data").Children() {
+			id, _ := t.S("id").Data().(string)
+			name, _ := t.Path("attributes.name").Data().(string)
+			teams = append(teams, Team{ID: id, Name: name})
 		}
-		defer resp.Body.Close()
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error listing teams in %s: %w", organization, err)
 	}
+	return teams, nil
 }
 
 // CreateVariable makes a Terraform vars API POST to create a variable
 // for a given organization and workspace
-func CreateVariable(organization, workspaceName string, tfVar Var) {
-	url := baseURL + "/vars"
+func (c *Client) CreateVariable(organization, workspaceName string, tfVar Var) error {
+	return c.CreateVariableContext(context.Background(), organization, workspaceName, tfVar)
+}
+
+// CreateVariableContext is like CreateVariable but uses ctx for its API calls
+func (c *Client) CreateVariableContext(ctx context.Context, organization, workspaceName string, tfVar Var) error {
+	_, err := c.createVariable(ctx, organization, workspaceName, tfVar)
+	return err
+}
 
-	ConvertHCLVariable(&tfVar)
+// createVariable creates a variable and returns its ID
+func (c *Client) createVariable(ctx context.Context, organization, workspaceName string, tfVar Var) (string, error) {
+	u := c.NewTfcUrl("/vars")
 
 	postData := GetCreateVariablePayload(organization, workspaceName, tfVar)
 
-	resp, err := callAPI(http.MethodPost, url, postData, nil)
+	resp, err := c.callAPI(ctx, http.MethodPost, u.String(), postData, nil)
 	if err != nil {
-		log.Fatalln(err)
+		return "", fmt.Errorf("failed to create variable %s: %w", tfVar.Key, err)
 	}
 	defer resp.Body.Close()
+
+	return parseCreatedID(resp.Body)
+}
+
+// parseCreatedID returns the ID of the object in a response to a POST request
+func parseCreatedID(r io.Reader) (string, error) {
+	var created struct {
+		Data struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(r).Decode(&created); err != nil {
+		return "", fmt.Errorf("unexpected content in response: %w", err)
+	}
+	return created.Data.ID, nil
 }
 
 // CreateAllVariables makes several Terraform vars API POSTs to create
-// variables for a given organization and workspace
-func CreateAllVariables(organization, workspaceName string, tfVars []Var) {
+// variables for a given organization and workspace. It stops at the first failure.
+func (c *Client) CreateAllVariables(organization, workspaceName string, tfVars []Var) error {
+	return c.CreateAllVariablesContext(context.Background(), organization, workspaceName, tfVars)
+}
+
+// CreateAllVariablesContext is like CreateAllVariables but uses ctx for its API calls
+func (c *Client) CreateAllVariablesContext(ctx context.Context, organization, workspaceName string, tfVars []Var) error {
 	for _, nextVar := range tfVars {
-		CreateVariable(organization, workspaceName, nextVar)
+		if err := c.CreateVariableContext(ctx, organization, workspaceName, nextVar); err != nil {
+			return err
+		}
 	}
+	return nil
 }
 
 // GetCreateWorkspacePayload returns the JSON needed to make a POST to the
@@ -602,32 +791,36 @@ func GetCreateWorkspacePayload(oc OpsConfig, vcsTokenID string) string {
 
 // UpdateVariable makes a Terraform vars API call to update a variable
 // for a given organization and workspace
-func UpdateVariable(organization, workspaceName, variableID string, tfVar Var) {
-	url := fmt.Sprintf(baseURL+"/vars/%s", variableID)
+func (c *Client) UpdateVariable(organization, workspaceName, variableID string, tfVar Var) error {
+	return c.UpdateVariableContext(context.Background(), organization, workspaceName, variableID, tfVar)
+}
 
-	ConvertHCLVariable(&tfVar)
+// UpdateVariableContext is like UpdateVariable but uses ctx for its API calls
+func (c *Client) UpdateVariableContext(ctx context.Context, organization, workspaceName, variableID string, tfVar Var) error {
+	u := c.NewTfcUrl("/vars/" + variableID)
 
 	patchData := GetUpdateVariablePayload(organization, workspaceName, variableID, tfVar)
 
-	resp, err := callAPI(http.MethodPatch, url, patchData, nil)
+	resp, err := c.callAPI(ctx, http.MethodPatch, u.String(), patchData, nil)
 	if err != nil {
-		log.Fatalln(err)
+		return fmt.Errorf("failed to update variable %s: %w", tfVar.Key, err)
 	}
-
-	defer resp.Body.Close()
+	return resp.Body.Close()
 }
 
 // CreateWorkspace makes a Terraform workspaces API call to create a
 // workspace for a given organization, including setting up its VCS repo integration
-func CreateWorkspace(oc OpsConfig, vcsTokenID string) (string, error) {
-	url := fmt.Sprintf(
-		baseURL+"/organizations/%s/workspaces",
-		oc.NewOrg,
-	)
+func (c *Client) CreateWorkspace(oc OpsConfig, vcsTokenID string) (string, error) {
+	return c.CreateWorkspaceContext(context.Background(), oc, vcsTokenID)
+}
+
+// CreateWorkspaceContext is like CreateWorkspace but uses ctx for its API calls
+func (c *Client) CreateWorkspaceContext(ctx context.Context, oc OpsConfig, vcsTokenID string) (string, error) {
+	u := c.NewTfcUrl(fmt.Sprintf("/organizations/%s/workspaces", oc.NewOrg))
 
 	postData := GetCreateWorkspacePayload(oc, vcsTokenID)
 
-	resp, err := callAPI(http.MethodPost, url, postData, nil)
+	resp, err := c.callAPI(ctx, http.MethodPost, u.String(), postData, nil)
 	if err != nil {
 		return "", err
 	}
@@ -642,15 +835,17 @@ func CreateWorkspace(oc OpsConfig, vcsTokenID string) (string, error) {
 
 // CreateWorkspace2 makes a Terraform workspaces API call to create a workspace for a given organization, including
 // setting up its VCS repo integration. Returns the properties of the new workspace.
-func CreateWorkspace2(oc OpsConfig, vcsTokenID string) (Workspace, error) {
-	url := fmt.Sprintf(
-		baseURL+"/organizations/%s/workspaces",
-		oc.NewOrg,
-	)
+func (c *Client) CreateWorkspace2(oc OpsConfig, vcsTokenID string) (Workspace, error) {
+	return c.CreateWorkspace2Context(context.Background(), oc, vcsTokenID)
+}
+
+// CreateWorkspace2Context is like CreateWorkspace2 but uses ctx for its API calls
+func (c *Client) CreateWorkspace2Context(ctx context.Context, oc OpsConfig, vcsTokenID string) (Workspace, error) {
+	u := c.NewTfcUrl(fmt.Sprintf("/organizations/%s/workspaces", oc.NewOrg))
 
 	postData := GetCreateWorkspacePayload(oc, vcsTokenID)
 
-	resp, err := callAPI(http.MethodPost, url, postData, nil)
+	resp, err := c.callAPI(ctx, http.MethodPost, u.String(), postData, nil)
 	if err != nil {
 		return Workspace{}, err
 	}
@@ -670,7 +865,18 @@ func CreateWorkspace2(oc OpsConfig, vcsTokenID string) (Workspace, error) {
 //
 // NOTE: This procedure can be used to copy/migrate a workspace's state to a new one.
 // (see the -backend-config mention below and the backend.tf file in this repo)
-func RunTFInit(oc OpsConfig, tfTokenDestination string) error {
+//
+// The client's token is given to terraform for the source workspace and tfTokenDestination for the new workspace.
+//
+// Deprecated: RunTFInit requires a terraform binary and runs in the current directory. Use CopyState instead.
+func (c *Client) RunTFInit(oc OpsConfig, tfTokenDestination string) error {
+	return c.RunTFInitContext(context.Background(), oc, tfTokenDestination)
+}
+
+// RunTFInitContext is like RunTFInit but uses ctx for its API calls
+//
+// Deprecated: Use CopyStateContext instead.
+func (c *Client) RunTFInitContext(ctx context.Context, oc OpsConfig, tfTokenDestination string) error {
 	var tfInit string
 	var err error
 	var osCmd *exec.Cmd
@@ -687,9 +893,11 @@ func RunTFInit(oc OpsConfig, tfTokenDestination string) error {
 		}
 	}
 
+	tfHostname := `-backend-config=hostname=` + c.hostname
 	tfInit = fmt.Sprintf(`-backend-config=name=%s/%s`, oc.SourceOrg, oc.SourceName)
 
-	osCmd = exec.Command("terraform", "init", tfInit)
+	osCmd = exec.CommandContext(ctx, "terraform", "init", tfHostname, tfInit)
+	osCmd.Env = append(os.Environ(), terraformTokenEnv(c.hostname, c.token))
 	osCmd.Stderr = &stderr
 
 	err = osCmd.Run()
@@ -699,11 +907,10 @@ func RunTFInit(oc OpsConfig, tfTokenDestination string) error {
 		return err
 	}
 
-	SetToken(tfTokenDestination)
-
 	// Run tf init with new version
 	tfInit = fmt.Sprintf(`-backend-config=name=%s/%s`, oc.NewOrg, oc.NewName)
-	osCmd = exec.Command("terraform", "init", tfInit)
+	osCmd = exec.CommandContext(ctx, "terraform", "init", tfHostname, tfInit)
+	osCmd.Env = append(os.Environ(), terraformTokenEnv(c.hostname, tfTokenDestination))
 	osCmd.Stderr = &stderr
 
 	// Needed to run the command interactively, in order to allow for an automated reply
@@ -732,168 +939,143 @@ func RunTFInit(oc OpsConfig, tfTokenDestination string) error {
 	return nil
 }
 
+// terraformTokenEnv returns the environment variable assignment that terraform uses to find the API token for a host
+func terraformTokenEnv(hostname, token string) string {
+	name := strings.ReplaceAll(hostname, "-", "__")
+	name = strings.ReplaceAll(name, ".", "_")
+	return "TF_TOKEN_" + name + "=" + token
+}
+
 // CloneWorkspace gets the data, variables and team access data for an existing Terraform Cloud workspace
 // and then creates a clone of it with the same data.
 //
-// If the copyVariables param is set to true, then all the non-sensitive variable values will be added to the new
-// workspace.  Otherwise, they will be set to "REPLACE_THIS_VALUE"
-func CloneWorkspace(cfg CloneConfig) ([]string, error) {
-	sourceWsData, err := GetWorkspaceData(cfg.Organization, cfg.SourceWorkspace)
-	if err != nil {
-		return nil, err
-	}
+// Variables keep their category, description and HCL and sensitive settings. If the copyVariables param is set to
+// true, then all the non-sensitive variable values will be added to the new workspace.  Otherwise, they will be set
+// to "REPLACE_THIS_VALUE". Sensitive variables get their value from cfg.Secrets, or "REPLACE_THIS_VALUE" if it has
+// none. The keys of the variables that were given "REPLACE_THIS_VALUE" are returned, since they need to be set by
+// hand.
+//
+// The workspace settings, project, tags, remote state consumers, inbound run triggers and notification configurations
+// are also copied, except for the categories in cfg.Exclude. When cloning to a different organization, the project,
+// agent pool, run triggers and remote state consumers are not copied because they refer to objects in the source
+// organization, and notifications are not sent to the source organization's users.
+//
+// If CopyState is set, the current state of the existing workspace is copied to the new one (see CopyState).
+//
+// If the clone fails, the new workspace is deleted. Use CloneWithJournal to keep a record of the clone's progress, so
+// that it can be resumed after a failure.
+func (c *Client) CloneWorkspace(cfg CloneConfig) ([]string, error) {
+	return c.CloneWorkspaceContext(context.Background(), cfg)
+}
 
-	variables, err := GetVarsFromWorkspace(cfg.Organization, cfg.SourceWorkspace)
+// CloneWorkspaceContext is like CloneWorkspace but uses ctx for its API calls
+func (c *Client) CloneWorkspaceContext(ctx context.Context, cfg CloneConfig) ([]string, error) {
+	journal := NewCloneJournal(cfg, "")
+	needValues, err := c.CloneWithJournalContext(ctx, journal)
 	if err != nil {
-		return nil, err
-	}
-
-	if !cfg.DifferentDestinationAccount {
-		cfg.NewOrganization = cfg.Organization
-		cfg.NewVCSTokenID = sourceWsData.Data.Attributes.VCSRepo.Identifier
-	}
-
-	oc := OpsConfig{
-		SourceOrg:        cfg.Organization,
-		SourceName:       sourceWsData.Data.Attributes.Name,
-		NewOrg:           cfg.NewOrganization,
-		NewName:          cfg.NewWorkspace,
-		TerraformVersion: sourceWsData.Data.Attributes.TerraformVersion,
-		RepoID:           sourceWsData.Data.Attributes.VCSRepo.Identifier,
-		Branch:           sourceWsData.Data.Attributes.VCSRepo.Branch,
-		Directory:        sourceWsData.Data.Attributes.WorkingDirectory,
-	}
-
-	sensitiveVars := []string{}
-	sensitiveValue := "TF_ENTERPRISE_SENSITIVE_VAR"
-	defaultValue := "REPLACE_THIS_VALUE"
-
-	tfVars := []Var{}
-	var tfVar Var
-
-	for _, nextVar := range variables {
-		if cfg.CopyVariables {
-			tfVar = Var{
-				Key:   nextVar.Key,
-				Value: nextVar.Value,
-				Hcl:   nextVar.Hcl,
-			}
-		} else {
-			tfVar = Var{
-				Key:   nextVar.Key,
-				Value: defaultValue,
-				Hcl:   nextVar.Hcl,
-			}
-		}
-		if nextVar.Value == sensitiveValue {
-			sensitiveVars = append(sensitiveVars, nextVar.Key)
+		// roll back even if ctx was canceled
+		if rollbackErr := c.RollbackCloneContext(context.Background(), journal); rollbackErr != nil {
+			return needValues, fmt.Errorf("%w (rollback failed: %s)", err, rollbackErr)
 		}
-		tfVars = append(tfVars, tfVar)
-	}
-
-	if config.readOnly {
-		return sensitiveVars, nil
 	}
+	return needValues, err
+}
 
-	if cfg.DifferentDestinationAccount {
-		// save primary token and set destination token to create the workspace and variables
-		primaryToken := config.token
-		SetToken(cfg.AtlasTokenDestination)
-		_, err := CreateWorkspace(oc, cfg.NewVCSTokenID)
-		if err != nil {
-			return nil, err
+// PlaceholderValue is the value given to a variable whose real value is not known, such as a sensitive variable in a
+// cloned workspace
+const PlaceholderValue = "REPLACE_THIS_VALUE"
+
+// cloneVariables returns the variables to create in a cloned workspace and the keys of the variables that were given
+// a placeholder value
+func cloneVariables(variables []Var, copyValues bool, secrets map[string]string) ([]Var, []string) {
+	const legacySensitiveValue = "TF_ENTERPRISE_SENSITIVE_VAR"
+
+	tfVars := make([]Var, 0, len(variables))
+	needValues := []string{}
+	for _, v := range variables {
+		tfVar := Var{
+			Key:         v.Key,
+			Value:       v.Value,
+			Description: v.Description,
+			Sensitive:   v.Sensitive || v.Value == legacySensitiveValue,
+			Category:    v.Category,
+			Hcl:         v.Hcl,
 		}
-		CreateAllVariables(oc.NewOrg, oc.NewName, tfVars)
-		SetToken(primaryToken)
-
-		if cfg.CopyState {
-			if err := RunTFInit(oc, cfg.AtlasTokenDestination); err != nil {
-				return sensitiveVars, err
-			}
+		if secret, ok := secrets[v.Key]; ok {
+			tfVar.Value = secret
+		} else if tfVar.Sensitive || !copyValues {
+			tfVar.Value = PlaceholderValue
+			needValues = append(needValues, v.Key)
 		}
-
-		return sensitiveVars, nil
-	}
-
-	destWsProps, err := CreateWorkspace2(oc, sourceWsData.Data.Attributes.VCSRepo.TokenID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create new workspace: %w", err)
-	}
-
-	err = copyVariableSetList(sourceWsData.Data.ID, destWsProps.ID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to clone variable sets: %w", err)
-	}
-
-	CreateAllVariables(oc.NewOrg, oc.NewName, tfVars)
-
-	// Get Team Access Data for source Workspace
-	allTeamData, err := GetTeamAccessFrom(sourceWsData.Data.ID)
-	if err != nil {
-		return sensitiveVars, err
-	}
-
-	// Get new Workspace data for its ID
-	newWsData, err := GetWorkspaceData(cfg.Organization, cfg.NewWorkspace)
-	if err != nil {
-		return sensitiveVars, err
+		tfVars = append(tfVars, tfVar)
 	}
-
-	AssignTeamAccess(newWsData.Data.ID, allTeamData)
-
-	return sensitiveVars, nil
+	return tfVars, needValues
 }
 
 // AddOrUpdateVariable adds or updates an existing Terraform Cloud workspace variable
 // If the copyVariables param is set to true, then all the non-sensitive variable values will be added to the new
 // workspace.  Otherwise, they will be set to "REPLACE_THIS_VALUE"
-func AddOrUpdateVariable(cfg UpdateConfig) (string, error) {
-	variables, err := GetVarsFromWorkspace(cfg.Organization, cfg.Workspace)
+func (c *Client) AddOrUpdateVariable(cfg UpdateConfig) (string, error) {
+	return c.AddOrUpdateVariableContext(context.Background(), cfg)
+}
+
+// AddOrUpdateVariableContext is like AddOrUpdateVariable but uses ctx for its API calls
+func (c *Client) AddOrUpdateVariableContext(ctx context.Context, cfg UpdateConfig) (string, error) {
+	variables, err := c.GetVarsFromWorkspaceContext(ctx, cfg.Organization, cfg.Workspace)
 	if err != nil {
 		return "", err
 	}
 
-	loweredSearchString := strings.ToLower(cfg.SearchString)
-
-	for _, nextVar := range variables {
-		oldValue := nextVar.Value
-		if cfg.SearchOnVariableValue {
-			if strings.ToLower(nextVar.Value) != loweredSearchString {
-				continue
-			}
-			// Found a match
-			tfVar := Var{Key: nextVar.Key, Value: cfg.NewValue, Hcl: false, Sensitive: cfg.SensitiveVariable}
-			if !config.readOnly {
-				UpdateVariable(cfg.Organization, cfg.Workspace, nextVar.ID, tfVar)
-			}
-			return fmt.Sprintf("Replaced the value of %s from %s to %s", nextVar.Key, oldValue, cfg.NewValue), nil
-		}
-
-		// Search on variable key, since search on value is not true
-		if strings.ToLower(nextVar.Key) != loweredSearchString {
-			continue
-		}
-
-		// Found a match
-		// Only add if there isn't a match
+	category := varCategory(Var{Category: cfg.Category})
+	match := exactKeyMatch(cfg.SearchString, cfg.SearchOnVariableValue && !cfg.AddKeyIfNotFound)
+	match.Category = category
+	if cfg.Match != nil {
 		if cfg.AddKeyIfNotFound {
-			return "", errors.New("addKeyIfNotFound was set to true but a variable already exists with key " + nextVar.Key)
+			return "", errors.New("addKeyIfNotFound may not be used with a variable match")
 		}
+		match = *cfg.Match
+	}
+	matched, err := FilterVariables(variables, match)
+	if err != nil {
+		return "", err
+	}
+	if cfg.Match == nil && len(matched) > 1 {
+		matched = matched[:1]
+	}
 
-		tfVar := Var{Key: nextVar.Key, Value: cfg.NewValue, Hcl: false, Sensitive: cfg.SensitiveVariable}
+	// Only add if there isn't a match
+	if len(matched) > 0 && cfg.AddKeyIfNotFound {
+		return "", errors.New("addKeyIfNotFound was set to true but a variable already exists with key " + matched[0].Key)
+	}
 
-		if !config.readOnly {
-			UpdateVariable(cfg.Organization, cfg.Workspace, nextVar.ID, tfVar)
+	var messages []string
+	for _, nextVar := range matched {
+		if !c.readOnly {
+			if err := c.UpdateVariableContext(ctx, cfg.Organization, cfg.Workspace, nextVar.ID, updatedVar(nextVar, cfg)); err != nil {
+				return "", err
+			}
 		}
-		return fmt.Sprintf("Replaced the value of %s from %s to %s", nextVar.Key, oldValue, cfg.NewValue), nil
+		messages = append(messages, fmt.Sprintf("Replaced the value of %s from %s to %s", nextVar.Key, nextVar.Value, cfg.NewValue))
+	}
+	if len(messages) > 0 {
+		return strings.Join(messages, "\n"), nil
 	}
 
 	// At this point, we haven't found a match
 	if cfg.AddKeyIfNotFound {
-		tfVar := Var{Key: cfg.SearchString, Value: cfg.NewValue, Hcl: false, Sensitive: cfg.SensitiveVariable}
+		tfVar := Var{
+			Key:         cfg.SearchString,
+			Value:       cfg.NewValue,
+			Description: cfg.Description,
+			Category:    category,
+			Hcl:         cfg.Hcl != nil && *cfg.Hcl,
+			Sensitive:   cfg.SensitiveVariable,
+		}
 
-		if !config.readOnly {
-			CreateVariable(cfg.Organization, cfg.Workspace, tfVar)
+		if !c.readOnly {
+			if err := c.CreateVariableContext(ctx, cfg.Organization, cfg.Workspace, tfVar); err != nil {
+				return "", err
+			}
 		}
 		return fmt.Sprintf("Added variable %s = %s", cfg.SearchString, cfg.NewValue), nil
 	}
@@ -901,6 +1083,26 @@ func AddOrUpdateVariable(cfg UpdateConfig) (string, error) {
 	return "No match found and no variable added", nil
 }
 
+// updatedVar returns the variable v with the value and settings given in cfg. The settings not given in cfg are
+// kept, and a sensitive variable can't be made not sensitive.
+func updatedVar(v Var, cfg UpdateConfig) Var {
+	tfVar := Var{
+		Key:         v.Key,
+		Value:       cfg.NewValue,
+		Description: v.Description,
+		Category:    v.Category,
+		Hcl:         v.Hcl,
+		Sensitive:   v.Sensitive || cfg.SensitiveVariable,
+	}
+	if cfg.Description != "" {
+		tfVar.Description = cfg.Description
+	}
+	if cfg.Hcl != nil {
+		tfVar.Hcl = *cfg.Hcl
+	}
+	return tfVar
+}
+
 type OAuthTokens struct {
 	Data []struct {
 		ID         string `json:"id"`
@@ -927,42 +1129,50 @@ type OAuthTokens struct {
 	} `json:"data"`
 }
 
-func getVCSToken(vcsUsername, orgName string) (string, error) {
-	url := fmt.Sprintf(baseURL+"/organizations/%s/oauth-tokens", orgName)
-	resp, err := callAPI(http.MethodGet, url, "", nil)
-	if err != nil {
-		return "", err
-	}
-	defer resp.Body.Close()
-
-	var oauthTokens OAuthTokens
-	if err := json.NewDecoder(resp.Body).Decode(&oauthTokens); err != nil {
-		return "", err
-	}
+func (c *Client) getVCSToken(ctx context.Context, vcsUsername, orgName string) (string, error) {
+	u := c.NewTfcUrl(fmt.Sprintf("/organizations/%s/oauth-tokens", orgName))
 
 	vcsTokenID := ""
-	for _, nextToken := range oauthTokens.Data {
-		if nextToken.Attributes.ServiceProviderUser == vcsUsername {
-			vcsTokenID = nextToken.ID
-			break
+	err := c.forEachPage(ctx, u, func(page []byte) error {
+		var oauthTokens OAuthTokens
+		if err := json.Unmarshal(page, &oauthTokens); err != nil {
+			return err
 		}
+		for _, nextToken := range oauthTokens.Data {
+			if vcsTokenID == "" && nextToken.Attributes.ServiceProviderUser == vcsUsername {
+				vcsTokenID = nextToken.ID
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
 	}
 
 	return vcsTokenID, nil
 }
 
-// UpdateWorkspace updates one attribute of one or more Terraform Cloud workspaces.
-func UpdateWorkspace(params WorkspaceUpdateParams) error {
-	if err := validateUpdateWorkspaceParams(params); err != nil {
+// UpdateWorkspace updates one attribute of one or more Terraform Cloud workspaces, several at a time. If it stops
+// before all workspaces have been updated, the error is an *IncompleteError.
+func (c *Client) UpdateWorkspace(params WorkspaceUpdateParams) error {
+	return c.UpdateWorkspaceContext(context.Background(), params)
+}
+
+// UpdateWorkspaceContext is like UpdateWorkspace but uses ctx for its API calls
+func (c *Client) UpdateWorkspaceContext(ctx context.Context, params WorkspaceUpdateParams) error {
+	if err := c.validateUpdateWorkspaceParams(params); err != nil {
 		return err
 	}
 
-	foundWs := FindWorkspaces(params.Organization, params.WorkspaceFilter)
+	foundWs, err := c.FindWorkspacesContext(ctx, params.Organization, params.WorkspaceFilter)
+	if err != nil {
+		return err
+	}
 	if len(foundWs) == 0 {
 		return fmt.Errorf("no workspaces found matching the filter '%s'\n", params.WorkspaceFilter)
 	}
 
-	if config.readOnly {
+	if c.readOnly {
 		w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', 0)
 		_, _ = fmt.Fprintln(w, "organization:\t", params.Organization)
 		_, _ = fmt.Fprintln(w, "workspace filter:\t", params.WorkspaceFilter)
@@ -986,31 +1196,170 @@ func UpdateWorkspace(params WorkspaceUpdateParams) error {
 	}
 	postData := jsonObj.String()
 
-	if config.debug {
+	if c.debug {
 		fmt.Printf("request body:\n    %s\n", postData)
 	}
-	if config.readOnly {
+	if c.readOnly {
 		return nil
 	}
-	for id, name := range foundWs {
-		url := fmt.Sprintf(baseURL+"/workspaces/%s", id)
-		resp, err := callAPI(http.MethodPatch, url, postData, nil)
+	ids, names := sortedWorkspaceMap(foundWs)
+	idByName := make(map[string]string, len(ids))
+	for i, id := range ids {
+		idByName[names[i]] = id
+	}
+	err = c.ForEachWorkspace(ctx, names, os.Stdout, func(ctx context.Context, name string, w io.Writer) error {
+		u := c.NewTfcUrl("/workspaces/" + idByName[name])
+		resp, err := c.callAPI(ctx, http.MethodPatch, u.String(), postData, nil)
 		if err != nil {
 			return err
 		}
 
 		bodyBytes, _ := io.ReadAll(resp.Body)
-		defer resp.Body.Close()
+		_ = resp.Body.Close()
 
-		fmt.Printf("set '%s' to '%s' on workspace %s\n", params.Attribute, params.Value, name)
-		if config.debug {
-			fmt.Printf("response:\n    %s\n", bodyBytes)
+		fmt.Fprintf(w, "set '%s' to '%s' on workspace %s\n", params.Attribute, params.Value, name)
+		if c.debug {
+			fmt.Fprintf(w, "response:\n    %s\n", bodyBytes)
 		}
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 	fmt.Printf("Updated %d workspace(s)\n", len(foundWs))
 	return nil
 }
 
+// UpdateWorkspaceAttributes sets the given attributes of one workspace. Nested attributes, like "vcs-repo", are given
+// as a map.
+func (c *Client) UpdateWorkspaceAttributes(workspaceID string, attributes map[string]any) error {
+	return c.UpdateWorkspaceAttributesContext(context.Background(), workspaceID, attributes)
+}
+
+// UpdateWorkspaceAttributesContext is like UpdateWorkspaceAttributes but uses ctx for its API calls
+func (c *Client) UpdateWorkspaceAttributesContext(ctx context.Context, workspaceID string, attributes map[string]any) error {
+	jsonObj := gabs.Wrap(map[string]any{
+		"data": map[string]any{
+			"type":       "workspaces",
+			"attributes": attributes,
+		},
+	})
+	postData := jsonObj.String()
+
+	if c.debug {
+		fmt.Printf("request body:\n    %s\n", postData)
+	}
+	if c.readOnly {
+		return nil
+	}
+
+	u := c.NewTfcUrl("/workspaces/" + workspaceID)
+	resp, err := c.callAPI(ctx, http.MethodPatch, u.String(), postData, nil)
+	if err != nil {
+		return fmt.Errorf("failed to update workspace %s: %w", workspaceID, err)
+	}
+	return resp.Body.Close()
+}
+
+// DeleteWorkspace deletes a workspace, with its variables, state, team access and other configuration
+// https://developer.hashicorp.com/terraform/cloud-docs/api-docs/workspaces#force-delete-a-workspace
+func (c *Client) DeleteWorkspace(workspaceID string) error {
+	return c.DeleteWorkspaceContext(context.Background(), workspaceID)
+}
+
+// DeleteWorkspaceContext is like DeleteWorkspace but uses ctx for its API calls
+func (c *Client) DeleteWorkspaceContext(ctx context.Context, workspaceID string) error {
+	if c.readOnly {
+		return nil
+	}
+
+	u := c.NewTfcUrl("/workspaces/" + workspaceID)
+	resp, err := c.callAPI(ctx, http.MethodDelete, u.String(), "", nil)
+	if err != nil {
+		return fmt.Errorf("failed to delete workspace %s: %w", workspaceID, err)
+	}
+	return resp.Body.Close()
+}
+
+// AddWorkspaceTags adds tags to a workspace. Tags that don't exist in the organization are created.
+// https://developer.hashicorp.com/terraform/cloud-docs/api-docs/workspaces#add-tags-to-a-workspace
+func (c *Client) AddWorkspaceTags(workspaceID string, tags []string) error {
+	return c.AddWorkspaceTagsContext(context.Background(), workspaceID, tags)
+}
+
+// AddWorkspaceTagsContext is like AddWorkspaceTags but uses ctx for its API calls
+func (c *Client) AddWorkspaceTagsContext(ctx context.Context, workspaceID string, tags []string) error {
+	data := make([]map[string]any, len(tags))
+	for i, tag := range tags {
+		data[i] = map[string]any{
+			"type":       "tags",
+			"attributes": map[string]any{"name": tag},
+		}
+	}
+	postData := gabs.Wrap(map[string]any{"data": data}).String()
+
+	if c.debug {
+		fmt.Printf("request body:\n    %s\n", postData)
+	}
+	if c.readOnly {
+		return nil
+	}
+
+	u := c.NewTfcUrl("/workspaces/" + workspaceID + "/relationships/tags")
+	resp, err := c.callAPI(ctx, http.MethodPost, u.String(), postData, nil)
+	if err != nil {
+		return fmt.Errorf("failed to add tags to workspace %s: %w", workspaceID, err)
+	}
+	return resp.Body.Close()
+}
+
+// SetWorkspaceProject moves a workspace to a project in the same organization
+func (c *Client) SetWorkspaceProject(workspaceID, projectID string) error {
+	return c.SetWorkspaceProjectContext(context.Background(), workspaceID, projectID)
+}
+
+// SetWorkspaceProjectContext is like SetWorkspaceProject but uses ctx for its API calls
+func (c *Client) SetWorkspaceProjectContext(ctx context.Context, workspaceID, projectID string) error {
+	jsonObj := gabs.Wrap(map[string]any{
+		"data": map[string]any{
+			"type": "workspaces",
+		},
+	})
+	_, _ = jsonObj.SetP(projectID, "data.relationships.project.data.id")
+	_, _ = jsonObj.SetP("projects", "data.relationships.project.data.type")
+	postData := jsonObj.String()
+
+	if c.debug {
+		fmt.Printf("request body:\n    %s\n", postData)
+	}
+	if c.readOnly {
+		return nil
+	}
+
+	u := c.NewTfcUrl("/workspaces/" + workspaceID)
+	resp, err := c.callAPI(ctx, http.MethodPatch, u.String(), postData, nil)
+	if err != nil {
+		return fmt.Errorf("failed to set project of workspace %s: %w", workspaceID, err)
+	}
+	return resp.Body.Close()
+}
+
+// sortedWorkspaceMap splits a map of workspace IDs to names, as returned by FindWorkspaces, into lists of IDs and
+// names ordered by name
+func sortedWorkspaceMap(m map[string]string) ([]string, []string) {
+	ids := make([]string, 0, len(m))
+	for id := range m {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return m[ids[i]] < m[ids[j]] })
+
+	names := make([]string, len(ids))
+	for i, id := range ids {
+		names[i] = m[id]
+	}
+	return ids, names
+}
+
 func parseVal(value string) any {
 	if value == "null" {
 		return nil
@@ -1024,8 +1373,8 @@ func parseVal(value string) any {
 	return value
 }
 
-func validateUpdateWorkspaceParams(params WorkspaceUpdateParams) error {
-	if config.debug {
+func (c *Client) validateUpdateWorkspaceParams(params WorkspaceUpdateParams) error {
+	if c.debug {
 		fmt.Printf("params:\n    %#v\n", params)
 	}
 
@@ -1039,65 +1388,62 @@ func validateUpdateWorkspaceParams(params WorkspaceUpdateParams) error {
 // FindWorkspaces uses the `search[name]` parameter to retrieve a list of workspaces in Terraform Cloud that
 // match the workspaceFilter by the workspace name. The list is returned as a map with the ID in the key
 // and the name in the value.
-func FindWorkspaces(organization, workspaceFilter string) map[string]string {
-	u := NewTfcUrl(fmt.Sprintf("/organizations/%s/workspaces", organization))
-	u.SetParam(paramPageSize, strconv.Itoa(pageSize))
+func (c *Client) FindWorkspaces(organization, workspaceFilter string) (map[string]string, error) {
+	return c.FindWorkspacesContext(context.Background(), organization, workspaceFilter)
+}
+
+// FindWorkspacesContext is like FindWorkspaces but uses ctx for its API calls
+func (c *Client) FindWorkspacesContext(ctx context.Context, organization, workspaceFilter string) (map[string]string, error) {
+	u := c.NewTfcUrl(fmt.Sprintf("/organizations/%s/workspaces", organization))
 	u.SetParam(paramSearchName, workspaceFilter)
 
-	var attributeData [][]string
-	for page := 1; ; page++ {
-		u.SetParam(paramPageNumber, strconv.Itoa(page))
-		resp, err := callAPI(http.MethodGet, u.String(), "", nil)
-		if err != nil {
-			log.Fatalln(err)
-		}
-		ws := parseWorkspacePage(resp, []string{"id", "name"})
-		attributeData = append(attributeData, ws...)
-		if len(ws) < pageSize {
-			break
-		}
+	attributeData, err := c.getWorkspacePages(ctx, u, []string{"id", "name"})
+	if err != nil {
+		return nil, fmt.Errorf("error finding workspaces in %s: %w", organization, err)
 	}
 
 	foundWs := map[string]string{}
 	for _, ws := range attributeData {
 		foundWs[ws[0]] = ws[1]
 	}
-	return foundWs
+	return foundWs, nil
 }
 
 // GetWorkspaceAttributes returns a list of all workspaces in `organization` and the values of the attributes requested
 // in the `attributes` list. The value of unrecognized attribute names will be returned as `null`.
-func GetWorkspaceAttributes(organization string, attributes []string) ([][]string, error) {
-	u := NewTfcUrl(fmt.Sprintf("/organizations/%s/workspaces", organization))
-	u.SetParam(paramPageSize, strconv.Itoa(pageSize))
+func (c *Client) GetWorkspaceAttributes(organization string, attributes []string) ([][]string, error) {
+	return c.GetWorkspaceAttributesContext(context.Background(), organization, attributes)
+}
 
-	var attributeData [][]string
-	for page := 1; ; page++ {
-		u.SetParam(paramPageNumber, strconv.Itoa(page))
-		resp, err := callAPI(http.MethodGet, u.String(), "", nil)
-		if err != nil {
-			log.Fatalln(err)
-		}
+// GetWorkspaceAttributesContext is like GetWorkspaceAttributes but uses ctx for its API calls
+func (c *Client) GetWorkspaceAttributesContext(ctx context.Context, organization string, attributes []string) ([][]string, error) {
+	u := c.NewTfcUrl(fmt.Sprintf("/organizations/%s/workspaces", organization))
 
-		ws := parseWorkspacePage(resp, attributes)
-		attributeData = append(attributeData, ws...)
-		if len(ws) < pageSize {
-			break
-		}
+	attributeData, err := c.getWorkspacePages(ctx, u, attributes)
+	if err != nil {
+		return nil, fmt.Errorf("error getting workspace attributes for %s: %w", organization, err)
 	}
 	return attributeData, nil
 }
 
-func parseWorkspacePage(resp *http.Response, attributes []string) [][]string {
-	defer func() {
-		if err := resp.Body.Close(); err != nil {
-			panic("failed to close response body: " + err.Error())
+// getWorkspacePages returns the values of the requested attributes of each workspace in every page of the list at u
+func (c *Client) getWorkspacePages(ctx context.Context, u TfcUrl, attributes []string) ([][]string, error) {
+	var attributeData [][]string
+	err := c.forEachPage(ctx, u, func(page []byte) error {
+		ws, err := parseWorkspacePage(page, attributes)
+		if err != nil {
+			return err
 		}
-	}()
+		attributeData = append(attributeData, ws...)
+		return nil
+	})
+	return attributeData, err
+}
 
-	parsed, err := gabs.ParseJSONBuffer(resp.Body)
+func parseWorkspacePage(page []byte, attributes []string) ([][]string, error) {
+	parsed, err := gabs.ParseJSON(page)
 	if err != nil {
-		panic(err)
+		return nil, fmt.Errorf("failed to parse workspace list: %w", err)
 	}
 
 	wsAttributes := parsed.Search("data", "*", "attributes").Children()
@@ -1114,13 +1460,18 @@ func parseWorkspacePage(resp *http.Response, attributes []string) [][]string {
 			attributeData[i][j] = fmt.Sprintf("%v", v)
 		}
 	}
-	return attributeData
+	return attributeData, nil
 }
 
-func GetWorkspaceByName(organizationName, workspaceName string) (Workspace, error) {
-	u := NewTfcUrl(fmt.Sprintf("/organizations/%s/workspaces/%s", organizationName, workspaceName))
+func (c *Client) GetWorkspaceByName(organizationName, workspaceName string) (Workspace, error) {
+	return c.GetWorkspaceByNameContext(context.Background(), organizationName, workspaceName)
+}
+
+// GetWorkspaceByNameContext is like GetWorkspaceByName but uses ctx for its API calls
+func (c *Client) GetWorkspaceByNameContext(ctx context.Context, organizationName, workspaceName string) (Workspace, error) {
+	u := c.NewTfcUrl(fmt.Sprintf("/organizations/%s/workspaces/%s", organizationName, workspaceName))
 
-	resp, err := callAPI(http.MethodGet, u.String(), "", nil)
+	resp, err := c.callAPI(ctx, http.MethodGet, u.String(), "", nil)
 	if err != nil {
 		return Workspace{}, err
 	}
@@ -1133,6 +1484,24 @@ func GetWorkspaceByName(organizationName, workspaceName string) (Workspace, erro
 	return ws.Data, nil
 }
 
+// getWorkspaceJSON returns the unparsed data of a workspace, for access to attributes that are not in the Workspace
+// struct
+func (c *Client) getWorkspaceJSON(ctx context.Context, organizationName, workspaceName string) (*gabs.Container, error) {
+	u := c.NewTfcUrl(fmt.Sprintf("/organizations/%s/workspaces/%s", organizationName, workspaceName))
+
+	resp, err := c.callAPI(ctx, http.MethodGet, u.String(), "", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	parsed, err := gabs.ParseJSONBuffer(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse workspace %s: %w", workspaceName, err)
+	}
+	return parsed.S("data"), nil
+}
+
 type VariableSet struct {
 	ID         string `json:"id"`
 	Type       string `json:"type"`
@@ -1140,6 +1509,7 @@ type VariableSet struct {
 		Name           string    `json:"name"`
 		Description    string    `json:"description"`
 		Global         bool      `json:"global"`
+		Priority       bool      `json:"priority"`
 		UpdatedAt      time.Time `json:"updated-at"`
 		VarCount       int       `json:"var-count"`
 		WorkspaceCount int       `json:"workspace-count"`
@@ -1163,11 +1533,22 @@ type VariableSet struct {
 				Type string `json:"type"`
 			} `json:"data"`
 		} `json:"workspaces"`
+		Projects struct {
+			Data []struct {
+				ID   string `json:"id"`
+				Type string `json:"type"`
+			} `json:"data"`
+		} `json:"projects"`
 	} `json:"relationships"`
 }
 
-func GetVariableSet(org, vsName string) (*VariableSet, error) {
-	list, err := GetAllVariableSets(org)
+func (c *Client) GetVariableSet(org, vsName string) (*VariableSet, error) {
+	return c.GetVariableSetContext(context.Background(), org, vsName)
+}
+
+// GetVariableSetContext is like GetVariableSet but uses ctx for its API calls
+func (c *Client) GetVariableSetContext(ctx context.Context, org, vsName string) (*VariableSet, error) {
+	list, err := c.GetAllVariableSetsContext(ctx, org)
 	if err != nil {
 		return nil, fmt.Errorf("error getting list of variable sets in org: %w", err)
 	}
@@ -1191,25 +1572,43 @@ type VariableSetList struct {
 	} `json:"links"`
 }
 
-func GetAllVariableSets(organizationName string) (VariableSetList, error) {
-	u := NewTfcUrl(fmt.Sprintf("/organizations/%s/varsets", organizationName))
+func (c *Client) GetAllVariableSets(organizationName string) (VariableSetList, error) {
+	return c.GetAllVariableSetsContext(context.Background(), organizationName)
+}
 
-	resp, err := callAPI(http.MethodGet, u.String(), "", nil)
-	if err != nil {
-		return VariableSetList{}, err
-	}
+// GetAllVariableSetsContext is like GetAllVariableSets but uses ctx for its API calls
+func (c *Client) GetAllVariableSetsContext(ctx context.Context, organizationName string) (VariableSetList, error) {
+	u := c.NewTfcUrl(fmt.Sprintf("/organizations/%s/varsets", organizationName))
+	return c.getVariableSetList(ctx, u)
+}
 
+// getVariableSetList returns the variable sets in every page of the list at u. The links are those of the last page.
+func (c *Client) getVariableSetList(ctx context.Context, u TfcUrl) (VariableSetList, error) {
 	var variableSetList VariableSetList
-	if err := json.NewDecoder(resp.Body).Decode(&variableSetList); err != nil {
-		return VariableSetList{}, fmt.Errorf("unexpected content retrieving variable set list: %w", err)
+	err := c.forEachPage(ctx, u, func(page []byte) error {
+		var nextPage VariableSetList
+		if err := json.Unmarshal(page, &nextPage); err != nil {
+			return fmt.Errorf("unexpected content retrieving variable set list: %w", err)
+		}
+		nextPage.Data = append(variableSetList.Data, nextPage.Data...)
+		variableSetList = nextPage
+		return nil
+	})
+	if err != nil {
+		return VariableSetList{}, err
 	}
 
 	return variableSetList, nil
 }
 
 // TODO: make a config struct for this call?
-func ApplyVariableSet(varsetID string, workspaceIDs []string) error {
-	u := NewTfcUrl(fmt.Sprintf("/varsets/%s/relationships/workspaces", varsetID))
+func (c *Client) ApplyVariableSet(varsetID string, workspaceIDs []string) error {
+	return c.ApplyVariableSetContext(context.Background(), varsetID, workspaceIDs)
+}
+
+// ApplyVariableSetContext is like ApplyVariableSet but uses ctx for its API calls
+func (c *Client) ApplyVariableSetContext(ctx context.Context, varsetID string, workspaceIDs []string) error {
+	u := c.NewTfcUrl(fmt.Sprintf("/varsets/%s/relationships/workspaces", varsetID))
 	data := gabs.New()
 	_, err := data.ArrayOfSize(len(workspaceIDs), "data")
 	if err != nil {
@@ -1225,33 +1624,27 @@ func ApplyVariableSet(varsetID string, workspaceIDs []string) error {
 	}
 	postData := data.String()
 
-	if config.debug {
+	if c.debug {
 		fmt.Printf("request body:\n    %s\n", postData)
 	}
-	if config.readOnly {
+	if c.readOnly {
 		return nil
 	}
-	_, err = callAPI(http.MethodPost, u.String(), postData, nil)
+	_, err = c.callAPI(ctx, http.MethodPost, u.String(), postData, nil)
 	// TODO: need to look at response?
 	return err
 }
 
-func copyVariableSetList(sourceWorkspaceID, destinationWorkspaceID string) error {
-	sets, err := ListWorkspaceVariableSets(sourceWorkspaceID)
-	if err != nil {
-		return fmt.Errorf("copy variable sets: %w", err)
-	}
-	if err := ApplyVariableSetsToWorkspace(sets, destinationWorkspaceID); err != nil {
-		return fmt.Errorf("copy variable sets: %w", err)
-	}
-	return nil
+func (c *Client) ApplyVariableSetsToWorkspace(sets VariableSetList, workspaceID string) error {
+	return c.ApplyVariableSetsToWorkspaceContext(context.Background(), sets, workspaceID)
 }
 
-func ApplyVariableSetsToWorkspace(sets VariableSetList, workspaceID string) error {
+// ApplyVariableSetsToWorkspaceContext is like ApplyVariableSetsToWorkspace but uses ctx for its API calls
+func (c *Client) ApplyVariableSetsToWorkspaceContext(ctx context.Context, sets VariableSetList, workspaceID string) error {
 	var failed []string
 	var err error
 	for _, set := range sets.Data {
-		err = ApplyVariableSet(set.ID, []string{workspaceID})
+		err = c.ApplyVariableSetContext(ctx, set.ID, []string{workspaceID})
 		if err != nil {
 			failed = append(failed, set.Attributes.Name)
 		}
@@ -1265,24 +1658,50 @@ func ApplyVariableSetsToWorkspace(sets VariableSetList, workspaceID string) erro
 	return nil
 }
 
-func ListWorkspaceVariableSets(workspaceID string) (VariableSetList, error) {
-	u := NewTfcUrl(fmt.Sprintf("/workspaces/%s/varsets", workspaceID))
+// ListVariableSetVariables returns the variables in a variable set
+func (c *Client) ListVariableSetVariables(varsetID string) ([]Var, error) {
+	return c.ListVariableSetVariablesContext(context.Background(), varsetID)
+}
+
+// ListVariableSetVariablesContext is like ListVariableSetVariables but uses ctx for its API calls
+func (c *Client) ListVariableSetVariablesContext(ctx context.Context, varsetID string) ([]Var, error) {
+	u := c.NewTfcUrl(fmt.Sprintf("/varsets/%s/relationships/vars", varsetID))
 
-	resp, err := callAPI(http.MethodGet, u.String(), "", nil)
+	var variables []Var
+	err := c.forEachPage(ctx, u, func(page []byte) error {
+		var varsResp VarsResponse
+		if err := json.Unmarshal(page, &varsResp); err != nil {
+			return fmt.Errorf("unexpected content retrieving variables of variable set %s: %w", varsetID, err)
+		}
+		for _, data := range varsResp.Data {
+			data.Variable.ID = data.ID
+			variables = append(variables, data.Variable)
+		}
+		return nil
+	})
 	if err != nil {
-		return VariableSetList{}, err
+		return nil, err
 	}
+	return variables, nil
+}
 
-	var variableSetList VariableSetList
-	if err := json.NewDecoder(resp.Body).Decode(&variableSetList); err != nil {
-		return variableSetList, fmt.Errorf("unexpected content retrieving variable set list: %w", err)
-	}
+func (c *Client) ListWorkspaceVariableSets(workspaceID string) (VariableSetList, error) {
+	return c.ListWorkspaceVariableSetsContext(context.Background(), workspaceID)
+}
 
-	return variableSetList, nil
+// ListWorkspaceVariableSetsContext is like ListWorkspaceVariableSets but uses ctx for its API calls
+func (c *Client) ListWorkspaceVariableSetsContext(ctx context.Context, workspaceID string) (VariableSetList, error) {
+	u := c.NewTfcUrl(fmt.Sprintf("/workspaces/%s/varsets", workspaceID))
+	return c.getVariableSetList(ctx, u)
 }
 
-func AddRemoteStateConsumers(workspaceID string, consumerIDs []string) error {
-	u := NewTfcUrl(fmt.Sprintf("/workspaces/%s/relationships/remote-state-consumers", workspaceID))
+func (c *Client) AddRemoteStateConsumers(workspaceID string, consumerIDs []string) error {
+	return c.AddRemoteStateConsumersContext(context.Background(), workspaceID, consumerIDs)
+}
+
+// AddRemoteStateConsumersContext is like AddRemoteStateConsumers but uses ctx for its API calls
+func (c *Client) AddRemoteStateConsumersContext(ctx context.Context, workspaceID string, consumerIDs []string) error {
+	u := c.NewTfcUrl(fmt.Sprintf("/workspaces/%s/relationships/remote-state-consumers", workspaceID))
 
 	data := gabs.New()
 	_, err := data.ArrayOfSize(len(consumerIDs), "data")
@@ -1299,6 +1718,28 @@ func AddRemoteStateConsumers(workspaceID string, consumerIDs []string) error {
 	}
 	postData := data.String()
 
-	_, err = callAPI(http.MethodPost, u.String(), postData, nil)
+	_, err = c.callAPI(ctx, http.MethodPost, u.String(), postData, nil)
 	return err
 }
+
+// ListRemoteStateConsumers returns the names of the workspaces that are allowed to read the state of a workspace,
+// in a map keyed by workspace ID
+func (c *Client) ListRemoteStateConsumers(workspaceID string) (map[string]string, error) {
+	return c.ListRemoteStateConsumersContext(context.Background(), workspaceID)
+}
+
+// ListRemoteStateConsumersContext is like ListRemoteStateConsumers but uses ctx for its API calls
+func (c *Client) ListRemoteStateConsumersContext(ctx context.Context, workspaceID string) (map[string]string, error) {
+	u := c.NewTfcUrl(fmt.Sprintf("/workspaces/%s/relationships/remote-state-consumers", workspaceID))
+
+	ws, err := c.getWorkspacePages(ctx, u, []string{"id", "name"})
+	if err != nil {
+		return nil, fmt.Errorf("error listing remote state consumers of %s: %w", workspaceID, err)
+	}
+
+	consumers := map[string]string{}
+	for _, w := range ws {
+		consumers[w[0]] = w[1]
+	}
+	return consumers, nil
+}
@@ -1,6 +1,9 @@
 package lib
 
 import (
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 
@@ -76,3 +79,210 @@ func removeWhitespace(s string) string {
 	s1 := strings.ReplaceAll(s, " ", "")
 	return strings.ReplaceAll(s1, "\n", "")
 }
+
+func TestGetCreateVariablePayload(t *testing.T) {
+	got := GetCreateVariablePayload("org", "ws", Var{
+		Key:         "TOKEN",
+		Value:       `a "quoted" value`,
+		Description: "line one\nline two",
+		Category:    "env",
+		Sensitive:   true,
+	})
+	require.JSONEq(t, `{
+  "data": {
+    "type": "vars",
+    "attributes": {
+      "key": "TOKEN",
+      "value": "a \"quoted\" value",
+      "description": "line one\nline two",
+      "category": "env",
+      "hcl": false,
+      "sensitive": true
+    }
+  },
+  "filter": {"organization": {"name": "org"}, "workspace": {"name": "ws"}}
+}`, got)
+
+	got = GetCreateVariablePayload("org", "ws", Var{Key: "name", Value: "value"})
+	require.Contains(t, got, `"category":"terraform"`)
+}
+
+func TestGetUpdateVariablePayload(t *testing.T) {
+	got := GetUpdateVariablePayload("org", "ws", "var-1", Var{
+		Key:         "tags",
+		Value:       "{\n  app = \"web\"\n}",
+		Description: "common tags",
+		Hcl:         true,
+	})
+	require.JSONEq(t, `{
+  "data": {
+    "id": "var-1",
+    "type": "vars",
+    "attributes": {
+      "key": "tags",
+      "value": "{\n  app = \"web\"\n}",
+      "description": "common tags",
+      "category": "terraform",
+      "hcl": true,
+      "sensitive": false
+    }
+  },
+  "filter": {"organization": {"name": "org"}, "workspace": {"name": "ws"}}
+}`, got)
+}
+
+func TestClient_AddOrUpdateVariableKeepsSettings(t *testing.T) {
+	var calls []string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		call := r.Method + " " + strings.TrimPrefix(r.URL.Path, apiPath)
+		switch call {
+		case "GET /organizations/org":
+			_, _ = w.Write([]byte(`{"data":{"id":"org"}}`))
+		case "GET /vars":
+			_, _ = w.Write([]byte(`{"data":[
+				{"id":"var-1","attributes":{"key":"tags","value":"{ a = 1 }","category":"terraform","hcl":true}},
+				{"id":"var-2","attributes":{"key":"token","category":"terraform","sensitive":true}}]}`))
+		default:
+			body, _ := io.ReadAll(r.Body)
+			calls = append(calls, call+" "+string(body))
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	defer server.Close()
+	c := newTestClient(server, "token")
+
+	_, err := c.AddOrUpdateVariable(UpdateConfig{Organization: "org", Workspace: "ws", SearchString: "tags",
+		NewValue: "{ b = 2 }"})
+	require.NoError(t, err)
+	require.Len(t, calls, 1)
+	require.Contains(t, calls[0], `"hcl":true`)
+	require.Contains(t, calls[0], `"sensitive":false`)
+
+	calls = nil
+	_, err = c.AddOrUpdateVariable(UpdateConfig{Organization: "org", Workspace: "ws", SearchString: "token",
+		NewValue: "secret"})
+	require.NoError(t, err)
+	require.Len(t, calls, 1)
+	require.Contains(t, calls[0], `"hcl":false`)
+	require.Contains(t, calls[0], `"sensitive":true`)
+
+	notHcl := false
+	calls = nil
+	_, err = c.AddOrUpdateVariable(UpdateConfig{Organization: "org", Workspace: "ws", SearchString: "tags",
+		NewValue: "b", Hcl: &notHcl})
+	require.NoError(t, err)
+	require.Len(t, calls, 1)
+	require.Contains(t, calls[0], `"hcl":false`)
+
+	// a bulk update of the variables matching a setting keeps the settings of each one
+	isSensitive := true
+	calls = nil
+	_, err = c.AddOrUpdateVariable(UpdateConfig{Organization: "org", Workspace: "ws", NewValue: "x",
+		Match: &VariableMatch{Sensitive: &isSensitive}})
+	require.NoError(t, err)
+	require.Len(t, calls, 1)
+	require.True(t, strings.HasPrefix(calls[0], "PATCH /vars/var-2 "))
+	require.Contains(t, calls[0], `"sensitive":true`)
+
+	isHcl := true
+	calls = nil
+	_, err = c.AddOrUpdateVariable(UpdateConfig{Organization: "org", Workspace: "ws", NewValue: "{ c = 3 }",
+		Match: &VariableMatch{Hcl: &isHcl}})
+	require.NoError(t, err)
+	require.Len(t, calls, 1)
+	require.True(t, strings.HasPrefix(calls[0], "PATCH /vars/var-1 "))
+	require.Contains(t, calls[0], `"hcl":true`)
+	require.Contains(t, calls[0], `"sensitive":false`)
+}
+
+func TestClient_AddOrUpdateVariable(t *testing.T) {
+	isHcl := true
+	var calls []string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		call := r.Method + " " + strings.TrimPrefix(r.URL.Path, apiPath)
+		switch call {
+		case "GET /organizations/org":
+			_, _ = w.Write([]byte(`{"data":{"id":"org"}}`))
+		case "GET /vars":
+			_, _ = w.Write([]byte(`{"data":[
+				{"id":"var-1","attributes":{"key":"token","value":"a","category":"terraform","description":"tf"}},
+				{"id":"var-2","attributes":{"key":"TOKEN","value":"b","category":"env","description":"env"}}]}`))
+		default:
+			body, _ := io.ReadAll(r.Body)
+			calls = append(calls, call+" "+string(body))
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	defer server.Close()
+	c := newTestClient(server, "token")
+
+	_, err := c.AddOrUpdateVariable(UpdateConfig{Organization: "org", Workspace: "ws", SearchString: "TOKEN",
+		NewValue: "c", Category: "env"})
+	require.NoError(t, err)
+	require.Len(t, calls, 1)
+	require.True(t, strings.HasPrefix(calls[0], "PATCH /vars/var-2 "))
+	require.Contains(t, calls[0], `"category":"env"`)
+	require.Contains(t, calls[0], `"description":"env"`)
+
+	calls = nil
+	_, err = c.AddOrUpdateVariable(UpdateConfig{Organization: "org", Workspace: "ws", SearchString: "token",
+		NewValue: "{ a = 1 }", Hcl: &isHcl, Description: "new"})
+	require.NoError(t, err)
+	require.Len(t, calls, 1)
+	require.True(t, strings.HasPrefix(calls[0], "PATCH /vars/var-1 "))
+	require.Contains(t, calls[0], `"hcl":true`)
+	require.Contains(t, calls[0], `"description":"new"`)
+
+	_, err = c.AddOrUpdateVariable(UpdateConfig{Organization: "org", Workspace: "ws", SearchString: "a",
+		NewValue: "d", SearchOnVariableValue: true, Category: "env"})
+	require.NoError(t, err)
+	require.Len(t, calls, 1, "a terraform variable must not match an env search")
+
+	calls = nil
+	_, err = c.AddOrUpdateVariable(UpdateConfig{Organization: "org", Workspace: "ws", SearchString: "REGION",
+		NewValue: "us-east-1", AddKeyIfNotFound: true, Category: "env"})
+	require.NoError(t, err)
+	require.Len(t, calls, 1)
+	require.True(t, strings.HasPrefix(calls[0], "POST /vars "))
+	require.Contains(t, calls[0], `"category":"env"`)
+
+	_, err = c.AddOrUpdateVariable(UpdateConfig{Organization: "org", Workspace: "ws", SearchString: "TOKEN",
+		NewValue: "e", AddKeyIfNotFound: true, Category: "env"})
+	require.ErrorContains(t, err, "already exists")
+
+	calls = nil
+	_, err = c.AddOrUpdateVariable(UpdateConfig{Organization: "org", Workspace: "ws", NewValue: "f",
+		Match: &VariableMatch{KeyRegex: "(?i)^token$"}})
+	require.NoError(t, err)
+	require.Len(t, calls, 2, "every matching variable must be updated")
+
+	v, err := c.GetWorkspaceVarInCategory("org", "ws", "env", "TOKEN")
+	require.NoError(t, err)
+	require.Equal(t, "var-2", v.ID)
+	v, err = c.GetWorkspaceVarInCategory("org", "ws", "", "TOKEN")
+	require.NoError(t, err)
+	require.Nil(t, v)
+}
+
+func Test_cloneVariables(t *testing.T) {
+	variables := []Var{
+		{Key: "region", Value: "us-east-1", Category: "terraform", Description: "AWS region"},
+		{Key: "AWS_SECRET_ACCESS_KEY", Category: "env", Sensitive: true},
+		{Key: "db_password", Category: "terraform", Sensitive: true},
+		{Key: "legacy", Value: "TF_ENTERPRISE_SENSITIVE_VAR", Hcl: true},
+	}
+	secrets := map[string]string{"db_password": "secret"}
+
+	tfVars, needValues := cloneVariables(variables, true, secrets)
+	require.Equal(t, []Var{
+		{Key: "region", Value: "us-east-1", Category: "terraform", Description: "AWS region"},
+		{Key: "AWS_SECRET_ACCESS_KEY", Value: "REPLACE_THIS_VALUE", Category: "env", Sensitive: true},
+		{Key: "db_password", Value: "secret", Category: "terraform", Sensitive: true},
+		{Key: "legacy", Value: "REPLACE_THIS_VALUE", Hcl: true, Sensitive: true},
+	}, tfVars)
+	require.Equal(t, []string{"AWS_SECRET_ACCESS_KEY", "legacy"}, needValues)
+
+	tfVars, needValues = cloneVariables(variables, false, nil)
+	require.Equal(t, "REPLACE_THIS_VALUE", tfVars[0].Value)
+	require.Equal(t, []string{"region", "AWS_SECRET_ACCESS_KEY", "db_password", "legacy"}, needValues)
+}
@@ -0,0 +1,162 @@
+package lib
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Jeffail/gabs/v2"
+)
+
+// Categories of workspace configuration that are copied by CloneWorkspace, and can be left out with
+// CloneConfig.Exclude
+const (
+	CloneSettings             = "settings"
+	CloneProject              = "project"
+	CloneTags                 = "tags"
+	CloneRemoteStateConsumers = "remote-state-consumers"
+	CloneRunTriggers          = "run-triggers"
+	CloneNotifications        = "notifications"
+)
+
+// CloneCategories lists the categories of workspace configuration that can be excluded from a clone
+var CloneCategories = []string{
+	CloneSettings,
+	CloneProject,
+	CloneTags,
+	CloneRemoteStateConsumers,
+	CloneRunTriggers,
+	CloneNotifications,
+}
+
+// cloneSettingAttributes are the workspace attributes copied in the "settings" category
+var cloneSettingAttributes = []string{
+	"allow-destroy-plan",
+	"assessments-enabled",
+	"auto-apply",
+	"description",
+	"execution-mode",
+	"file-triggers-enabled",
+	"global-remote-state",
+	"queue-all-runs",
+	"speculative-enabled",
+	"structured-run-output-enabled",
+	"trigger-patterns",
+	"trigger-prefixes",
+}
+
+// validateCloneExclude returns an error if exclude has a category that is not in CloneCategories
+func validateCloneExclude(exclude []string) error {
+	for _, e := range exclude {
+		if !isOneOf(e, CloneCategories) {
+			return fmt.Errorf("invalid category to exclude '%s', must be one of: %s", e, strings.Join(CloneCategories, ", "))
+		}
+	}
+	return nil
+}
+
+func isOneOf(s string, list []string) bool {
+	for _, l := range list {
+		if s == l {
+			return true
+		}
+	}
+	return false
+}
+
+// cloneWorkspaceCategory copies one category of configuration of the source workspace, from CloneCategories, to the
+// new workspace. The destination client makes the API calls for the new workspace. The project, agent pool, run
+// triggers and remote state consumers refer to other objects in the source organization, so they are only copied if
+// the new workspace is in the same organization.
+func (c *Client) cloneWorkspaceCategory(ctx context.Context, category string, source *gabs.Container, destination *Client, newID string, sameOrg bool) error {
+	sourceID, _ := source.Path("id").Data().(string)
+
+	switch category {
+	case CloneSettings:
+		return destination.UpdateWorkspaceAttributesContext(ctx, newID, cloneSettings(source, sameOrg))
+
+	case CloneProject:
+		projectID, _ := source.Path("relationships.project.data.id").Data().(string)
+		if !sameOrg || projectID == "" {
+			return nil
+		}
+		return destination.SetWorkspaceProjectContext(ctx, newID, projectID)
+
+	case CloneTags:
+		var tags []string
+		for _, tag := range source.Path("attributes.tag-names").Children() {
+			if name, ok := tag.Data().(string); ok {
+				tags = append(tags, name)
+			}
+		}
+		if len(tags) == 0 {
+			return nil
+		}
+		return destination.AddWorkspaceTagsContext(ctx, newID, tags)
+
+	case CloneRemoteStateConsumers:
+		if !sameOrg {
+			return nil
+		}
+		consumers, err := c.ListRemoteStateConsumersContext(ctx, sourceID)
+		if err != nil || len(consumers) == 0 {
+			return err
+		}
+		ids, _ := sortedWorkspaceMap(consumers)
+		if err := destination.AddRemoteStateConsumersContext(ctx, newID, ids); err != nil {
+			return fmt.Errorf("failed to add remote state consumers: %w", err)
+		}
+
+	case CloneRunTriggers:
+		if !sameOrg {
+			return nil
+		}
+		triggers, err := c.ListRunTriggersContext(ctx, ListRunTriggerConfig{WorkspaceID: sourceID, Type: "inbound"})
+		if err != nil {
+			return fmt.Errorf("failed to list run triggers: %w", err)
+		}
+		for _, t := range triggers {
+			err := destination.CreateRunTriggerContext(ctx, RunTriggerConfig{WorkspaceID: newID, SourceWorkspaceID: t.SourceID})
+			if err != nil {
+				return fmt.Errorf("failed to create run trigger from %s: %w", t.SourceName, err)
+			}
+		}
+
+	case CloneNotifications:
+		configs, err := c.ListNotificationConfigurationsContext(ctx, sourceID)
+		if err != nil {
+			return fmt.Errorf("failed to list notification configurations: %w", err)
+		}
+		for _, nc := range configs {
+			if !sameOrg {
+				nc.Relationships.Users.Data = nil
+			}
+			if err := destination.CreateNotificationConfigurationContext(ctx, newID, nc); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// cloneSettings returns the attributes in the "settings" category of a workspace. A workspace that runs on an agent
+// pool in another organization is given the default execution mode.
+func cloneSettings(source *gabs.Container, sameOrg bool) map[string]any {
+	attributes := map[string]any{}
+	for _, name := range cloneSettingAttributes {
+		if v := source.Search("attributes", name); v != nil && v.Data() != nil {
+			attributes[name] = v.Data()
+		}
+	}
+
+	if attributes["execution-mode"] == "agent" {
+		agentPoolID, _ := source.Path("relationships.agent-pool.data.id").Data().(string)
+		if sameOrg && agentPoolID != "" {
+			attributes["agent-pool-id"] = agentPoolID
+		} else {
+			delete(attributes, "execution-mode")
+		}
+	}
+	return attributes
+}
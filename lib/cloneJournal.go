@@ -0,0 +1,322 @@
+package lib
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Types of resources recorded in a CloneJournal
+const (
+	JournalVariable    = "variable"
+	JournalVariableSet = "variable-set"
+	JournalTeamAccess  = "team-access"
+)
+
+// Steps of a clone that are recorded in a CloneJournal when they are complete, other than creating resources
+const (
+	journalStepState = "state"
+)
+
+// CloneJournal records the progress of a clone: the new workspace and every resource created in it, and the steps
+// that are complete. If a clone fails, its journal is used to roll it back with RollbackClone or to finish it with
+// CloneWithJournal. A journal with a Path is saved to that file after every change.
+type CloneJournal struct {
+	Config      CloneConfig       `json:"config"`
+	WorkspaceID string            `json:"workspace-id,omitempty"` // ID of the new workspace, once it is created
+	Created     []JournalResource `json:"created"`
+	Completed   []string          `json:"completed"`
+
+	Path string `json:"-"`
+}
+
+// JournalResource is a resource created by a clone
+type JournalResource struct {
+	Type string `json:"type"`
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name"` // identifies the source of the resource, e.g. the category and key of a variable
+}
+
+// NewCloneJournal returns an empty journal for a clone. If path is not empty, the journal is saved to that file as
+// soon as the clone creates anything.
+func NewCloneJournal(cfg CloneConfig, path string) *CloneJournal {
+	return &CloneJournal{Config: cfg, Path: path}
+}
+
+// ReadCloneJournal reads a journal saved by an earlier clone. The AtlasTokenDestination and Secrets of its Config are
+// not saved, so they need to be set again before the clone is resumed.
+func ReadCloneJournal(path string) (*CloneJournal, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var j CloneJournal
+	if err := json.Unmarshal(data, &j); err != nil {
+		return nil, fmt.Errorf("invalid clone journal %s: %w", path, err)
+	}
+	j.Path = path
+	return &j, nil
+}
+
+// Remove deletes the journal's file, if it has one
+func (j *CloneJournal) Remove() error {
+	if j.Path == "" {
+		return nil
+	}
+	if err := os.Remove(j.Path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// save writes the journal to its file. The file is replaced in one step, so that it is never left half written.
+func (j *CloneJournal) save() error {
+	if j.Path == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(j, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(j.Path), filepath.Base(j.Path)+".*")
+	if err != nil {
+		return fmt.Errorf("error saving clone journal: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("error saving clone journal: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("error saving clone journal: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), j.Path); err != nil {
+		return fmt.Errorf("error saving clone journal: %w", err)
+	}
+	return nil
+}
+
+// has returns true if a resource of the given type and name was created
+func (j *CloneJournal) has(resourceType, name string) bool {
+	for _, r := range j.Created {
+		if r.Type == resourceType && r.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// add records a resource that was created
+func (j *CloneJournal) add(resourceType, id, name string) error {
+	j.Created = append(j.Created, JournalResource{Type: resourceType, ID: id, Name: name})
+	return j.save()
+}
+
+func (j *CloneJournal) isComplete(step string) bool {
+	return isOneOf(step, j.Completed)
+}
+
+// complete records a step that is complete
+func (j *CloneJournal) complete(step string) error {
+	j.Completed = append(j.Completed, step)
+	return j.save()
+}
+
+// CloneWithJournal is like CloneWorkspace, but records its progress in journal and does not roll back a failed clone.
+// If journal already has progress from an earlier attempt, the steps that are complete are skipped. The new workspace
+// can be deleted with RollbackClone.
+func (c *Client) CloneWithJournal(journal *CloneJournal) ([]string, error) {
+	return c.CloneWithJournalContext(context.Background(), journal)
+}
+
+// CloneWithJournalContext is like CloneWithJournal but uses ctx for its API calls
+func (c *Client) CloneWithJournalContext(ctx context.Context, journal *CloneJournal) ([]string, error) {
+	cfg := journal.Config
+	if err := validateCloneExclude(cfg.Exclude); err != nil {
+		return nil, err
+	}
+
+	sourceWsData, err := c.GetWorkspaceDataContext(ctx, cfg.Organization, cfg.SourceWorkspace)
+	if err != nil {
+		return nil, err
+	}
+	sourceID := sourceWsData.Data.ID
+
+	sourceJSON, err := c.getWorkspaceJSON(ctx, cfg.Organization, cfg.SourceWorkspace)
+	if err != nil {
+		return nil, err
+	}
+
+	variables, err := c.GetVarsFromWorkspaceContext(ctx, cfg.Organization, cfg.SourceWorkspace)
+	if err != nil {
+		return nil, err
+	}
+
+	if !cfg.DifferentDestinationAccount {
+		cfg.NewOrganization = cfg.Organization
+		cfg.NewVCSTokenID = sourceWsData.Data.Attributes.VCSRepo.Identifier
+	}
+
+	oc := OpsConfig{
+		SourceOrg:        cfg.Organization,
+		SourceName:       sourceWsData.Data.Attributes.Name,
+		NewOrg:           cfg.NewOrganization,
+		NewName:          cfg.NewWorkspace,
+		TerraformVersion: sourceWsData.Data.Attributes.TerraformVersion,
+		RepoID:           sourceWsData.Data.Attributes.VCSRepo.Identifier,
+		Branch:           sourceWsData.Data.Attributes.VCSRepo.Branch,
+		Directory:        sourceWsData.Data.Attributes.WorkingDirectory,
+	}
+	overrideOpsConfig(&oc, cfg)
+
+	tfVars, needValues := cloneVariables(variables, cfg.CopyVariables, cfg.Secrets)
+
+	if c.readOnly {
+		return needValues, nil
+	}
+
+	// use the destination token to create the workspace and its configuration in a different account
+	destination := c
+	if cfg.DifferentDestinationAccount {
+		destination = c.WithToken(cfg.AtlasTokenDestination)
+	}
+
+	if journal.WorkspaceID == "" {
+		var newID string
+		if cfg.DifferentDestinationAccount {
+			newID, err = destination.CreateWorkspaceContext(ctx, oc, cfg.NewVCSTokenID)
+		} else {
+			var ws Workspace
+			ws, err = c.CreateWorkspace2Context(ctx, oc, sourceWsData.Data.Attributes.VCSRepo.TokenID)
+			newID = ws.ID
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to create new workspace: %w", err)
+		}
+		journal.WorkspaceID = newID
+		if err := journal.save(); err != nil {
+			return needValues, err
+		}
+	}
+	newID := journal.WorkspaceID
+
+	if cfg.ApplyVariableSets && !cfg.DifferentDestinationAccount {
+		sets, err := c.ListWorkspaceVariableSetsContext(ctx, sourceID)
+		if err != nil {
+			return needValues, fmt.Errorf("failed to clone variable sets: %w", err)
+		}
+		for _, set := range sets.Data {
+			// global variable sets apply to every workspace already
+			if set.Attributes.Global || journal.has(JournalVariableSet, set.ID) {
+				continue
+			}
+			if err := c.ApplyVariableSetContext(ctx, set.ID, []string{newID}); err != nil {
+				return needValues, fmt.Errorf("failed to apply variable set %s: %w", set.Attributes.Name, err)
+			}
+			if err := journal.add(JournalVariableSet, set.ID, set.ID); err != nil {
+				return needValues, err
+			}
+		}
+	}
+
+	for _, v := range tfVars {
+		name := v.Category + ":" + v.Key
+		if journal.has(JournalVariable, name) {
+			continue
+		}
+		id, err := destination.createVariable(ctx, oc.NewOrg, oc.NewName, v)
+		if err != nil {
+			return needValues, err
+		}
+		if err := journal.add(JournalVariable, id, name); err != nil {
+			return needValues, err
+		}
+	}
+
+	if !cfg.DifferentDestinationAccount {
+		allTeamData, err := c.GetTeamAccessFromContext(ctx, sourceID)
+		if err != nil {
+			return needValues, err
+		}
+		for _, teamData := range allTeamData.Data {
+			teamID := teamData.Relationships.Team.Data.ID
+			if journal.has(JournalTeamAccess, teamID) {
+				continue
+			}
+			id, err := c.assignTeamAccess(ctx, newID, teamID, teamData.Attributes.Access)
+			if err != nil {
+				return needValues, err
+			}
+			if err := journal.add(JournalTeamAccess, id, teamID); err != nil {
+				return needValues, err
+			}
+		}
+	}
+
+	sameOrg := oc.NewOrg == oc.SourceOrg
+	for _, category := range CloneCategories {
+		if isOneOf(category, cfg.Exclude) || journal.isComplete(category) {
+			continue
+		}
+		if err := c.cloneWorkspaceCategory(ctx, category, sourceJSON, destination, newID, sameOrg); err != nil {
+			return needValues, err
+		}
+		if err := journal.complete(category); err != nil {
+			return needValues, err
+		}
+	}
+
+	if cfg.CopyState && !journal.isComplete(journalStepState) {
+		if err := c.CopyStateContext(ctx, sourceID, destination, newID); err != nil {
+			return needValues, fmt.Errorf("failed to copy state: %w", err)
+		}
+		if err := journal.complete(journalStepState); err != nil {
+			return needValues, err
+		}
+	}
+
+	return needValues, nil
+}
+
+// overrideOpsConfig replaces the settings in oc with those given in cfg
+func overrideOpsConfig(oc *OpsConfig, cfg CloneConfig) {
+	if cfg.TerraformVersion != "" {
+		oc.TerraformVersion = cfg.TerraformVersion
+	}
+	if cfg.RepoID != "" {
+		oc.RepoID = cfg.RepoID
+	}
+	if cfg.Branch != "" {
+		oc.Branch = cfg.Branch
+	}
+	if cfg.Directory != "" {
+		oc.Directory = cfg.Directory
+	}
+}
+
+// RollbackClone undoes a clone that failed, by deleting the new workspace recorded in journal. The variables, variable
+// set attachments, team access and other configuration created by the clone belong to the workspace, so they are
+// deleted with it. The journal is emptied, and its file removed.
+func (c *Client) RollbackClone(journal *CloneJournal) error {
+	return c.RollbackCloneContext(context.Background(), journal)
+}
+
+// RollbackCloneContext is like RollbackClone but uses ctx for its API calls
+func (c *Client) RollbackCloneContext(ctx context.Context, journal *CloneJournal) error {
+	if journal.WorkspaceID != "" {
+		destination := c
+		if journal.Config.DifferentDestinationAccount {
+			destination = c.WithToken(journal.Config.AtlasTokenDestination)
+		}
+		if err := destination.DeleteWorkspaceContext(ctx, journal.WorkspaceID); err != nil {
+			return err
+		}
+	}
+
+	journal.WorkspaceID = ""
+	journal.Created = nil
+	journal.Completed = nil
+	return journal.Remove()
+}
@@ -0,0 +1,134 @@
+package lib
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// newCloneServer returns a fake API for a same-account clone of "source" to "new". Assigning team access fails
+// while failTeamAccess is true. Every create and delete call is recorded in calls.
+func newCloneServer(failTeamAccess *bool, calls *[]string) *httptest.Server {
+	return httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		call := r.Method + " " + strings.TrimPrefix(r.URL.Path, apiPath)
+		if r.Method != http.MethodGet {
+			*calls = append(*calls, call)
+		}
+
+		switch call {
+		case "GET /organizations/org":
+			_, _ = w.Write([]byte(`{"data":{"id":"org"}}`))
+		case "GET /organizations/org/workspaces/source":
+			_, _ = w.Write([]byte(`{"data":{"id":"ws-source","attributes":{"name":"source"}}}`))
+		case "GET /organizations/org/workspaces/missing":
+			w.WriteHeader(http.StatusNotFound)
+		case "GET /vars":
+			_, _ = w.Write([]byte(`{"data":[
+				{"id":"var-1","attributes":{"key":"region","value":"us-east-1","category":"terraform"}},
+				{"id":"var-2","attributes":{"key":"TOKEN","sensitive":true,"category":"env"}}]}`))
+		case "GET /workspaces/ws-source/varsets":
+			_, _ = w.Write([]byte(`{"data":[{"id":"varset-1","attributes":{"name":"common"}},
+				{"id":"varset-2","attributes":{"name":"global","global":true}}]}`))
+		case "GET /team-workspaces":
+			_, _ = w.Write([]byte(`{"data":[{"id":"tws-1","attributes":{"access":"write"},
+				"relationships":{"team":{"data":{"id":"team-1"}}}}]}`))
+		case "POST /organizations/org/workspaces":
+			_, _ = w.Write([]byte(`{"data":{"id":"ws-new"}}`))
+		case "POST /vars":
+			_, _ = w.Write([]byte(`{"data":{"id":"var-new-` + strconv.Itoa(len(*calls)) + `"}}`))
+		case "POST /team-workspaces":
+			if *failTeamAccess {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			_, _ = w.Write([]byte(`{"data":{"id":"tws-new"}}`))
+		default:
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+}
+
+func TestClient_CloneWithJournal(t *testing.T) {
+	failTeamAccess := true
+	var calls []string
+	server := newCloneServer(&failTeamAccess, &calls)
+	defer server.Close()
+
+	c := newTestClient(server, "token")
+	path := filepath.Join(t.TempDir(), "journal.json")
+	cfg := CloneConfig{
+		Organization:      "org",
+		SourceWorkspace:   "source",
+		NewWorkspace:      "new",
+		CopyVariables:     true,
+		ApplyVariableSets: true,
+		Exclude:           CloneCategories,
+	}
+	journal := NewCloneJournal(cfg, path)
+	_, err := c.CloneWithJournal(journal)
+	require.Error(t, err)
+
+	saved, err := ReadCloneJournal(path)
+	require.NoError(t, err)
+	require.Equal(t, "ws-new", saved.WorkspaceID)
+	require.Equal(t, []JournalResource{
+		{Type: JournalVariableSet, ID: "varset-1", Name: "varset-1"},
+		{Type: JournalVariable, ID: "var-new-3", Name: "terraform:region"},
+		{Type: JournalVariable, ID: "var-new-4", Name: "env:TOKEN"},
+	}, saved.Created)
+
+	// resume, and only the team access is created
+	failTeamAccess = false
+	calls = nil
+	needValues, err := c.CloneWithJournal(saved)
+	require.NoError(t, err)
+	require.Equal(t, []string{"TOKEN"}, needValues)
+	require.Equal(t, []string{"POST /team-workspaces"}, calls)
+
+	require.NoError(t, saved.Remove())
+	_, err = os.Stat(path)
+	require.True(t, os.IsNotExist(err))
+}
+
+func TestClient_CloneWorkspaceRollback(t *testing.T) {
+	failTeamAccess := true
+	var calls []string
+	server := newCloneServer(&failTeamAccess, &calls)
+	defer server.Close()
+
+	c := newTestClient(server, "token")
+	_, err := c.CloneWorkspace(CloneConfig{
+		Organization:    "org",
+		SourceWorkspace: "source",
+		NewWorkspace:    "new",
+		Exclude:         CloneCategories,
+	})
+	require.Error(t, err)
+	require.NotContains(t, calls, "POST /varsets/varset-1/relationships/workspaces")
+	require.Equal(t, "DELETE /workspaces/ws-new", calls[len(calls)-1])
+}
+
+func TestClient_RollbackClone(t *testing.T) {
+	var calls []string
+	server := newCloneServer(new(bool), &calls)
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "journal.json")
+	journal := NewCloneJournal(CloneConfig{}, path)
+	journal.WorkspaceID = "ws-new"
+	require.NoError(t, journal.add(JournalVariable, "var-1", "terraform:region"))
+
+	c := newTestClient(server, "token")
+	require.NoError(t, c.RollbackClone(journal))
+	require.Equal(t, []string{"DELETE /workspaces/ws-new"}, calls)
+	require.Empty(t, journal.WorkspaceID)
+	require.Empty(t, journal.Created)
+	_, err := os.Stat(path)
+	require.True(t, os.IsNotExist(err))
+}
@@ -0,0 +1,234 @@
+package lib
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Status of a row in a ClonePlanResult
+const (
+	ClonePlanCloned     = "cloned"
+	ClonePlanFailed     = "failed"
+	ClonePlanNotStarted = "not started"
+)
+
+// ClonePlanResult is the outcome of cloning one row of a clone plan
+type ClonePlanResult struct {
+	OpsConfig
+	Status     string
+	NeedValues []string // keys of the variables in the new workspace that need a value
+	Error      string
+}
+
+// ClonePlanResultColumns returns the names of the values returned by ClonePlanResult.AsArray
+func ClonePlanResultColumns() []string {
+	return append(new(OpsConfig).getColNames(), "Status", "NeedValues", "Error")
+}
+
+// AsArray returns the values of the ClonePlanResult attributes
+func (r *ClonePlanResult) AsArray() []string {
+	return append(r.OpsConfig.AsArray(), r.Status, strings.Join(r.NeedValues, " "), r.Error)
+}
+
+// ReadClonePlanCSV reads the rows of a clone plan in CSV format. The first line names the columns, which are the
+// attributes of OpsConfig in any order. Column names are not case-sensitive, and may have dashes or underscores
+// between words, e.g. "source-name". Columns that are left out are empty in every row.
+func ReadClonePlanCSV(r io.Reader) ([]OpsConfig, error) {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+	records, err := cr.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("invalid clone plan: %w", err)
+	}
+	if len(records) < 2 {
+		return nil, errors.New("invalid clone plan: no rows after the header")
+	}
+
+	colNames := new(OpsConfig).getColNames()
+	fields := map[string]int{}
+	for i, name := range colNames {
+		fields[planColumnKey(name)] = i
+	}
+	columns := make([]int, len(records[0]))
+	seen := map[int]bool{}
+	for i, name := range records[0] {
+		field, ok := fields[planColumnKey(name)]
+		if !ok {
+			return nil, fmt.Errorf("invalid clone plan: unknown column '%s', must be one of: %s",
+				name, strings.Join(colNames, ", "))
+		}
+		if seen[field] {
+			return nil, fmt.Errorf("invalid clone plan: column '%s' is given more than once", name)
+		}
+		seen[field] = true
+		columns[i] = field
+	}
+
+	rows := make([]OpsConfig, len(records)-1)
+	for i, record := range records[1:] {
+		val := reflect.ValueOf(&rows[i]).Elem()
+		for j, value := range record {
+			val.Field(columns[j]).SetString(strings.TrimSpace(value))
+		}
+	}
+	return rows, nil
+}
+
+// planColumnKey returns a column name in a form that doesn't depend on how it is written
+func planColumnKey(name string) string {
+	return strings.NewReplacer("-", "", "_", "", " ", "").Replace(strings.ToLower(strings.TrimSpace(name)))
+}
+
+// ReadClonePlanYAML reads the rows of a clone plan in YAML format: a list of objects with the keys "source-org",
+// "source-name", "new-org", "new-name", "terraform-version", "repo-id", "branch", "directory" and "vcs-token-id".
+func ReadClonePlanYAML(r io.Reader) ([]OpsConfig, error) {
+	var rows []OpsConfig
+	dec := yaml.NewDecoder(r)
+	dec.KnownFields(true)
+	if err := dec.Decode(&rows); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("invalid clone plan: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, errors.New("invalid clone plan: no rows")
+	}
+	return rows, nil
+}
+
+// clonePlanConfig returns the configuration for cloning one row of a clone plan. The organizations that are not
+// given in the row are taken from cfg. A row that clones to another organization is treated as a clone to a
+// different account, which uses the destination token of cfg, and the VCS token of the row or else of cfg.
+func clonePlanConfig(cfg CloneConfig, oc OpsConfig) CloneConfig {
+	if oc.SourceOrg != "" {
+		cfg.Organization = oc.SourceOrg
+	}
+	if oc.NewOrg != "" {
+		cfg.NewOrganization = oc.NewOrg
+	}
+	if cfg.NewOrganization == "" {
+		cfg.NewOrganization = cfg.Organization
+	}
+	if cfg.NewOrganization != cfg.Organization {
+		cfg.DifferentDestinationAccount = true
+	}
+	cfg.SourceWorkspace = oc.SourceName
+	cfg.NewWorkspace = oc.NewName
+	cfg.TerraformVersion = oc.TerraformVersion
+	cfg.RepoID = oc.RepoID
+	cfg.Branch = oc.Branch
+	cfg.Directory = oc.Directory
+	if oc.VCSTokenID != "" {
+		cfg.NewVCSTokenID = oc.VCSTokenID
+	}
+	return cfg
+}
+
+// ValidateClonePlan checks every row of a clone plan, with the options in cfg, before anything is cloned: that the
+// source workspace exists, that the new workspace doesn't exist yet, that no two rows create the same workspace, and
+// that the VCS token of cfg, which belongs to one organization, is not used for more than one. The error lists the
+// problems found in all the rows.
+func (c *Client) ValidateClonePlan(cfg CloneConfig, rows []OpsConfig) error {
+	return c.ValidateClonePlanContext(context.Background(), cfg, rows)
+}
+
+// ValidateClonePlanContext is like ValidateClonePlan but uses ctx for its API calls
+func (c *Client) ValidateClonePlanContext(ctx context.Context, cfg CloneConfig, rows []OpsConfig) error {
+	var problems []string
+	newWorkspaces := map[string]int{}
+	sharedTokenOrg, sharedTokenRow := "", 0
+	for i, oc := range rows {
+		row := i + 1
+		rowCfg := clonePlanConfig(cfg, oc)
+		if rowCfg.Organization == "" || rowCfg.SourceWorkspace == "" || rowCfg.NewWorkspace == "" {
+			problems = append(problems, fmt.Sprintf("row %d: the source organization, source name and new name are required", row))
+			continue
+		}
+
+		newWorkspace := rowCfg.NewOrganization + ":" + rowCfg.NewWorkspace
+		if first, ok := newWorkspaces[newWorkspace]; ok {
+			problems = append(problems, fmt.Sprintf("row %d: workspace %s is also created by row %d", row, newWorkspace, first))
+			continue
+		}
+		newWorkspaces[newWorkspace] = row
+
+		if rowCfg.DifferentDestinationAccount && rowCfg.NewVCSTokenID == "" {
+			problems = append(problems, fmt.Sprintf("row %d: a VCS token ID for organization %s is required", row, rowCfg.NewOrganization))
+		} else if rowCfg.DifferentDestinationAccount && oc.VCSTokenID == "" {
+			if sharedTokenOrg == "" {
+				sharedTokenOrg, sharedTokenRow = rowCfg.NewOrganization, row
+			} else if sharedTokenOrg != rowCfg.NewOrganization {
+				problems = append(problems, fmt.Sprintf("row %d: a VCS token ID for organization %s is required, "+
+					"the one given for the plan is used for organization %s in row %d",
+					row, rowCfg.NewOrganization, sharedTokenOrg, sharedTokenRow))
+			}
+		}
+
+		_, err := c.GetWorkspaceDataContext(ctx, rowCfg.Organization, rowCfg.SourceWorkspace)
+		if errors.Is(err, ErrNotFound) {
+			problems = append(problems, fmt.Sprintf("row %d: source workspace %s:%s not found", row, rowCfg.Organization, rowCfg.SourceWorkspace))
+		} else if err != nil {
+			return err
+		}
+
+		destination := c
+		if rowCfg.DifferentDestinationAccount {
+			destination = c.WithToken(rowCfg.AtlasTokenDestination)
+		}
+		_, err = destination.GetWorkspaceDataContext(ctx, rowCfg.NewOrganization, rowCfg.NewWorkspace)
+		if err == nil {
+			problems = append(problems, fmt.Sprintf("row %d: workspace %s already exists", row, newWorkspace))
+		} else if !errors.Is(err, ErrNotFound) {
+			return err
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid clone plan:\n  %s", strings.Join(problems, "\n  "))
+	}
+	return nil
+}
+
+// ClonePlan clones the workspace in each row of a clone plan, with the options in cfg, and returns the result of
+// each row. Rows are cloned up to the client's parallelism limit at once. A clone that fails is rolled back, and the
+// other rows are still cloned. Progress messages are written to out, which may be nil.
+func (c *Client) ClonePlan(cfg CloneConfig, rows []OpsConfig, out io.Writer) []ClonePlanResult {
+	return c.ClonePlanContext(context.Background(), cfg, rows, out)
+}
+
+// ClonePlanContext is like ClonePlan but uses ctx for its API calls. If ctx is canceled, the rows that were not
+// started have the status ClonePlanNotStarted.
+func (c *Client) ClonePlanContext(ctx context.Context, cfg CloneConfig, rows []OpsConfig, out io.Writer) []ClonePlanResult {
+	results := make([]ClonePlanResult, len(rows))
+	names := make([]string, len(rows))
+	for i, oc := range rows {
+		rowCfg := clonePlanConfig(cfg, oc)
+		oc.SourceOrg = rowCfg.Organization
+		oc.NewOrg = rowCfg.NewOrganization
+		results[i] = ClonePlanResult{OpsConfig: oc, Status: ClonePlanNotStarted}
+		names[i] = strconv.Itoa(i)
+	}
+
+	_ = c.ForEachWorkspace(ctx, names, out, func(ctx context.Context, name string, w io.Writer) error {
+		i, _ := strconv.Atoi(name)
+		r := &results[i]
+		needValues, err := c.CloneWorkspaceContext(ctx, clonePlanConfig(cfg, rows[i]))
+		if err != nil {
+			r.Status = ClonePlanFailed
+			r.Error = err.Error()
+			fmt.Fprintf(w, "failed to clone %s:%s to %s:%s: %s\n", r.SourceOrg, r.SourceName, r.NewOrg, r.NewName, err)
+			return nil
+		}
+		r.Status = ClonePlanCloned
+		r.NeedValues = needValues
+		fmt.Fprintf(w, "cloned %s:%s to %s:%s\n", r.SourceOrg, r.SourceName, r.NewOrg, r.NewName)
+		return nil
+	})
+	return results
+}
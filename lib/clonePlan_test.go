@@ -0,0 +1,150 @@
+package lib
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadClonePlanCSV(t *testing.T) {
+	rows, err := ReadClonePlanCSV(strings.NewReader(`source-name,NewName, new_org ,Branch,vcs-token-id
+template,env-dev,org2,develop,ot-1
+template,env-prod,,main,
+`))
+	require.NoError(t, err)
+	require.Equal(t, []OpsConfig{
+		{SourceName: "template", NewName: "env-dev", NewOrg: "org2", Branch: "develop", VCSTokenID: "ot-1"},
+		{SourceName: "template", NewName: "env-prod", Branch: "main"},
+	}, rows)
+
+	_, err = ReadClonePlanCSV(strings.NewReader("SourceName,Workspace\na,b\n"))
+	require.ErrorContains(t, err, "unknown column 'Workspace'")
+
+	_, err = ReadClonePlanCSV(strings.NewReader("SourceName,source-name\na,b\n"))
+	require.ErrorContains(t, err, "column 'source-name' is given more than once")
+
+	_, err = ReadClonePlanCSV(strings.NewReader("SourceName,NewName\n"))
+	require.ErrorContains(t, err, "no rows")
+
+	_, err = ReadClonePlanCSV(strings.NewReader("SourceName,NewName\na\n"))
+	require.ErrorContains(t, err, "wrong number of fields")
+}
+
+func TestReadClonePlanYAML(t *testing.T) {
+	rows, err := ReadClonePlanYAML(strings.NewReader(`
+- source-org: org1
+  source-name: template
+  new-name: env-dev
+  terraform-version: 1.5.7
+  vcs-token-id: ot-1
+- source-name: template
+  new-name: env-prod
+  directory: prod
+`))
+	require.NoError(t, err)
+	require.Equal(t, []OpsConfig{
+		{SourceOrg: "org1", SourceName: "template", NewName: "env-dev", TerraformVersion: "1.5.7", VCSTokenID: "ot-1"},
+		{SourceName: "template", NewName: "env-prod", Directory: "prod"},
+	}, rows)
+
+	_, err = ReadClonePlanYAML(strings.NewReader("- source-name: a\n  workspace: b\n"))
+	require.ErrorContains(t, err, "field workspace not found")
+
+	_, err = ReadClonePlanYAML(strings.NewReader(""))
+	require.ErrorContains(t, err, "no rows")
+}
+
+func Test_clonePlanConfig(t *testing.T) {
+	cfg := CloneConfig{Organization: "org1", CopyVariables: true}
+
+	got := clonePlanConfig(cfg, OpsConfig{SourceName: "a", NewName: "b", Branch: "main"})
+	require.Equal(t, CloneConfig{Organization: "org1", NewOrganization: "org1", SourceWorkspace: "a",
+		NewWorkspace: "b", Branch: "main", CopyVariables: true}, got)
+
+	got = clonePlanConfig(cfg, OpsConfig{SourceName: "a", NewOrg: "org2", NewName: "b"})
+	require.Equal(t, "org2", got.NewOrganization)
+	require.True(t, got.DifferentDestinationAccount)
+
+	cfg.NewVCSTokenID = "ot-1"
+	got = clonePlanConfig(cfg, OpsConfig{SourceName: "a", NewOrg: "org2", NewName: "b", VCSTokenID: "ot-2"})
+	require.Equal(t, "ot-2", got.NewVCSTokenID)
+	got = clonePlanConfig(cfg, OpsConfig{SourceName: "a", NewOrg: "org2", NewName: "b"})
+	require.Equal(t, "ot-1", got.NewVCSTokenID)
+}
+
+func TestClient_ValidateClonePlan(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch strings.TrimPrefix(r.URL.Path, apiPath) {
+		case "/organizations/org/workspaces/template", "/organizations/org/workspaces/existing":
+			_, _ = w.Write([]byte(`{"data":{"id":"ws-1"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c := newTestClient(server, "token")
+	cfg := CloneConfig{Organization: "org"}
+
+	require.NoError(t, c.ValidateClonePlan(cfg, []OpsConfig{
+		{SourceName: "template", NewName: "env-dev"},
+		{SourceName: "template", NewName: "env-prod"},
+	}))
+
+	err := c.ValidateClonePlan(cfg, []OpsConfig{
+		{SourceName: "template", NewName: "env-dev"},
+		{SourceName: "missing", NewName: "env-test"},
+		{SourceName: "template", NewName: "existing"},
+		{SourceName: "template", NewName: "env-dev"},
+		{SourceName: "template"},
+		{SourceName: "template", NewOrg: "org2", NewName: "env-dev"},
+	})
+	require.EqualError(t, err, `invalid clone plan:
+  row 2: source workspace org:missing not found
+  row 3: workspace org:existing already exists
+  row 4: workspace org:env-dev is also created by row 1
+  row 5: the source organization, source name and new name are required
+  row 6: a VCS token ID for organization org2 is required`)
+
+	cfg.NewVCSTokenID = "ot-1"
+	err = c.ValidateClonePlan(cfg, []OpsConfig{
+		{SourceName: "template", NewOrg: "org2", NewName: "env-dev"},
+		{SourceName: "template", NewOrg: "org3", NewName: "env-dev", VCSTokenID: "ot-3"},
+		{SourceName: "template", NewOrg: "org4", NewName: "env-dev"},
+	})
+	require.EqualError(t, err, `invalid clone plan:
+  row 3: a VCS token ID for organization org4 is required, the one given for the plan is used for organization org2 in row 1`)
+}
+
+func TestClient_ClonePlan(t *testing.T) {
+	failTeamAccess := false
+	var calls []string
+	server := newCloneServer(&failTeamAccess, &calls)
+	defer server.Close()
+
+	c := newTestClient(server, "token")
+	cfg := CloneConfig{Organization: "org", Exclude: CloneCategories}
+	results := c.ClonePlan(cfg, []OpsConfig{
+		{SourceName: "source", NewName: "new"},
+		{SourceName: "missing", NewName: "other"},
+	}, nil)
+
+	require.Equal(t, []ClonePlanResult{
+		{
+			OpsConfig:  OpsConfig{SourceOrg: "org", SourceName: "source", NewOrg: "org", NewName: "new"},
+			Status:     ClonePlanCloned,
+			NeedValues: []string{"region", "TOKEN"},
+		},
+		{
+			OpsConfig: OpsConfig{SourceOrg: "org", SourceName: "missing", NewOrg: "org", NewName: "other"},
+			Status:    ClonePlanFailed,
+			Error:     results[1].Error,
+		},
+	}, results)
+	require.NotEmpty(t, results[1].Error)
+	require.Equal(t, []string{"org", "missing", "org", "other", "", "", "", "", "", "failed", "", results[1].Error},
+		results[1].AsArray())
+}
@@ -0,0 +1,119 @@
+package lib
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Jeffail/gabs/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const cloneSourceJSON = `{
+  "id": "ws-source",
+  "attributes": {
+    "auto-apply": true,
+    "description": null,
+    "execution-mode": "agent",
+    "trigger-patterns": ["/modules/**/*"],
+    "tag-names": ["app", "prod"]
+  },
+  "relationships": {
+    "agent-pool": {"data": {"id": "apool-1", "type": "agent-pools"}},
+    "project": {"data": {"id": "prj-1", "type": "projects"}}
+  }
+}`
+
+func Test_cloneSettings(t *testing.T) {
+	source, err := gabs.ParseJSON([]byte(cloneSourceJSON))
+	require.NoError(t, err)
+
+	require.Equal(t, map[string]any{
+		"auto-apply":       true,
+		"execution-mode":   "agent",
+		"agent-pool-id":    "apool-1",
+		"trigger-patterns": []any{"/modules/**/*"},
+	}, cloneSettings(source, true))
+
+	require.Equal(t, map[string]any{
+		"auto-apply":       true,
+		"trigger-patterns": []any{"/modules/**/*"},
+	}, cloneSettings(source, false))
+}
+
+func Test_validateCloneExclude(t *testing.T) {
+	require.NoError(t, validateCloneExclude(nil))
+	require.NoError(t, validateCloneExclude([]string{CloneTags, CloneNotifications}))
+	require.ErrorContains(t, validateCloneExclude([]string{"tags", "variables"}), "invalid category to exclude 'variables'")
+}
+
+func TestClient_cloneWorkspaceConfig(t *testing.T) {
+	source, err := gabs.ParseJSON([]byte(cloneSourceJSON))
+	require.NoError(t, err)
+
+	bodies := map[string][]string{}
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		call := r.Method + " " + strings.TrimPrefix(r.URL.Path, apiPath)
+		body, _ := io.ReadAll(r.Body)
+		bodies[call] = append(bodies[call], string(body))
+
+		switch call {
+		case "GET /workspaces/ws-source/relationships/remote-state-consumers":
+			_, _ = w.Write([]byte(`{"data":[{"id":"ws-consumer","attributes":{"name":"consumer"}}]}`))
+		case "GET /workspaces/ws-source/run-triggers":
+			assert.Equal(t, "inbound", r.URL.Query().Get(paramFilterRunTriggerType))
+			_, _ = w.Write([]byte(`{"data":[{"id":"rt-1","attributes":{"sourceable-name":"network",
+				"workspace-name":"source","created-at":"2023-06-20T08:56:50.996Z"},"relationships":{
+				"sourceable":{"data":{"id":"ws-network"}},"workspace":{"data":{"id":"ws-source"}}}}]}`))
+		case "GET /workspaces/ws-source/notification-configurations":
+			_, _ = w.Write([]byte(`{"data":[{"id":"nc-1","attributes":{"destination-type":"email","enabled":true,
+				"name":"errors","url":null,"triggers":["run:errored"]},"relationships":{"users":{"data":[
+				{"id":"user-1","type":"users"}]}}}]}`))
+		default:
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	defer server.Close()
+
+	c := newTestClient(server, "token")
+	for _, category := range CloneCategories {
+		require.NoError(t, c.cloneWorkspaceCategory(context.Background(), category, source, c, "ws-new", true))
+	}
+
+	require.JSONEq(t, `{"data":{"type":"workspaces","attributes":{"auto-apply":true,"execution-mode":"agent",
+		"agent-pool-id":"apool-1","trigger-patterns":["/modules/**/*"]}}}`, bodies["PATCH /workspaces/ws-new"][0])
+	require.JSONEq(t, `{"data":{"type":"workspaces","relationships":{"project":{"data":{"id":"prj-1","type":"projects"}}}}}`,
+		bodies["PATCH /workspaces/ws-new"][1])
+	require.JSONEq(t, `{"data":[{"type":"tags","attributes":{"name":"app"}},{"type":"tags","attributes":{"name":"prod"}}]}`,
+		bodies["POST /workspaces/ws-new/relationships/tags"][0])
+	require.JSONEq(t, `{"data":[{"type":"workspaces","id":"ws-consumer"}]}`,
+		bodies["POST /workspaces/ws-new/relationships/remote-state-consumers"][0])
+	require.JSONEq(t, `{"data":{"relationships":{"sourceable":{"data":{"type":"workspaces","id":"ws-network"}}}}}`,
+		bodies["POST /workspaces/ws-new/run-triggers"][0])
+	require.JSONEq(t, `{"data":{"type":"notification-configurations","attributes":{"destination-type":"email",
+		"enabled":true,"name":"errors","triggers":["run:errored"]},"relationships":{"users":{"data":[
+		{"id":"user-1","type":"users"}]}}}}`, bodies["POST /workspaces/ws-new/notification-configurations"][0])
+}
+
+func TestClient_cloneWorkspaceCategoryOtherOrg(t *testing.T) {
+	source, err := gabs.ParseJSON([]byte(cloneSourceJSON))
+	require.NoError(t, err)
+
+	var calls []string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+strings.TrimPrefix(r.URL.Path, apiPath))
+		_, _ = w.Write([]byte(`{"data":[]}`))
+	}))
+	defer server.Close()
+
+	c := newTestClient(server, "token")
+	for _, category := range []string{CloneProject, CloneRemoteStateConsumers, CloneRunTriggers} {
+		require.NoError(t, c.cloneWorkspaceCategory(context.Background(), category, source, c, "ws-new", false))
+	}
+	require.Empty(t, calls, "objects in the source organization should not be copied to another organization")
+}
@@ -1,25 +1,35 @@
 package lib
 
-type Config struct {
-	token    string
-	debug    bool
-	readOnly bool
-}
-
-var config Config
+// defaultClient is used by the package-level functions. Its settings are changed by the functions in this file,
+// which are not safe to call while another goroutine is using the package-level functions.
+var defaultClient = NewClient(ClientConfig{})
 
 func EnableDebug() {
-	config.debug = true
+	defaultClient.debug = true
 }
 
 func EnableReadOnlyMode() {
-	config.readOnly = true
+	defaultClient.readOnly = true
 }
 
 func SetToken(t string) {
-	config.token = t
+	defaultClient.token = t
 }
 
 func GetToken() string {
-	return config.token
+	return defaultClient.token
+}
+
+// SetHostname selects the Terraform Cloud or Terraform Enterprise host used for API calls. An empty string
+// restores the default, app.terraform.io.
+func SetHostname(h string) {
+	if h == "" {
+		h = DefaultHostname
+	}
+	defaultClient.hostname = h
+}
+
+// GetHostname returns the hostname used for API calls
+func GetHostname() string {
+	return defaultClient.hostname
 }
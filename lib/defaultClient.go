@@ -0,0 +1,830 @@
+package lib
+
+import (
+	"context"
+	"io"
+)
+
+// The functions in this file are kept for compatibility with earlier versions of this package. Each one calls the
+// Client method of the same name on a default client, which is configured with SetToken, SetHostname, EnableDebug
+// and EnableReadOnlyMode. New code should create its own Client with NewClient.
+
+// OrganizationExists is a wrapper around Client.OrganizationExists using the default client
+func OrganizationExists(organization string) (bool, error) {
+	return defaultClient.OrganizationExists(organization)
+}
+
+// OrganizationExistsContext is a wrapper around Client.OrganizationExistsContext using the default client
+func OrganizationExistsContext(ctx context.Context, organization string) (bool, error) {
+	return defaultClient.OrganizationExistsContext(ctx, organization)
+}
+
+// GetAllWorkspaces is a wrapper around Client.GetAllWorkspaces using the default client
+func GetAllWorkspaces(organization string) ([]Workspace, error) {
+	return defaultClient.GetAllWorkspaces(organization)
+}
+
+// GetAllWorkspacesContext is a wrapper around Client.GetAllWorkspacesContext using the default client
+func GetAllWorkspacesContext(ctx context.Context, organization string) ([]Workspace, error) {
+	return defaultClient.GetAllWorkspacesContext(ctx, organization)
+}
+
+// GetWorkspaceData is a wrapper around Client.GetWorkspaceData using the default client
+func GetWorkspaceData(organization, workspaceName string) (WorkspaceJSON, error) {
+	return defaultClient.GetWorkspaceData(organization, workspaceName)
+}
+
+// GetWorkspaceDataContext is a wrapper around Client.GetWorkspaceDataContext using the default client
+func GetWorkspaceDataContext(ctx context.Context, organization, workspaceName string) (WorkspaceJSON, error) {
+	return defaultClient.GetWorkspaceDataContext(ctx, organization, workspaceName)
+}
+
+// GetWorkspaceVar is a wrapper around Client.GetWorkspaceVar using the default client
+func GetWorkspaceVar(organization, wsName, key string) (*Var, error) {
+	return defaultClient.GetWorkspaceVar(organization, wsName, key)
+}
+
+// GetWorkspaceVarContext is a wrapper around Client.GetWorkspaceVarContext using the default client
+func GetWorkspaceVarContext(ctx context.Context, organization, wsName, key string) (*Var, error) {
+	return defaultClient.GetWorkspaceVarContext(ctx, organization, wsName, key)
+}
+
+// GetWorkspaceVarInCategory is a wrapper around Client.GetWorkspaceVarInCategory using the default client
+func GetWorkspaceVarInCategory(organization, wsName, category, key string) (*Var, error) {
+	return defaultClient.GetWorkspaceVarInCategory(organization, wsName, category, key)
+}
+
+// GetWorkspaceVarInCategoryContext is a wrapper around Client.GetWorkspaceVarInCategoryContext using the default client
+func GetWorkspaceVarInCategoryContext(ctx context.Context, organization, wsName, category, key string) (*Var, error) {
+	return defaultClient.GetWorkspaceVarInCategoryContext(ctx, organization, wsName, category, key)
+}
+
+// GetVarsFromWorkspace is a wrapper around Client.GetVarsFromWorkspace using the default client
+func GetVarsFromWorkspace(organization, workspaceName string) ([]Var, error) {
+	return defaultClient.GetVarsFromWorkspace(organization, workspaceName)
+}
+
+// GetVarsFromWorkspaceContext is a wrapper around Client.GetVarsFromWorkspaceContext using the default client
+func GetVarsFromWorkspaceContext(ctx context.Context, organization, workspaceName string) ([]Var, error) {
+	return defaultClient.GetVarsFromWorkspaceContext(ctx, organization, workspaceName)
+}
+
+// DeleteVariable is a wrapper around Client.DeleteVariable using the default client
+func DeleteVariable(variableID string) error {
+	return defaultClient.DeleteVariable(variableID)
+}
+
+// DeleteVariableContext is a wrapper around Client.DeleteVariableContext using the default client
+func DeleteVariableContext(ctx context.Context, variableID string) error {
+	return defaultClient.DeleteVariableContext(ctx, variableID)
+}
+
+// SearchVarsInAllWorkspaces is a wrapper around Client.SearchVarsInAllWorkspaces using the default client
+func SearchVarsInAllWorkspaces(wsData []Workspace, organization, keyContains, valueContains string) (map[string][]Var, error) {
+	return defaultClient.SearchVarsInAllWorkspaces(wsData, organization, keyContains, valueContains)
+}
+
+// SearchVarsInAllWorkspacesContext is a wrapper around Client.SearchVarsInAllWorkspacesContext using the default client
+func SearchVarsInAllWorkspacesContext(ctx context.Context, wsData []Workspace, organization, keyContains, valueContains string) (map[string][]Var, error) {
+	return defaultClient.SearchVarsInAllWorkspacesContext(ctx, wsData, organization, keyContains, valueContains)
+}
+
+// SearchVariables is a wrapper around Client.SearchVariables using the default client
+func SearchVariables(organization, wsName, keyContains, valueContains string) ([]Var, error) {
+	return defaultClient.SearchVariables(organization, wsName, keyContains, valueContains)
+}
+
+// SearchVariablesContext is a wrapper around Client.SearchVariablesContext using the default client
+func SearchVariablesContext(ctx context.Context, organization, wsName, keyContains, valueContains string) ([]Var, error) {
+	return defaultClient.SearchVariablesContext(ctx, organization, wsName, keyContains, valueContains)
+}
+
+// GetTeamAccessFrom is a wrapper around Client.GetTeamAccessFrom using the default client
+func GetTeamAccessFrom(workspaceID string) (AllTeamWorkspaceData, error) {
+	return defaultClient.GetTeamAccessFrom(workspaceID)
+}
+
+// GetTeamAccessFromContext is a wrapper around Client.GetTeamAccessFromContext using the default client
+func GetTeamAccessFromContext(ctx context.Context, workspaceID string) (AllTeamWorkspaceData, error) {
+	return defaultClient.GetTeamAccessFromContext(ctx, workspaceID)
+}
+
+// AssignTeamAccess is a wrapper around Client.AssignTeamAccess using the default client
+func AssignTeamAccess(workspaceID string, allTeamData AllTeamWorkspaceData) error {
+	return defaultClient.AssignTeamAccess(workspaceID, allTeamData)
+}
+
+// AssignTeamAccessContext is a wrapper around Client.AssignTeamAccessContext using the default client
+func AssignTeamAccessContext(ctx context.Context, workspaceID string, allTeamData AllTeamWorkspaceData) error {
+	return defaultClient.AssignTeamAccessContext(ctx, workspaceID, allTeamData)
+}
+
+// UpdateTeamAccess is a wrapper around Client.UpdateTeamAccess using the default client
+func UpdateTeamAccess(teamWorkspaceID, accessLevel string) error {
+	return defaultClient.UpdateTeamAccess(teamWorkspaceID, accessLevel)
+}
+
+// UpdateTeamAccessContext is a wrapper around Client.UpdateTeamAccessContext using the default client
+func UpdateTeamAccessContext(ctx context.Context, teamWorkspaceID, accessLevel string) error {
+	return defaultClient.UpdateTeamAccessContext(ctx, teamWorkspaceID, accessLevel)
+}
+
+// ListTeams is a wrapper around Client.ListTeams using the default client
+func ListTeams(organization string) ([]Team, error) {
+	return defaultClient.ListTeams(organization)
+}
+
+// ListTeamsContext is a wrapper around Client.ListTeamsContext using the default client
+func ListTeamsContext(ctx context.Context, organization string) ([]Team, error) {
+	return defaultClient.ListTeamsContext(ctx, organization)
+}
+
+// CreateVariable is a wrapper around Client.CreateVariable using the default client
+func CreateVariable(organization, workspaceName string, tfVar Var) error {
+	return defaultClient.CreateVariable(organization, workspaceName, tfVar)
+}
+
+// CreateVariableContext is a wrapper around Client.CreateVariableContext using the default client
+func CreateVariableContext(ctx context.Context, organization, workspaceName string, tfVar Var) error {
+	return defaultClient.CreateVariableContext(ctx, organization, workspaceName, tfVar)
+}
+
+// CreateAllVariables is a wrapper around Client.CreateAllVariables using the default client
+func CreateAllVariables(organization, workspaceName string, tfVars []Var) error {
+	return defaultClient.CreateAllVariables(organization, workspaceName, tfVars)
+}
+
+// CreateAllVariablesContext is a wrapper around Client.CreateAllVariablesContext using the default client
+func CreateAllVariablesContext(ctx context.Context, organization, workspaceName string, tfVars []Var) error {
+	return defaultClient.CreateAllVariablesContext(ctx, organization, workspaceName, tfVars)
+}
+
+// UpdateVariable is a wrapper around Client.UpdateVariable using the default client
+func UpdateVariable(organization, workspaceName, variableID string, tfVar Var) error {
+	return defaultClient.UpdateVariable(organization, workspaceName, variableID, tfVar)
+}
+
+// UpdateVariableContext is a wrapper around Client.UpdateVariableContext using the default client
+func UpdateVariableContext(ctx context.Context, organization, workspaceName, variableID string, tfVar Var) error {
+	return defaultClient.UpdateVariableContext(ctx, organization, workspaceName, variableID, tfVar)
+}
+
+// CreateWorkspace is a wrapper around Client.CreateWorkspace using the default client
+func CreateWorkspace(oc OpsConfig, vcsTokenID string) (string, error) {
+	return defaultClient.CreateWorkspace(oc, vcsTokenID)
+}
+
+// CreateWorkspaceContext is a wrapper around Client.CreateWorkspaceContext using the default client
+func CreateWorkspaceContext(ctx context.Context, oc OpsConfig, vcsTokenID string) (string, error) {
+	return defaultClient.CreateWorkspaceContext(ctx, oc, vcsTokenID)
+}
+
+// CreateWorkspace2 is a wrapper around Client.CreateWorkspace2 using the default client
+func CreateWorkspace2(oc OpsConfig, vcsTokenID string) (Workspace, error) {
+	return defaultClient.CreateWorkspace2(oc, vcsTokenID)
+}
+
+// CreateWorkspace2Context is a wrapper around Client.CreateWorkspace2Context using the default client
+func CreateWorkspace2Context(ctx context.Context, oc OpsConfig, vcsTokenID string) (Workspace, error) {
+	return defaultClient.CreateWorkspace2Context(ctx, oc, vcsTokenID)
+}
+
+// RunTFInit is a wrapper around Client.RunTFInit using the default client
+func RunTFInit(oc OpsConfig, tfTokenDestination string) error {
+	return defaultClient.RunTFInit(oc, tfTokenDestination)
+}
+
+// RunTFInitContext is a wrapper around Client.RunTFInitContext using the default client
+func RunTFInitContext(ctx context.Context, oc OpsConfig, tfTokenDestination string) error {
+	return defaultClient.RunTFInitContext(ctx, oc, tfTokenDestination)
+}
+
+// CloneWorkspace is a wrapper around Client.CloneWorkspace using the default client
+func CloneWorkspace(cfg CloneConfig) ([]string, error) {
+	return defaultClient.CloneWorkspace(cfg)
+}
+
+// CloneWorkspaceContext is a wrapper around Client.CloneWorkspaceContext using the default client
+func CloneWorkspaceContext(ctx context.Context, cfg CloneConfig) ([]string, error) {
+	return defaultClient.CloneWorkspaceContext(ctx, cfg)
+}
+
+// AddOrUpdateVariable is a wrapper around Client.AddOrUpdateVariable using the default client
+func AddOrUpdateVariable(cfg UpdateConfig) (string, error) {
+	return defaultClient.AddOrUpdateVariable(cfg)
+}
+
+// AddOrUpdateVariableContext is a wrapper around Client.AddOrUpdateVariableContext using the default client
+func AddOrUpdateVariableContext(ctx context.Context, cfg UpdateConfig) (string, error) {
+	return defaultClient.AddOrUpdateVariableContext(ctx, cfg)
+}
+
+// UpdateWorkspace is a wrapper around Client.UpdateWorkspace using the default client
+func UpdateWorkspace(params WorkspaceUpdateParams) error {
+	return defaultClient.UpdateWorkspace(params)
+}
+
+// UpdateWorkspaceContext is a wrapper around Client.UpdateWorkspaceContext using the default client
+func UpdateWorkspaceContext(ctx context.Context, params WorkspaceUpdateParams) error {
+	return defaultClient.UpdateWorkspaceContext(ctx, params)
+}
+
+// UpdateWorkspaceAttributes is a wrapper around Client.UpdateWorkspaceAttributes using the default client
+func UpdateWorkspaceAttributes(workspaceID string, attributes map[string]any) error {
+	return defaultClient.UpdateWorkspaceAttributes(workspaceID, attributes)
+}
+
+// UpdateWorkspaceAttributesContext is a wrapper around Client.UpdateWorkspaceAttributesContext using the default client
+func UpdateWorkspaceAttributesContext(ctx context.Context, workspaceID string, attributes map[string]any) error {
+	return defaultClient.UpdateWorkspaceAttributesContext(ctx, workspaceID, attributes)
+}
+
+// DeleteWorkspace is a wrapper around Client.DeleteWorkspace using the default client
+func DeleteWorkspace(workspaceID string) error {
+	return defaultClient.DeleteWorkspace(workspaceID)
+}
+
+// DeleteWorkspaceContext is a wrapper around Client.DeleteWorkspaceContext using the default client
+func DeleteWorkspaceContext(ctx context.Context, workspaceID string) error {
+	return defaultClient.DeleteWorkspaceContext(ctx, workspaceID)
+}
+
+// AddWorkspaceTags is a wrapper around Client.AddWorkspaceTags using the default client
+func AddWorkspaceTags(workspaceID string, tags []string) error {
+	return defaultClient.AddWorkspaceTags(workspaceID, tags)
+}
+
+// AddWorkspaceTagsContext is a wrapper around Client.AddWorkspaceTagsContext using the default client
+func AddWorkspaceTagsContext(ctx context.Context, workspaceID string, tags []string) error {
+	return defaultClient.AddWorkspaceTagsContext(ctx, workspaceID, tags)
+}
+
+// SetWorkspaceProject is a wrapper around Client.SetWorkspaceProject using the default client
+func SetWorkspaceProject(workspaceID, projectID string) error {
+	return defaultClient.SetWorkspaceProject(workspaceID, projectID)
+}
+
+// SetWorkspaceProjectContext is a wrapper around Client.SetWorkspaceProjectContext using the default client
+func SetWorkspaceProjectContext(ctx context.Context, workspaceID, projectID string) error {
+	return defaultClient.SetWorkspaceProjectContext(ctx, workspaceID, projectID)
+}
+
+// FindWorkspaces is a wrapper around Client.FindWorkspaces using the default client
+func FindWorkspaces(organization, workspaceFilter string) (map[string]string, error) {
+	return defaultClient.FindWorkspaces(organization, workspaceFilter)
+}
+
+// FindWorkspacesContext is a wrapper around Client.FindWorkspacesContext using the default client
+func FindWorkspacesContext(ctx context.Context, organization, workspaceFilter string) (map[string]string, error) {
+	return defaultClient.FindWorkspacesContext(ctx, organization, workspaceFilter)
+}
+
+// GetWorkspaceAttributes is a wrapper around Client.GetWorkspaceAttributes using the default client
+func GetWorkspaceAttributes(organization string, attributes []string) ([][]string, error) {
+	return defaultClient.GetWorkspaceAttributes(organization, attributes)
+}
+
+// GetWorkspaceAttributesContext is a wrapper around Client.GetWorkspaceAttributesContext using the default client
+func GetWorkspaceAttributesContext(ctx context.Context, organization string, attributes []string) ([][]string, error) {
+	return defaultClient.GetWorkspaceAttributesContext(ctx, organization, attributes)
+}
+
+// GetWorkspaceByName is a wrapper around Client.GetWorkspaceByName using the default client
+func GetWorkspaceByName(organizationName, workspaceName string) (Workspace, error) {
+	return defaultClient.GetWorkspaceByName(organizationName, workspaceName)
+}
+
+// GetWorkspaceByNameContext is a wrapper around Client.GetWorkspaceByNameContext using the default client
+func GetWorkspaceByNameContext(ctx context.Context, organizationName, workspaceName string) (Workspace, error) {
+	return defaultClient.GetWorkspaceByNameContext(ctx, organizationName, workspaceName)
+}
+
+// GetVariableSet is a wrapper around Client.GetVariableSet using the default client
+func GetVariableSet(org, vsName string) (*VariableSet, error) {
+	return defaultClient.GetVariableSet(org, vsName)
+}
+
+// GetVariableSetContext is a wrapper around Client.GetVariableSetContext using the default client
+func GetVariableSetContext(ctx context.Context, org, vsName string) (*VariableSet, error) {
+	return defaultClient.GetVariableSetContext(ctx, org, vsName)
+}
+
+// GetAllVariableSets is a wrapper around Client.GetAllVariableSets using the default client
+func GetAllVariableSets(organizationName string) (VariableSetList, error) {
+	return defaultClient.GetAllVariableSets(organizationName)
+}
+
+// GetAllVariableSetsContext is a wrapper around Client.GetAllVariableSetsContext using the default client
+func GetAllVariableSetsContext(ctx context.Context, organizationName string) (VariableSetList, error) {
+	return defaultClient.GetAllVariableSetsContext(ctx, organizationName)
+}
+
+// ApplyVariableSet is a wrapper around Client.ApplyVariableSet using the default client
+func ApplyVariableSet(varsetID string, workspaceIDs []string) error {
+	return defaultClient.ApplyVariableSet(varsetID, workspaceIDs)
+}
+
+// ApplyVariableSetContext is a wrapper around Client.ApplyVariableSetContext using the default client
+func ApplyVariableSetContext(ctx context.Context, varsetID string, workspaceIDs []string) error {
+	return defaultClient.ApplyVariableSetContext(ctx, varsetID, workspaceIDs)
+}
+
+// ApplyVariableSetsToWorkspace is a wrapper around Client.ApplyVariableSetsToWorkspace using the default client
+func ApplyVariableSetsToWorkspace(sets VariableSetList, workspaceID string) error {
+	return defaultClient.ApplyVariableSetsToWorkspace(sets, workspaceID)
+}
+
+// ApplyVariableSetsToWorkspaceContext is a wrapper around Client.ApplyVariableSetsToWorkspaceContext using the default client
+func ApplyVariableSetsToWorkspaceContext(ctx context.Context, sets VariableSetList, workspaceID string) error {
+	return defaultClient.ApplyVariableSetsToWorkspaceContext(ctx, sets, workspaceID)
+}
+
+// ListVariableSetVariables is a wrapper around Client.ListVariableSetVariables using the default client
+func ListVariableSetVariables(varsetID string) ([]Var, error) {
+	return defaultClient.ListVariableSetVariables(varsetID)
+}
+
+// ListVariableSetVariablesContext is a wrapper around Client.ListVariableSetVariablesContext using the default client
+func ListVariableSetVariablesContext(ctx context.Context, varsetID string) ([]Var, error) {
+	return defaultClient.ListVariableSetVariablesContext(ctx, varsetID)
+}
+
+// ListWorkspaceVariableSets is a wrapper around Client.ListWorkspaceVariableSets using the default client
+func ListWorkspaceVariableSets(workspaceID string) (VariableSetList, error) {
+	return defaultClient.ListWorkspaceVariableSets(workspaceID)
+}
+
+// ListWorkspaceVariableSetsContext is a wrapper around Client.ListWorkspaceVariableSetsContext using the default client
+func ListWorkspaceVariableSetsContext(ctx context.Context, workspaceID string) (VariableSetList, error) {
+	return defaultClient.ListWorkspaceVariableSetsContext(ctx, workspaceID)
+}
+
+// AddRemoteStateConsumers is a wrapper around Client.AddRemoteStateConsumers using the default client
+func AddRemoteStateConsumers(workspaceID string, consumerIDs []string) error {
+	return defaultClient.AddRemoteStateConsumers(workspaceID, consumerIDs)
+}
+
+// AddRemoteStateConsumersContext is a wrapper around Client.AddRemoteStateConsumersContext using the default client
+func AddRemoteStateConsumersContext(ctx context.Context, workspaceID string, consumerIDs []string) error {
+	return defaultClient.AddRemoteStateConsumersContext(ctx, workspaceID, consumerIDs)
+}
+
+// ListRemoteStateConsumers is a wrapper around Client.ListRemoteStateConsumers using the default client
+func ListRemoteStateConsumers(workspaceID string) (map[string]string, error) {
+	return defaultClient.ListRemoteStateConsumers(workspaceID)
+}
+
+// ListRemoteStateConsumersContext is a wrapper around Client.ListRemoteStateConsumersContext using the default client
+func ListRemoteStateConsumersContext(ctx context.Context, workspaceID string) (map[string]string, error) {
+	return defaultClient.ListRemoteStateConsumersContext(ctx, workspaceID)
+}
+
+// CreateRun is a wrapper around Client.CreateRun using the default client
+func CreateRun(config RunConfig) error {
+	return defaultClient.CreateRun(config)
+}
+
+// CreateRunContext is a wrapper around Client.CreateRunContext using the default client
+func CreateRunContext(ctx context.Context, config RunConfig) error {
+	return defaultClient.CreateRunContext(ctx, config)
+}
+
+// CreateRun2 is a wrapper around Client.CreateRun2 using the default client
+func CreateRun2(config RunConfig) (Run, error) {
+	return defaultClient.CreateRun2(config)
+}
+
+// CreateRun2Context is a wrapper around Client.CreateRun2Context using the default client
+func CreateRun2Context(ctx context.Context, config RunConfig) (Run, error) {
+	return defaultClient.CreateRun2Context(ctx, config)
+}
+
+// ListRuns is a wrapper around Client.ListRuns using the default client
+func ListRuns(config ListRunsConfig) ([]Run, error) {
+	return defaultClient.ListRuns(config)
+}
+
+// ListRunsContext is a wrapper around Client.ListRunsContext using the default client
+func ListRunsContext(ctx context.Context, config ListRunsConfig) ([]Run, error) {
+	return defaultClient.ListRunsContext(ctx, config)
+}
+
+// GetRun is a wrapper around Client.GetRun using the default client
+func GetRun(runID string) (Run, error) {
+	return defaultClient.GetRun(runID)
+}
+
+// GetRunContext is a wrapper around Client.GetRunContext using the default client
+func GetRunContext(ctx context.Context, runID string) (Run, error) {
+	return defaultClient.GetRunContext(ctx, runID)
+}
+
+// GetPlan is a wrapper around Client.GetPlan using the default client
+func GetPlan(planID string) (Plan, error) {
+	return defaultClient.GetPlan(planID)
+}
+
+// GetPlanContext is a wrapper around Client.GetPlanContext using the default client
+func GetPlanContext(ctx context.Context, planID string) (Plan, error) {
+	return defaultClient.GetPlanContext(ctx, planID)
+}
+
+// ApplyRun is a wrapper around Client.ApplyRun using the default client
+func ApplyRun(runID, comment string) error {
+	return defaultClient.ApplyRun(runID, comment)
+}
+
+// ApplyRunContext is a wrapper around Client.ApplyRunContext using the default client
+func ApplyRunContext(ctx context.Context, runID, comment string) error {
+	return defaultClient.ApplyRunContext(ctx, runID, comment)
+}
+
+// DiscardRun is a wrapper around Client.DiscardRun using the default client
+func DiscardRun(runID, comment string) error {
+	return defaultClient.DiscardRun(runID, comment)
+}
+
+// DiscardRunContext is a wrapper around Client.DiscardRunContext using the default client
+func DiscardRunContext(ctx context.Context, runID, comment string) error {
+	return defaultClient.DiscardRunContext(ctx, runID, comment)
+}
+
+// CancelRun is a wrapper around Client.CancelRun using the default client
+func CancelRun(runID, comment string) error {
+	return defaultClient.CancelRun(runID, comment)
+}
+
+// CancelRunContext is a wrapper around Client.CancelRunContext using the default client
+func CancelRunContext(ctx context.Context, runID, comment string) error {
+	return defaultClient.CancelRunContext(ctx, runID, comment)
+}
+
+// CreateRunTrigger is a wrapper around Client.CreateRunTrigger using the default client
+func CreateRunTrigger(config RunTriggerConfig) error {
+	return defaultClient.CreateRunTrigger(config)
+}
+
+// CreateRunTriggerContext is a wrapper around Client.CreateRunTriggerContext using the default client
+func CreateRunTriggerContext(ctx context.Context, config RunTriggerConfig) error {
+	return defaultClient.CreateRunTriggerContext(ctx, config)
+}
+
+// FindRunTrigger is a wrapper around Client.FindRunTrigger using the default client
+func FindRunTrigger(config FindRunTriggerConfig) (*RunTrigger, error) {
+	return defaultClient.FindRunTrigger(config)
+}
+
+// FindRunTriggerContext is a wrapper around Client.FindRunTriggerContext using the default client
+func FindRunTriggerContext(ctx context.Context, config FindRunTriggerConfig) (*RunTrigger, error) {
+	return defaultClient.FindRunTriggerContext(ctx, config)
+}
+
+// ListRunTriggers is a wrapper around Client.ListRunTriggers using the default client
+func ListRunTriggers(config ListRunTriggerConfig) ([]RunTrigger, error) {
+	return defaultClient.ListRunTriggers(config)
+}
+
+// ListRunTriggersContext is a wrapper around Client.ListRunTriggersContext using the default client
+func ListRunTriggersContext(ctx context.Context, config ListRunTriggerConfig) ([]RunTrigger, error) {
+	return defaultClient.ListRunTriggersContext(ctx, config)
+}
+
+// CloneWithJournal is a wrapper around Client.CloneWithJournal using the default client
+func CloneWithJournal(journal *CloneJournal) ([]string, error) {
+	return defaultClient.CloneWithJournal(journal)
+}
+
+// CloneWithJournalContext is a wrapper around Client.CloneWithJournalContext using the default client
+func CloneWithJournalContext(ctx context.Context, journal *CloneJournal) ([]string, error) {
+	return defaultClient.CloneWithJournalContext(ctx, journal)
+}
+
+// RollbackClone is a wrapper around Client.RollbackClone using the default client
+func RollbackClone(journal *CloneJournal) error {
+	return defaultClient.RollbackClone(journal)
+}
+
+// RollbackCloneContext is a wrapper around Client.RollbackCloneContext using the default client
+func RollbackCloneContext(ctx context.Context, journal *CloneJournal) error {
+	return defaultClient.RollbackCloneContext(ctx, journal)
+}
+
+// ValidateClonePlan is a wrapper around Client.ValidateClonePlan using the default client
+func ValidateClonePlan(cfg CloneConfig, rows []OpsConfig) error {
+	return defaultClient.ValidateClonePlan(cfg, rows)
+}
+
+// ValidateClonePlanContext is a wrapper around Client.ValidateClonePlanContext using the default client
+func ValidateClonePlanContext(ctx context.Context, cfg CloneConfig, rows []OpsConfig) error {
+	return defaultClient.ValidateClonePlanContext(ctx, cfg, rows)
+}
+
+// ClonePlan is a wrapper around Client.ClonePlan using the default client
+func ClonePlan(cfg CloneConfig, rows []OpsConfig, out io.Writer) []ClonePlanResult {
+	return defaultClient.ClonePlan(cfg, rows, out)
+}
+
+// ClonePlanContext is a wrapper around Client.ClonePlanContext using the default client
+func ClonePlanContext(ctx context.Context, cfg CloneConfig, rows []OpsConfig, out io.Writer) []ClonePlanResult {
+	return defaultClient.ClonePlanContext(ctx, cfg, rows, out)
+}
+
+// ExportHCL is a wrapper around Client.ExportHCL using the default client
+func ExportHCL(organization string, w io.Writer) error {
+	return defaultClient.ExportHCL(organization, w)
+}
+
+// ExportHCLContext is a wrapper around Client.ExportHCLContext using the default client
+func ExportHCLContext(ctx context.Context, organization string, w io.Writer) error {
+	return defaultClient.ExportHCLContext(ctx, organization, w)
+}
+
+// PlanManifest is a wrapper around Client.PlanManifest using the default client
+func PlanManifest(organization string, m Manifest) (*ManifestPlan, error) {
+	return defaultClient.PlanManifest(organization, m)
+}
+
+// PlanManifestContext is a wrapper around Client.PlanManifestContext using the default client
+func PlanManifestContext(ctx context.Context, organization string, m Manifest) (*ManifestPlan, error) {
+	return defaultClient.PlanManifestContext(ctx, organization, m)
+}
+
+// ApplyManifestPlan is a wrapper around Client.ApplyManifestPlan using the default client
+func ApplyManifestPlan(plan *ManifestPlan, out io.Writer) error {
+	return defaultClient.ApplyManifestPlan(plan, out)
+}
+
+// ApplyManifestPlanContext is a wrapper around Client.ApplyManifestPlanContext using the default client
+func ApplyManifestPlanContext(ctx context.Context, plan *ManifestPlan, out io.Writer) error {
+	return defaultClient.ApplyManifestPlanContext(ctx, plan, out)
+}
+
+// ListNotificationConfigurations is a wrapper around Client.ListNotificationConfigurations using the default client
+func ListNotificationConfigurations(workspaceID string) ([]NotificationConfiguration, error) {
+	return defaultClient.ListNotificationConfigurations(workspaceID)
+}
+
+// ListNotificationConfigurationsContext is a wrapper around Client.ListNotificationConfigurationsContext using the default client
+func ListNotificationConfigurationsContext(ctx context.Context, workspaceID string) ([]NotificationConfiguration, error) {
+	return defaultClient.ListNotificationConfigurationsContext(ctx, workspaceID)
+}
+
+// CreateNotificationConfiguration is a wrapper around Client.CreateNotificationConfiguration using the default client
+func CreateNotificationConfiguration(workspaceID string, nc NotificationConfiguration) error {
+	return defaultClient.CreateNotificationConfiguration(workspaceID, nc)
+}
+
+// CreateNotificationConfigurationContext is a wrapper around Client.CreateNotificationConfigurationContext using the default client
+func CreateNotificationConfigurationContext(ctx context.Context, workspaceID string, nc NotificationConfiguration) error {
+	return defaultClient.CreateNotificationConfigurationContext(ctx, workspaceID, nc)
+}
+
+// GetCurrentStateVersion is a wrapper around Client.GetCurrentStateVersion using the default client
+func GetCurrentStateVersion(workspaceID string) (*StateVersion, error) {
+	return defaultClient.GetCurrentStateVersion(workspaceID)
+}
+
+// GetCurrentStateVersionContext is a wrapper around Client.GetCurrentStateVersionContext using the default client
+func GetCurrentStateVersionContext(ctx context.Context, workspaceID string) (*StateVersion, error) {
+	return defaultClient.GetCurrentStateVersionContext(ctx, workspaceID)
+}
+
+// DownloadState is a wrapper around Client.DownloadState using the default client
+func DownloadState(url string) ([]byte, error) {
+	return defaultClient.DownloadState(url)
+}
+
+// DownloadStateContext is a wrapper around Client.DownloadStateContext using the default client
+func DownloadStateContext(ctx context.Context, url string) ([]byte, error) {
+	return defaultClient.DownloadStateContext(ctx, url)
+}
+
+// CreateStateVersion is a wrapper around Client.CreateStateVersion using the default client
+func CreateStateVersion(workspaceID string, state []byte) (StateVersion, error) {
+	return defaultClient.CreateStateVersion(workspaceID, state)
+}
+
+// CreateStateVersionContext is a wrapper around Client.CreateStateVersionContext using the default client
+func CreateStateVersionContext(ctx context.Context, workspaceID string, state []byte) (StateVersion, error) {
+	return defaultClient.CreateStateVersionContext(ctx, workspaceID, state)
+}
+
+// LockWorkspace is a wrapper around Client.LockWorkspace using the default client
+func LockWorkspace(workspaceID, reason string) error {
+	return defaultClient.LockWorkspace(workspaceID, reason)
+}
+
+// LockWorkspaceContext is a wrapper around Client.LockWorkspaceContext using the default client
+func LockWorkspaceContext(ctx context.Context, workspaceID, reason string) error {
+	return defaultClient.LockWorkspaceContext(ctx, workspaceID, reason)
+}
+
+// UnlockWorkspace is a wrapper around Client.UnlockWorkspace using the default client
+func UnlockWorkspace(workspaceID string) error {
+	return defaultClient.UnlockWorkspace(workspaceID)
+}
+
+// UnlockWorkspaceContext is a wrapper around Client.UnlockWorkspaceContext using the default client
+func UnlockWorkspaceContext(ctx context.Context, workspaceID string) error {
+	return defaultClient.UnlockWorkspaceContext(ctx, workspaceID)
+}
+
+// CopyState is a wrapper around Client.CopyState using the default client
+func CopyState(sourceWorkspaceID string, destination *Client, destinationWorkspaceID string) error {
+	return defaultClient.CopyState(sourceWorkspaceID, destination, destinationWorkspaceID)
+}
+
+// CopyStateContext is a wrapper around Client.CopyStateContext using the default client
+func CopyStateContext(ctx context.Context, sourceWorkspaceID string, destination *Client, destinationWorkspaceID string) error {
+	return defaultClient.CopyStateContext(ctx, sourceWorkspaceID, destination, destinationWorkspaceID)
+}
+
+// DiffWorkspaceVariables is a wrapper around Client.DiffWorkspaceVariables using the default client
+func DiffWorkspaceVariables(fromOrg, fromWorkspace, toOrg, toWorkspace string) ([]VariableDiff, error) {
+	return defaultClient.DiffWorkspaceVariables(fromOrg, fromWorkspace, toOrg, toWorkspace)
+}
+
+// DiffWorkspaceVariablesContext is a wrapper around Client.DiffWorkspaceVariablesContext using the default client
+func DiffWorkspaceVariablesContext(ctx context.Context, fromOrg, fromWorkspace, toOrg, toWorkspace string) ([]VariableDiff, error) {
+	return defaultClient.DiffWorkspaceVariablesContext(ctx, fromOrg, fromWorkspace, toOrg, toWorkspace)
+}
+
+// GetEffectiveVariables is a wrapper around Client.GetEffectiveVariables using the default client
+func GetEffectiveVariables(organization, wsName string) ([]EffectiveVariable, error) {
+	return defaultClient.GetEffectiveVariables(organization, wsName)
+}
+
+// GetEffectiveVariablesContext is a wrapper around Client.GetEffectiveVariablesContext using the default client
+func GetEffectiveVariablesContext(ctx context.Context, organization, wsName string) ([]EffectiveVariable, error) {
+	return defaultClient.GetEffectiveVariablesContext(ctx, organization, wsName)
+}
+
+// PlanVariableImport is a wrapper around Client.PlanVariableImport using the default client
+func PlanVariableImport(organization, workspace, format string, vars []Var) (*ManifestPlan, []string, error) {
+	return defaultClient.PlanVariableImport(organization, workspace, format, vars)
+}
+
+// PlanVariableImportContext is a wrapper around Client.PlanVariableImportContext using the default client
+func PlanVariableImportContext(ctx context.Context, organization, workspace, format string, vars []Var) (*ManifestPlan, []string, error) {
+	return defaultClient.PlanVariableImportContext(ctx, organization, workspace, format, vars)
+}
+
+// FindVariables is a wrapper around Client.FindVariables using the default client
+func FindVariables(organization, wsName string, m VariableMatch) ([]Var, error) {
+	return defaultClient.FindVariables(organization, wsName, m)
+}
+
+// FindVariablesContext is a wrapper around Client.FindVariablesContext using the default client
+func FindVariablesContext(ctx context.Context, organization, wsName string, m VariableMatch) ([]Var, error) {
+	return defaultClient.FindVariablesContext(ctx, organization, wsName, m)
+}
+
+// FindVarsInWorkspaces is a wrapper around Client.FindVarsInWorkspaces using the default client
+func FindVarsInWorkspaces(organization string, wsNames []string, m VariableMatch) (map[string][]Var, error) {
+	return defaultClient.FindVarsInWorkspaces(organization, wsNames, m)
+}
+
+// FindVarsInWorkspacesContext is a wrapper around Client.FindVarsInWorkspacesContext using the default client
+func FindVarsInWorkspacesContext(ctx context.Context, organization string, wsNames []string, m VariableMatch) (map[string][]Var, error) {
+	return defaultClient.FindVarsInWorkspacesContext(ctx, organization, wsNames, m)
+}
+
+// PlanVariableDelete is a wrapper around Client.PlanVariableDelete using the default client
+func PlanVariableDelete(organization string, wsNames []string, m VariableMatch) (*ManifestPlan, error) {
+	return defaultClient.PlanVariableDelete(organization, wsNames, m)
+}
+
+// PlanVariableDeleteContext is a wrapper around Client.PlanVariableDeleteContext using the default client
+func PlanVariableDeleteContext(ctx context.Context, organization string, wsNames []string, m VariableMatch) (*ManifestPlan, error) {
+	return defaultClient.PlanVariableDeleteContext(ctx, organization, wsNames, m)
+}
+
+// CreateVariableSet is a wrapper around Client.CreateVariableSet using the default client
+func CreateVariableSet(organization string, cfg VariableSetConfig) (VariableSet, error) {
+	return defaultClient.CreateVariableSet(organization, cfg)
+}
+
+// CreateVariableSetContext is a wrapper around Client.CreateVariableSetContext using the default client
+func CreateVariableSetContext(ctx context.Context, organization string, cfg VariableSetConfig) (VariableSet, error) {
+	return defaultClient.CreateVariableSetContext(ctx, organization, cfg)
+}
+
+// UpdateVariableSet is a wrapper around Client.UpdateVariableSet using the default client
+func UpdateVariableSet(varsetID string, cfg VariableSetConfig) (VariableSet, error) {
+	return defaultClient.UpdateVariableSet(varsetID, cfg)
+}
+
+// UpdateVariableSetContext is a wrapper around Client.UpdateVariableSetContext using the default client
+func UpdateVariableSetContext(ctx context.Context, varsetID string, cfg VariableSetConfig) (VariableSet, error) {
+	return defaultClient.UpdateVariableSetContext(ctx, varsetID, cfg)
+}
+
+// DeleteVariableSet is a wrapper around Client.DeleteVariableSet using the default client
+func DeleteVariableSet(varsetID string) error {
+	return defaultClient.DeleteVariableSet(varsetID)
+}
+
+// DeleteVariableSetContext is a wrapper around Client.DeleteVariableSetContext using the default client
+func DeleteVariableSetContext(ctx context.Context, varsetID string) error {
+	return defaultClient.DeleteVariableSetContext(ctx, varsetID)
+}
+
+// GetVariableSetByID is a wrapper around Client.GetVariableSetByID using the default client
+func GetVariableSetByID(varsetID string) (VariableSet, error) {
+	return defaultClient.GetVariableSetByID(varsetID)
+}
+
+// GetVariableSetByIDContext is a wrapper around Client.GetVariableSetByIDContext using the default client
+func GetVariableSetByIDContext(ctx context.Context, varsetID string) (VariableSet, error) {
+	return defaultClient.GetVariableSetByIDContext(ctx, varsetID)
+}
+
+// CreateVariableSetVariable is a wrapper around Client.CreateVariableSetVariable using the default client
+func CreateVariableSetVariable(varsetID string, tfVar Var) (string, error) {
+	return defaultClient.CreateVariableSetVariable(varsetID, tfVar)
+}
+
+// CreateVariableSetVariableContext is a wrapper around Client.CreateVariableSetVariableContext using the default client
+func CreateVariableSetVariableContext(ctx context.Context, varsetID string, tfVar Var) (string, error) {
+	return defaultClient.CreateVariableSetVariableContext(ctx, varsetID, tfVar)
+}
+
+// UpdateVariableSetVariable is a wrapper around Client.UpdateVariableSetVariable using the default client
+func UpdateVariableSetVariable(varsetID, variableID string, tfVar Var) error {
+	return defaultClient.UpdateVariableSetVariable(varsetID, variableID, tfVar)
+}
+
+// UpdateVariableSetVariableContext is a wrapper around Client.UpdateVariableSetVariableContext using the default client
+func UpdateVariableSetVariableContext(ctx context.Context, varsetID, variableID string, tfVar Var) error {
+	return defaultClient.UpdateVariableSetVariableContext(ctx, varsetID, variableID, tfVar)
+}
+
+// DeleteVariableSetVariable is a wrapper around Client.DeleteVariableSetVariable using the default client
+func DeleteVariableSetVariable(varsetID, variableID string) error {
+	return defaultClient.DeleteVariableSetVariable(varsetID, variableID)
+}
+
+// DeleteVariableSetVariableContext is a wrapper around Client.DeleteVariableSetVariableContext using the default client
+func DeleteVariableSetVariableContext(ctx context.Context, varsetID, variableID string) error {
+	return defaultClient.DeleteVariableSetVariableContext(ctx, varsetID, variableID)
+}
+
+// RemoveVariableSet is a wrapper around Client.RemoveVariableSet using the default client
+func RemoveVariableSet(varsetID string, workspaceIDs []string) error {
+	return defaultClient.RemoveVariableSet(varsetID, workspaceIDs)
+}
+
+// RemoveVariableSetContext is a wrapper around Client.RemoveVariableSetContext using the default client
+func RemoveVariableSetContext(ctx context.Context, varsetID string, workspaceIDs []string) error {
+	return defaultClient.RemoveVariableSetContext(ctx, varsetID, workspaceIDs)
+}
+
+// ApplyVariableSetToProjects is a wrapper around Client.ApplyVariableSetToProjects using the default client
+func ApplyVariableSetToProjects(varsetID string, projectIDs []string) error {
+	return defaultClient.ApplyVariableSetToProjects(varsetID, projectIDs)
+}
+
+// ApplyVariableSetToProjectsContext is a wrapper around Client.ApplyVariableSetToProjectsContext using the default client
+func ApplyVariableSetToProjectsContext(ctx context.Context, varsetID string, projectIDs []string) error {
+	return defaultClient.ApplyVariableSetToProjectsContext(ctx, varsetID, projectIDs)
+}
+
+// RemoveVariableSetFromProjects is a wrapper around Client.RemoveVariableSetFromProjects using the default client
+func RemoveVariableSetFromProjects(varsetID string, projectIDs []string) error {
+	return defaultClient.RemoveVariableSetFromProjects(varsetID, projectIDs)
+}
+
+// RemoveVariableSetFromProjectsContext is a wrapper around Client.RemoveVariableSetFromProjectsContext using the default client
+func RemoveVariableSetFromProjectsContext(ctx context.Context, varsetID string, projectIDs []string) error {
+	return defaultClient.RemoveVariableSetFromProjectsContext(ctx, varsetID, projectIDs)
+}
+
+// GetProjectID is a wrapper around Client.GetProjectID using the default client
+func GetProjectID(organization, name string) (string, error) {
+	return defaultClient.GetProjectID(organization, name)
+}
+
+// GetProjectIDContext is a wrapper around Client.GetProjectIDContext using the default client
+func GetProjectIDContext(ctx context.Context, organization, name string) (string, error) {
+	return defaultClient.GetProjectIDContext(ctx, organization, name)
+}
+
+// GetVariableSetReport is a wrapper around Client.GetVariableSetReport using the default client
+func GetVariableSetReport(organization string) (VariableSetReport, error) {
+	return defaultClient.GetVariableSetReport(organization)
+}
+
+// GetVariableSetReportContext is a wrapper around Client.GetVariableSetReportContext using the default client
+func GetVariableSetReportContext(ctx context.Context, organization string) (VariableSetReport, error) {
+	return defaultClient.GetVariableSetReportContext(ctx, organization)
+}
+
+// PlanVariableSync is a wrapper around Client.PlanVariableSync using the default client
+func PlanVariableSync(sourceOrg, source, organization string, targets []string, cfg VariableSyncConfig) (*ManifestPlan, []string, error) {
+	return defaultClient.PlanVariableSync(sourceOrg, source, organization, targets, cfg)
+}
+
+// PlanVariableSyncContext is a wrapper around Client.PlanVariableSyncContext using the default client
+func PlanVariableSyncContext(ctx context.Context, sourceOrg, source, organization string, targets []string, cfg VariableSyncConfig) (*ManifestPlan, []string, error) {
+	return defaultClient.PlanVariableSyncContext(ctx, sourceOrg, source, organization, targets, cfg)
+}
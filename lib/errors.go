@@ -0,0 +1,99 @@
+package lib
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Errors that can be matched with errors.Is to check the status of a failed API call
+var (
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
+	ErrNotFound     = errors.New("not found")
+	ErrRateLimited  = errors.New("rate limited")
+)
+
+// APIError is returned when the API responds with an error status
+type APIError struct {
+	Method     string
+	URL        string
+	StatusCode int
+	Status     string
+	Errors     []APIErrorObject // the JSON:API `errors` array, if the response had one
+	Body       []byte           // the undecoded response body
+}
+
+// APIErrorObject is one entry in the JSON:API `errors` array
+type APIErrorObject struct {
+	Status string `json:"status"`
+	Title  string `json:"title"`
+	Detail string `json:"detail"`
+	Source struct {
+		Pointer string `json:"pointer"`
+	} `json:"source"`
+}
+
+func newAPIError(method, url string, resp *http.Response, body []byte) *APIError {
+	apiErr := &APIError{
+		Method:     method,
+		URL:        url,
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+		Body:       body,
+	}
+
+	var errorResponse struct {
+		Errors []APIErrorObject `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &errorResponse); err == nil {
+		apiErr.Errors = errorResponse.Errors
+	}
+	return apiErr
+}
+
+func (e *APIError) Error() string {
+	var details string
+	for _, o := range e.Errors {
+		details += "\n\t" + strings.TrimSpace(strings.Join([]string{o.Title, o.Detail, o.Source.Pointer}, " "))
+	}
+	if len(e.Errors) == 0 && len(e.Body) > 0 {
+		details = fmt.Sprintf("\n\tResponse Body: %s", e.Body)
+	}
+	return fmt.Sprintf("API returned an error.\n\tMethod: %s\n\tURL: %s\n\tCode: %v\n\tStatus: %s%s",
+		e.Method, e.URL, e.StatusCode, e.Status, details)
+}
+
+// Is reports whether the error matches one of the sentinel errors, based on its status code
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	}
+	return false
+}
+
+// IncompleteError is returned by a function that operates on a list of workspaces when it stops before all of them
+// have been processed, e.g. because its context was canceled.
+type IncompleteError struct {
+	Processed []string // names of the workspaces that were completed
+	Remaining []string // names of the workspaces that were not completed
+	Err       error
+}
+
+func (e *IncompleteError) Error() string {
+	return fmt.Sprintf("stopped after %d of %d workspaces: %s",
+		len(e.Processed), len(e.Processed)+len(e.Remaining), e.Err)
+}
+
+func (e *IncompleteError) Unwrap() error {
+	return e.Err
+}
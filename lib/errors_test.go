@@ -0,0 +1,50 @@
+package lib
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIError(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/organizations/missing":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errors":[{"status":"404","title":"not found"}]}`))
+		case "/api/v2/vars":
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			_, _ = w.Write([]byte(`{"errors":[{"status":"422","title":"invalid attribute",` +
+				`"detail":"Key has already been taken","source":{"pointer":"/data/attributes/key"}}]}`))
+		default:
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`unauthorized`))
+		}
+	}))
+	defer server.Close()
+	c := newTestClient(server, "token")
+	ctx := context.Background()
+
+	exists, err := c.OrganizationExistsContext(ctx, "missing")
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	err = c.CreateVariableContext(ctx, "org", "ws", Var{Key: "key"})
+	var apiErr *APIError
+	require.True(t, errors.As(err, &apiErr))
+	require.Equal(t, http.MethodPost, apiErr.Method)
+	require.Equal(t, http.StatusUnprocessableEntity, apiErr.StatusCode)
+	require.Len(t, apiErr.Errors, 1)
+	require.Equal(t, "Key has already been taken", apiErr.Errors[0].Detail)
+	require.Equal(t, "/data/attributes/key", apiErr.Errors[0].Source.Pointer)
+	require.Contains(t, err.Error(), "invalid attribute Key has already been taken /data/attributes/key")
+	require.False(t, errors.Is(err, ErrNotFound))
+
+	_, err = c.GetWorkspaceDataContext(ctx, "org", "ws")
+	require.ErrorIs(t, err, ErrUnauthorized)
+	require.Contains(t, err.Error(), "Response Body: unauthorized")
+}
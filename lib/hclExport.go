@@ -0,0 +1,343 @@
+package lib
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ExportHCL writes Terraform configuration for the `tfe` provider describing the workspaces, variables, variable
+// sets, team access and run triggers of an organization. Each resource has an `import` block (Terraform 1.5 or
+// later) so that the existing objects are brought under Terraform management rather than created again. The value
+// of a sensitive variable can't be read, so it is replaced by a reference to an input variable, which is declared
+// at the end of the configuration.
+func (c *Client) ExportHCL(organization string, w io.Writer) error {
+	return c.ExportHCLContext(context.Background(), organization, w)
+}
+
+// ExportHCLContext is like ExportHCL but uses ctx for its API calls
+func (c *Client) ExportHCLContext(ctx context.Context, organization string, w io.Writer) error {
+	workspaces, err := c.GetAllWorkspacesContext(ctx, organization)
+	if err != nil {
+		return err
+	}
+	sort.Slice(workspaces, func(i, j int) bool { return workspaces[i].Attributes.Name < workspaces[j].Attributes.Name })
+
+	teams, err := c.ListTeamsContext(ctx, organization)
+	if err != nil {
+		return err
+	}
+
+	varsets, err := c.GetAllVariableSetsContext(ctx, organization)
+	if err != nil {
+		return fmt.Errorf("error getting variable sets: %w", err)
+	}
+	sort.Slice(varsets.Data, func(i, j int) bool { return varsets.Data[i].Attributes.Name < varsets.Data[j].Attributes.Name })
+
+	e := hclExporter{
+		organization: organization,
+		labels:       map[string]bool{},
+		wsLabels:     map[string]string{},
+		wsNames:      map[string]string{},
+		teamNames:    map[string]string{},
+	}
+	for _, team := range teams {
+		e.teamNames[team.ID] = team.Name
+	}
+	for _, ws := range workspaces {
+		e.wsLabels[ws.ID] = e.label(ws.Attributes.Name)
+		e.wsNames[ws.ID] = ws.Attributes.Name
+	}
+
+	index := make(map[string]int, len(workspaces))
+	for i, ws := range workspaces {
+		index[ws.Attributes.Name] = i
+	}
+	details := make([]workspaceDetails, len(workspaces))
+	err = c.ForEachWorkspace(ctx, workspaceNames(workspaces), nil, func(ctx context.Context, name string, _ io.Writer) error {
+		i := index[name]
+		d, err := c.getWorkspaceDetails(ctx, organization, workspaces[i])
+		if err != nil {
+			return fmt.Errorf("workspace %s: %w", name, err)
+		}
+		details[i] = d
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	varsetVars := make([][]Var, len(varsets.Data))
+	for i, set := range varsets.Data {
+		if varsetVars[i], err = c.ListVariableSetVariablesContext(ctx, set.ID); err != nil {
+			return fmt.Errorf("variable set %s: %w", set.Attributes.Name, err)
+		}
+	}
+
+	for i, ws := range workspaces {
+		e.workspace(ws, details[i])
+	}
+	for i, set := range varsets.Data {
+		e.variableSet(set, varsetVars[i])
+	}
+	e.inputVariables()
+
+	_, err = io.WriteString(w, strings.TrimSuffix(e.String(), "\n"))
+	return err
+}
+
+// workspaceDetails is the configuration of a workspace that is not in the Workspace struct
+type workspaceDetails struct {
+	vars        []Var
+	teamAccess  []TeamWorkspaceData
+	runTriggers []RunTrigger
+}
+
+func (c *Client) getWorkspaceDetails(ctx context.Context, organization string, ws Workspace) (workspaceDetails, error) {
+	var d workspaceDetails
+	var err error
+	if d.vars, err = c.GetVarsFromWorkspaceContext(ctx, organization, ws.Attributes.Name); err != nil {
+		return d, err
+	}
+	teams, err := c.GetTeamAccessFromContext(ctx, ws.ID)
+	if err != nil {
+		return d, err
+	}
+	d.teamAccess = teams.Data
+	if d.runTriggers, err = c.ListRunTriggersContext(ctx, ListRunTriggerConfig{WorkspaceID: ws.ID, Type: "inbound"}); err != nil {
+		return d, err
+	}
+	return d, nil
+}
+
+// hclExporter builds the configuration written by ExportHCL
+type hclExporter struct {
+	strings.Builder
+	organization string
+	labels       map[string]bool   // labels already used, to keep them unique
+	wsLabels     map[string]string // by workspace ID
+	wsNames      map[string]string // by workspace ID
+	teamNames    map[string]string // by team ID
+	inputs       []string          // names of input variables for sensitive values
+}
+
+var nonLabelChars = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// label returns a unique resource label made from a name
+func (e *hclExporter) label(name string) string {
+	base := strings.Trim(nonLabelChars.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if base == "" || (base[0] >= '0' && base[0] <= '9') {
+		base = "_" + base
+	}
+	label := base
+	for n := 2; e.labels[label]; n++ {
+		label = base + "_" + strconv.Itoa(n)
+	}
+	e.labels[label] = true
+	return label
+}
+
+func (e *hclExporter) workspace(ws Workspace, d workspaceDetails) {
+	label := e.wsLabels[ws.ID]
+	a := ws.Attributes
+	b := hclBlock{
+		header: fmt.Sprintf(`resource "tfe_workspace" %q`, label),
+		attrs: []hclAttr{
+			{"name", hclString(a.Name)},
+			{"organization", hclString(e.organization)},
+			{"auto_apply", strconv.FormatBool(a.AutoApply)},
+			{"terraform_version", hclString(a.TerraformVersion)},
+		},
+	}
+	if a.WorkingDirectory != "" {
+		b.attrs = append(b.attrs, hclAttr{"working_directory", hclString(a.WorkingDirectory)})
+	}
+	if a.VCSRepo.Identifier != "" {
+		vcs := hclBlock{header: "vcs_repo", attrs: []hclAttr{{"identifier", hclString(a.VCSRepo.Identifier)}}}
+		if a.VCSRepo.Branch != "" {
+			vcs.attrs = append(vcs.attrs, hclAttr{"branch", hclString(a.VCSRepo.Branch)})
+		}
+		vcs.attrs = append(vcs.attrs, hclAttr{"oauth_token_id", hclString(a.VCSRepo.TokenID)})
+		b.blocks = append(b.blocks, vcs)
+	}
+	e.resource(b, "tfe_workspace."+label, ws.ID)
+
+	for _, v := range d.vars {
+		e.variable(v, label, hclAttr{"workspace_id", "tfe_workspace." + label + ".id"},
+			fmt.Sprintf("%s/%s/%s", e.organization, a.Name, v.ID))
+	}
+
+	for _, t := range d.teamAccess {
+		teamID := t.Relationships.Team.Data.ID
+		teamName := e.teamNames[teamID]
+		if teamName == "" {
+			teamName = teamID
+		}
+		accessLabel := e.label(label + "_" + teamName)
+		e.resource(hclBlock{
+			comment: "team " + teamName,
+			header:  fmt.Sprintf(`resource "tfe_team_access" %q`, accessLabel),
+			attrs: []hclAttr{
+				{"access", hclString(t.Attributes.Access)},
+				{"team_id", hclString(teamID)},
+				{"workspace_id", "tfe_workspace." + label + ".id"},
+			},
+		}, "tfe_team_access."+accessLabel, fmt.Sprintf("%s/%s/%s", e.organization, a.Name, t.ID))
+	}
+
+	for _, rt := range d.runTriggers {
+		source := hclString(rt.SourceID)
+		if sourceLabel, ok := e.wsLabels[rt.SourceID]; ok {
+			source = "tfe_workspace." + sourceLabel + ".id"
+		}
+		triggerLabel := e.label(label + "_from_" + rt.SourceName)
+		e.resource(hclBlock{
+			header: fmt.Sprintf(`resource "tfe_run_trigger" %q`, triggerLabel),
+			attrs: []hclAttr{
+				{"workspace_id", "tfe_workspace." + label + ".id"},
+				{"sourceable_id", source},
+			},
+		}, "tfe_run_trigger."+triggerLabel, rt.ID)
+	}
+}
+
+func (e *hclExporter) variableSet(set VariableSet, vars []Var) {
+	label := e.label(set.Attributes.Name)
+	e.resource(hclBlock{
+		header: fmt.Sprintf(`resource "tfe_variable_set" %q`, label),
+		attrs: []hclAttr{
+			{"name", hclString(set.Attributes.Name)},
+			{"description", hclString(set.Attributes.Description)},
+			{"global", strconv.FormatBool(set.Attributes.Global)},
+			{"organization", hclString(e.organization)},
+		},
+	}, "tfe_variable_set."+label, set.ID)
+
+	for _, v := range vars {
+		e.variable(v, label, hclAttr{"variable_set_id", "tfe_variable_set." + label + ".id"}, set.ID+"/"+v.ID)
+	}
+
+	for _, ws := range set.Relationships.Workspaces.Data {
+		wsLabel, ok := e.wsLabels[ws.ID]
+		if !ok {
+			continue
+		}
+		applyLabel := e.label(label + "_" + wsLabel)
+		e.resource(hclBlock{
+			header: fmt.Sprintf(`resource "tfe_workspace_variable_set" %q`, applyLabel),
+			attrs: []hclAttr{
+				{"variable_set_id", "tfe_variable_set." + label + ".id"},
+				{"workspace_id", "tfe_workspace." + wsLabel + ".id"},
+			},
+		}, "tfe_workspace_variable_set."+applyLabel,
+			fmt.Sprintf("%s/%s/%s", e.organization, e.wsNames[ws.ID], set.Attributes.Name))
+	}
+}
+
+// variable writes a tfe_variable resource for a variable in a workspace or variable set
+func (e *hclExporter) variable(v Var, ownerLabel string, owner hclAttr, importID string) {
+	label := e.label(ownerLabel + "_" + v.Key)
+	value := hclString(v.Value)
+	if v.Sensitive {
+		e.inputs = append(e.inputs, label)
+		value = "var." + label
+	}
+	category := v.Category
+	if category == "" {
+		category = "terraform"
+	}
+	e.resource(hclBlock{
+		header: fmt.Sprintf(`resource "tfe_variable" %q`, label),
+		attrs: []hclAttr{
+			{"key", hclString(v.Key)},
+			{"value", value},
+			{"category", hclString(category)},
+			{"hcl", strconv.FormatBool(v.Hcl)},
+			{"sensitive", strconv.FormatBool(v.Sensitive)},
+			owner,
+		},
+	}, "tfe_variable."+label, importID)
+}
+
+// inputVariables declares the input variables that hold sensitive values
+func (e *hclExporter) inputVariables() {
+	for _, name := range e.inputs {
+		hclBlock{
+			header: fmt.Sprintf(`variable %q`, name),
+			attrs: []hclAttr{
+				{"type", "string"},
+				{"sensitive", "true"},
+			},
+		}.write(&e.Builder, "")
+		e.WriteString("\n")
+	}
+}
+
+// resource writes a resource block and an import block for it
+func (e *hclExporter) resource(b hclBlock, address, importID string) {
+	b.write(&e.Builder, "")
+	e.WriteString("\n")
+	hclBlock{
+		header: "import",
+		attrs: []hclAttr{
+			{"to", address},
+			{"id", hclString(importID)},
+		},
+	}.write(&e.Builder, "")
+	e.WriteString("\n")
+}
+
+// hclBlock is an HCL block with its attributes and nested blocks
+type hclBlock struct {
+	comment string
+	header  string
+	attrs   []hclAttr
+	blocks  []hclBlock
+}
+
+// hclAttr is an attribute name and its value, as an HCL expression
+type hclAttr struct {
+	name  string
+	value string
+}
+
+// write writes the block in the layout used by `terraform fmt`
+func (b hclBlock) write(sb *strings.Builder, indent string) {
+	if b.comment != "" {
+		sb.WriteString(indent + "# " + b.comment + "\n")
+	}
+	sb.WriteString(indent + b.header + " {\n")
+	width := 0
+	for _, a := range b.attrs {
+		if len(a.name) > width {
+			width = len(a.name)
+		}
+	}
+	for _, a := range b.attrs {
+		fmt.Fprintf(sb, "%s  %-*s = %s\n", indent, width, a.name, a.value)
+	}
+	for _, nested := range b.blocks {
+		sb.WriteString("\n")
+		nested.write(sb, indent+"  ")
+	}
+	sb.WriteString(indent + "}\n")
+}
+
+var hclStringReplacer = strings.NewReplacer(
+	`\`, `\\`,
+	`"`, `\"`,
+	"\n", `\n`,
+	"\r", `\r`,
+	"\t", `\t`,
+	"${", "$${",
+	"%{", "%%{",
+)
+
+// hclString returns s as a quoted HCL string, with template sequences escaped so that it is used literally
+func hclString(s string) string {
+	return `"` + hclStringReplacer.Replace(s) + `"`
+}
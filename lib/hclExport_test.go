@@ -0,0 +1,179 @@
+package lib
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_hclString(t *testing.T) {
+	require.Equal(t, `"plain"`, hclString("plain"))
+	require.Equal(t, `"say \"hi\"\n$${name} %%{if} C:\\dir"`, hclString("say \"hi\"\n${name} %{if} C:\\dir"))
+}
+
+func Test_hclExporter_label(t *testing.T) {
+	e := hclExporter{labels: map[string]bool{}}
+	require.Equal(t, "my_app_prod", e.label("My-App.prod"))
+	require.Equal(t, "my_app_prod_2", e.label("my app prod"))
+	require.Equal(t, "_1password", e.label("1password"))
+}
+
+func TestClient_ExportHCL(t *testing.T) {
+	responses := map[string]string{
+		"/organizations/org": `{"data":{"id":"org"}}`,
+		"/organizations/org/workspaces": `{"data":[
+			{"id":"ws-2","attributes":{"name":"network","terraform-version":"1.5.7"}},
+			{"id":"ws-1","attributes":{"name":"app","auto-apply":true,"terraform-version":"1.5.7",
+				"vcs-repo":{"identifier":"org/app","branch":"main","oauth-token-id":"ot-1"}}}
+		]}`,
+		"/organizations/org/teams": `{"data":[{"id":"team-1","attributes":{"name":"developers"}}]}`,
+		"/organizations/org/varsets": `{"data":[{"id":"varset-1","attributes":{"name":"common","global":false},
+			"relationships":{"workspaces":{"data":[{"id":"ws-1","type":"workspaces"}]}}}]}`,
+		"/vars":                                `{"data":[{"id":"var-1","attributes":{"key":"password","value":"","sensitive":true,"category":"terraform"}}]}`,
+		"/team-workspaces":                     `{"data":[]}`,
+		"/workspaces/ws-1/run-triggers":        `{"data":[]}`,
+		"/workspaces/ws-2/run-triggers":        `{"data":[]}`,
+		"/varsets/varset-1/relationships/vars": `{"data":[{"id":"var-2","attributes":{"key":"tags","value":"{a=\"b\"}","hcl":true,"category":"terraform"}}]}`,
+	}
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == apiPath+"/team-workspaces" && r.URL.Query().Get(paramFilterWorkspaceID) == "ws-1" {
+			_, _ = w.Write([]byte(`{"data":[{"id":"tws-1","attributes":{"access":"write"},
+				"relationships":{"team":{"data":{"id":"team-1"}}}}]}`))
+			return
+		}
+		if r.URL.Path == apiPath+"/workspaces/ws-1/run-triggers" {
+			_, _ = w.Write([]byte(`{"data":[{"id":"rt-1","attributes":{"sourceable-name":"network",
+				"workspace-name":"app","created-at":"2023-06-20T08:56:50.996Z"},
+				"relationships":{"workspace":{"data":{"id":"ws-1"}},"sourceable":{"data":{"id":"ws-2"}}}}]}`))
+			return
+		}
+		if r.URL.Path == apiPath+"/vars" && r.URL.Query().Get(paramFilterWorkspaceName) == "network" {
+			_, _ = w.Write([]byte(`{"data":[]}`))
+			return
+		}
+		body, ok := responses[r.URL.Path[len(apiPath):]]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	var out bytes.Buffer
+	c := newTestClient(server, "token")
+	require.NoError(t, c.ExportHCLContext(context.Background(), "org", &out))
+	require.Equal(t, wantHCLExport, out.String())
+}
+
+const wantHCLExport = `resource "tfe_workspace" "app" {
+  name              = "app"
+  organization      = "org"
+  auto_apply        = true
+  terraform_version = "1.5.7"
+
+  vcs_repo {
+    identifier     = "org/app"
+    branch         = "main"
+    oauth_token_id = "ot-1"
+  }
+}
+
+import {
+  to = tfe_workspace.app
+  id = "ws-1"
+}
+
+resource "tfe_variable" "app_password" {
+  key          = "password"
+  value        = var.app_password
+  category     = "terraform"
+  hcl          = false
+  sensitive    = true
+  workspace_id = tfe_workspace.app.id
+}
+
+import {
+  to = tfe_variable.app_password
+  id = "org/app/var-1"
+}
+
+# team developers
+resource "tfe_team_access" "app_developers" {
+  access       = "write"
+  team_id      = "team-1"
+  workspace_id = tfe_workspace.app.id
+}
+
+import {
+  to = tfe_team_access.app_developers
+  id = "org/app/tws-1"
+}
+
+resource "tfe_run_trigger" "app_from_network" {
+  workspace_id  = tfe_workspace.app.id
+  sourceable_id = tfe_workspace.network.id
+}
+
+import {
+  to = tfe_run_trigger.app_from_network
+  id = "rt-1"
+}
+
+resource "tfe_workspace" "network" {
+  name              = "network"
+  organization      = "org"
+  auto_apply        = false
+  terraform_version = "1.5.7"
+}
+
+import {
+  to = tfe_workspace.network
+  id = "ws-2"
+}
+
+resource "tfe_variable_set" "common" {
+  name         = "common"
+  description  = ""
+  global       = false
+  organization = "org"
+}
+
+import {
+  to = tfe_variable_set.common
+  id = "varset-1"
+}
+
+resource "tfe_variable" "common_tags" {
+  key             = "tags"
+  value           = "{a=\"b\"}"
+  category        = "terraform"
+  hcl             = true
+  sensitive       = false
+  variable_set_id = tfe_variable_set.common.id
+}
+
+import {
+  to = tfe_variable.common_tags
+  id = "varset-1/var-2"
+}
+
+resource "tfe_workspace_variable_set" "common_app" {
+  variable_set_id = tfe_variable_set.common.id
+  workspace_id    = tfe_workspace.app.id
+}
+
+import {
+  to = tfe_workspace_variable_set.common_app
+  id = "org/app/common"
+}
+
+variable "app_password" {
+  type      = string
+  sensitive = true
+}
+`
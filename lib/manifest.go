@@ -0,0 +1,679 @@
+package lib
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Jeffail/gabs/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// Manifest describes the desired configuration of a list of workspaces in one organization. It can be read from
+// YAML or JSON.
+type Manifest struct {
+	Workspaces []WorkspaceManifest `yaml:"workspaces"`
+}
+
+// WorkspaceManifest describes the desired configuration of one workspace. Anything in the workspace that is not
+// mentioned in the manifest is left as it is.
+type WorkspaceManifest struct {
+	Name string `yaml:"name"`
+
+	// Attributes uses the names from the Terraform Cloud API, e.g. "terraform-version" or "vcs-repo". The value of
+	// a nested attribute is a map, and only the keys in the map are compared with the workspace.
+	Attributes map[string]any `yaml:"attributes"`
+
+	Variables    []VariableManifest `yaml:"variables"`
+	VariableSets []string           `yaml:"variable-sets"`
+	TeamAccess   []TeamAccessConfig `yaml:"team-access"`
+
+	// RemoteStateConsumers lists the names of workspaces allowed to read this workspace's state
+	RemoteStateConsumers []string `yaml:"remote-state-consumers"`
+
+	// RunTriggers lists the names of workspaces whose runs start a run in this workspace
+	RunTriggers []string `yaml:"run-triggers"`
+}
+
+// VariableManifest is a Terraform variable in a WorkspaceManifest
+type VariableManifest struct {
+	Key       string `yaml:"key"`
+	Value     string `yaml:"value"`
+	Sensitive bool   `yaml:"sensitive"`
+	HCL       bool   `yaml:"hcl"`
+}
+
+// TeamAccessConfig is the access level of one team, given by name, in a WorkspaceManifest
+type TeamAccessConfig struct {
+	Team   string `yaml:"team"`
+	Access string `yaml:"access"`
+}
+
+// ReadManifest reads a manifest in YAML or JSON format and checks it for errors
+func ReadManifest(r io.Reader) (Manifest, error) {
+	var m Manifest
+	dec := yaml.NewDecoder(r)
+	dec.KnownFields(true)
+	if err := dec.Decode(&m); err != nil && !errors.Is(err, io.EOF) {
+		return Manifest{}, fmt.Errorf("invalid manifest: %w", err)
+	}
+	if err := m.validate(); err != nil {
+		return Manifest{}, fmt.Errorf("invalid manifest: %w", err)
+	}
+	return m, nil
+}
+
+func (m Manifest) validate() error {
+	if len(m.Workspaces) == 0 {
+		return errors.New("no workspaces listed")
+	}
+	names := map[string]bool{}
+	for i, ws := range m.Workspaces {
+		if ws.Name == "" {
+			return fmt.Errorf("workspace %d has no name", i+1)
+		}
+		if names[ws.Name] {
+			return fmt.Errorf("workspace %s is listed more than once", ws.Name)
+		}
+		names[ws.Name] = true
+		if _, ok := ws.Attributes["name"]; ok {
+			return fmt.Errorf("workspace %s: set the name outside of attributes", ws.Name)
+		}
+		for _, v := range ws.Variables {
+			if v.Key == "" {
+				return fmt.Errorf("workspace %s has a variable with no key", ws.Name)
+			}
+		}
+		for _, t := range ws.TeamAccess {
+			if t.Team == "" || t.Access == "" {
+				return fmt.Errorf("workspace %s: team access needs both a team and an access level", ws.Name)
+			}
+		}
+	}
+	return nil
+}
+
+const (
+	ManifestActionCreate = "create"
+	ManifestActionUpdate = "update"
+	ManifestActionDelete = "delete" // only used by a variable sync, a manifest never deletes anything
+)
+
+const (
+	ManifestResourceWorkspace           = "workspace"
+	ManifestResourceVariable            = "variable"
+	ManifestResourceVariableSet         = "variable-set"
+	ManifestResourceTeamAccess          = "team-access"
+	ManifestResourceRemoteStateConsumer = "remote-state-consumer"
+	ManifestResourceRunTrigger          = "run-trigger"
+)
+
+// ManifestChange is one change needed to make a workspace match its manifest
+type ManifestChange struct {
+	Workspace string `json:"workspace" yaml:"workspace"`
+	Action    string `json:"action" yaml:"action"`
+	Resource  string `json:"resource" yaml:"resource"`
+	Name      string `json:"name" yaml:"name"`
+	Detail    string `json:"detail" yaml:"detail"`
+
+	apply func(ctx context.Context, c *Client, ids *workspaceIDs) error
+}
+
+// ManifestPlan is the list of changes needed to make an organization's workspaces match a manifest, in the order
+// they will be applied. All workspaces are created and updated before any other changes are made, so that the
+// workspaces can refer to each other. A ManifestPlan is also used for the changes made by a variable sync.
+type ManifestPlan struct {
+	Organization string
+	Changes      []ManifestChange
+
+	ids *workspaceIDs
+}
+
+// workspaceIDs maps workspace names to IDs, including workspaces created while applying a plan
+type workspaceIDs struct {
+	sync.Mutex
+	ids map[string]string
+}
+
+func (w *workspaceIDs) get(name string) (string, error) {
+	w.Lock()
+	defer w.Unlock()
+	id, ok := w.ids[name]
+	if !ok {
+		return "", fmt.Errorf("workspace %s has not been created", name)
+	}
+	return id, nil
+}
+
+func (w *workspaceIDs) set(name, id string) {
+	w.Lock()
+	defer w.Unlock()
+	w.ids[name] = id
+}
+
+// PlanManifest compares the manifest with the workspaces in the organization, and returns the changes needed to
+// make them match. Nothing is changed.
+func (c *Client) PlanManifest(organization string, m Manifest) (*ManifestPlan, error) {
+	return c.PlanManifestContext(context.Background(), organization, m)
+}
+
+// PlanManifestContext is like PlanManifest but uses ctx for its API calls
+func (c *Client) PlanManifestContext(ctx context.Context, organization string, m Manifest) (*ManifestPlan, error) {
+	p := planner{
+		client:       c,
+		organization: organization,
+		inManifest:   map[string]bool{},
+		existing:     map[string]*gabs.Container{},
+		ids:          &workspaceIDs{ids: map[string]string{}},
+	}
+	if err := p.lookUpWorkspaces(ctx, m); err != nil {
+		return nil, err
+	}
+	if err := p.lookUpVariableSetsAndTeams(ctx, m); err != nil {
+		return nil, err
+	}
+
+	changes := make([][]ManifestChange, len(m.Workspaces))
+	index := map[string]int{}
+	names := make([]string, len(m.Workspaces))
+	for i, ws := range m.Workspaces {
+		names[i] = ws.Name
+		index[ws.Name] = i
+	}
+	err := c.ForEachWorkspace(ctx, names, nil, func(ctx context.Context, name string, w io.Writer) error {
+		i := index[name]
+		wsChanges, err := p.planWorkspace(ctx, m.Workspaces[i])
+		if err != nil {
+			return fmt.Errorf("workspace %s: %w", name, err)
+		}
+		changes[i] = wsChanges
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &ManifestPlan{Organization: organization, ids: p.ids}
+	for _, wsChanges := range changes {
+		for _, change := range wsChanges {
+			if change.Resource == ManifestResourceWorkspace {
+				plan.Changes = append(plan.Changes, change)
+			}
+		}
+	}
+	for _, wsChanges := range changes {
+		for _, change := range wsChanges {
+			if change.Resource != ManifestResourceWorkspace {
+				plan.Changes = append(plan.Changes, change)
+			}
+		}
+	}
+	return plan, nil
+}
+
+// ApplyManifestPlan makes the changes in the plan, one at a time, writing a line to out for each. It stops at the
+// first failure.
+func (c *Client) ApplyManifestPlan(plan *ManifestPlan, out io.Writer) error {
+	return c.ApplyManifestPlanContext(context.Background(), plan, out)
+}
+
+// ApplyManifestPlanContext is like ApplyManifestPlan but uses ctx for its API calls
+func (c *Client) ApplyManifestPlanContext(ctx context.Context, plan *ManifestPlan, out io.Writer) error {
+	for i, change := range plan.Changes {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("stopped after %d of %d changes: %w", i, len(plan.Changes), err)
+		}
+		fmt.Fprintf(out, "Workspace %s: %s %s %s\n", change.Workspace, change.Action, change.Resource, change.Name)
+		if c.readOnly {
+			continue
+		}
+		if err := change.apply(ctx, c, plan.ids); err != nil {
+			return fmt.Errorf("stopped after %d of %d changes: failed to %s %s %s in workspace %s: %w",
+				i, len(plan.Changes), change.Action, change.Resource, change.Name, change.Workspace, err)
+		}
+	}
+	return nil
+}
+
+// planner holds what is known about the organization while making a ManifestPlan
+type planner struct {
+	client       *Client
+	organization string
+	inManifest   map[string]bool
+	existing     map[string]*gabs.Container // data of existing workspaces, by name
+	ids          *workspaceIDs
+	varsetIDs    map[string]string // by name
+	teamIDs      map[string]string // by name
+}
+
+// lookUpWorkspaces gets the data of the workspaces in the manifest and those they refer to
+func (p *planner) lookUpWorkspaces(ctx context.Context, m Manifest) error {
+	referenced := map[string]bool{}
+	for _, ws := range m.Workspaces {
+		p.inManifest[ws.Name] = true
+		referenced[ws.Name] = true
+		for _, name := range ws.RemoteStateConsumers {
+			referenced[name] = true
+		}
+		for _, name := range ws.RunTriggers {
+			referenced[name] = true
+		}
+	}
+	names := make([]string, 0, len(referenced))
+	for name := range referenced {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var mu sync.Mutex
+	err := p.client.ForEachWorkspace(ctx, names, nil, func(ctx context.Context, name string, w io.Writer) error {
+		data, err := p.client.getWorkspaceJSON(ctx, p.organization, name)
+		if errors.Is(err, ErrNotFound) {
+			if !p.inManifest[name] {
+				return fmt.Errorf("workspace %s is not in the manifest or the organization", name)
+			}
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("error getting workspace %s: %w", name, err)
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		p.existing[name] = data
+		p.ids.set(name, fmt.Sprint(data.S("id").Data()))
+		return nil
+	})
+	return err
+}
+
+// lookUpVariableSetsAndTeams gets the IDs of the variable sets and teams named in the manifest
+func (p *planner) lookUpVariableSetsAndTeams(ctx context.Context, m Manifest) error {
+	var needVarsets, needTeams bool
+	for _, ws := range m.Workspaces {
+		needVarsets = needVarsets || len(ws.VariableSets) > 0
+		needTeams = needTeams || len(ws.TeamAccess) > 0
+	}
+
+	p.varsetIDs = map[string]string{}
+	if needVarsets {
+		sets, err := p.client.GetAllVariableSetsContext(ctx, p.organization)
+		if err != nil {
+			return fmt.Errorf("error getting variable sets: %w", err)
+		}
+		for _, set := range sets.Data {
+			p.varsetIDs[set.Attributes.Name] = set.ID
+		}
+	}
+
+	p.teamIDs = map[string]string{}
+	if needTeams {
+		teams, err := p.client.ListTeamsContext(ctx, p.organization)
+		if err != nil {
+			return err
+		}
+		for _, team := range teams {
+			p.teamIDs[team.Name] = team.ID
+		}
+	}
+
+	for _, ws := range m.Workspaces {
+		for _, name := range ws.VariableSets {
+			if _, ok := p.varsetIDs[name]; !ok {
+				return fmt.Errorf("workspace %s: variable set %s not found", ws.Name, name)
+			}
+		}
+		for _, t := range ws.TeamAccess {
+			if _, ok := p.teamIDs[t.Team]; !ok {
+				return fmt.Errorf("workspace %s: team %s not found", ws.Name, t.Team)
+			}
+		}
+	}
+	return nil
+}
+
+// planWorkspace returns the changes needed to make one workspace match its manifest
+func (p *planner) planWorkspace(ctx context.Context, ws WorkspaceManifest) ([]ManifestChange, error) {
+	data, exists := p.existing[ws.Name]
+
+	var changes []ManifestChange
+	add := func(action, resource, name, detail string, apply func(ctx context.Context, c *Client, ids *workspaceIDs) error) {
+		changes = append(changes, ManifestChange{
+			Workspace: ws.Name,
+			Action:    action,
+			Resource:  resource,
+			Name:      name,
+			Detail:    detail,
+			apply:     apply,
+		})
+	}
+
+	if !exists {
+		add(ManifestActionCreate, ManifestResourceWorkspace, ws.Name, describeAttributes(ws.Attributes), p.createWorkspace(ws))
+	} else if diff := diffAttributes(ws.Attributes, data.S("attributes").Data()); len(diff) > 0 {
+		keys := make([]string, 0, len(diff))
+		for k := range diff {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		details := make([]string, len(keys))
+		for i, k := range keys {
+			details[i] = fmt.Sprintf("%s: %s -> %s", k, attributeString(data.S("attributes", k).Data()), attributeString(diff[k]))
+		}
+		add(ManifestActionUpdate, ManifestResourceWorkspace, ws.Name, strings.Join(details, ", "),
+			func(ctx context.Context, c *Client, ids *workspaceIDs) error {
+				id, err := ids.get(ws.Name)
+				if err != nil {
+					return err
+				}
+				return c.UpdateWorkspaceAttributesContext(ctx, id, diff)
+			},
+		)
+	}
+
+	current, err := p.currentState(ctx, ws, exists)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, v := range ws.Variables {
+		tfVar := Var{Key: v.Key, Value: v.Value, Sensitive: v.Sensitive, Hcl: v.HCL}
+		old, found := current.variables[v.Key]
+		if !found {
+			add(ManifestActionCreate, ManifestResourceVariable, v.Key, variableDetail(tfVar),
+				func(ctx context.Context, c *Client, ids *workspaceIDs) error {
+					return c.CreateVariableContext(ctx, p.organization, ws.Name, tfVar)
+				},
+			)
+			continue
+		}
+		if detail := variableChange(old, tfVar); detail != "" {
+			add(ManifestActionUpdate, ManifestResourceVariable, v.Key, detail,
+				func(ctx context.Context, c *Client, ids *workspaceIDs) error {
+					return c.UpdateVariableContext(ctx, p.organization, ws.Name, old.ID, tfVar)
+				},
+			)
+		}
+	}
+
+	for _, name := range ws.VariableSets {
+		varsetID := p.varsetIDs[name]
+		if current.varsets[varsetID] {
+			continue
+		}
+		add(ManifestActionCreate, ManifestResourceVariableSet, name, "",
+			func(ctx context.Context, c *Client, ids *workspaceIDs) error {
+				id, err := ids.get(ws.Name)
+				if err != nil {
+					return err
+				}
+				return c.ApplyVariableSetContext(ctx, varsetID, []string{id})
+			},
+		)
+	}
+
+	for _, t := range ws.TeamAccess {
+		teamID := p.teamIDs[t.Team]
+		access := t.Access
+		old, found := current.teams[teamID]
+		if !found {
+			add(ManifestActionCreate, ManifestResourceTeamAccess, t.Team, access,
+				func(ctx context.Context, c *Client, ids *workspaceIDs) error {
+					id, err := ids.get(ws.Name)
+					if err != nil {
+						return err
+					}
+					var teamData TeamWorkspaceData
+					teamData.Attributes.Access = access
+					teamData.Relationships.Team.Data.ID = teamID
+					return c.AssignTeamAccessContext(ctx, id, AllTeamWorkspaceData{Data: []TeamWorkspaceData{teamData}})
+				},
+			)
+			continue
+		}
+		if old.Attributes.Access != access {
+			add(ManifestActionUpdate, ManifestResourceTeamAccess, t.Team, old.Attributes.Access+" -> "+access,
+				func(ctx context.Context, c *Client, ids *workspaceIDs) error {
+					return c.UpdateTeamAccessContext(ctx, old.ID, access)
+				},
+			)
+		}
+	}
+
+	for _, name := range ws.RemoteStateConsumers {
+		if current.consumers[name] {
+			continue
+		}
+		consumer := name
+		add(ManifestActionCreate, ManifestResourceRemoteStateConsumer, consumer, "",
+			func(ctx context.Context, c *Client, ids *workspaceIDs) error {
+				id, err := ids.get(ws.Name)
+				if err != nil {
+					return err
+				}
+				consumerID, err := ids.get(consumer)
+				if err != nil {
+					return err
+				}
+				return c.AddRemoteStateConsumersContext(ctx, id, []string{consumerID})
+			},
+		)
+	}
+
+	for _, name := range ws.RunTriggers {
+		if current.triggers[name] {
+			continue
+		}
+		source := name
+		add(ManifestActionCreate, ManifestResourceRunTrigger, source, "",
+			func(ctx context.Context, c *Client, ids *workspaceIDs) error {
+				id, err := ids.get(ws.Name)
+				if err != nil {
+					return err
+				}
+				sourceID, err := ids.get(source)
+				if err != nil {
+					return err
+				}
+				return c.CreateRunTriggerContext(ctx, RunTriggerConfig{WorkspaceID: id, SourceWorkspaceID: sourceID})
+			},
+		)
+	}
+
+	return changes, nil
+}
+
+// createWorkspace returns a function to create a workspace with the attributes in its manifest
+func (p *planner) createWorkspace(ws WorkspaceManifest) func(ctx context.Context, c *Client, ids *workspaceIDs) error {
+	return func(ctx context.Context, c *Client, ids *workspaceIDs) error {
+		oc := OpsConfig{NewOrg: p.organization, NewName: ws.Name}
+		oc.TerraformVersion, _ = ws.Attributes["terraform-version"].(string)
+		oc.Directory, _ = ws.Attributes["working-directory"].(string)
+		var vcsTokenID string
+		if vcs, ok := ws.Attributes["vcs-repo"].(map[string]any); ok {
+			oc.RepoID, _ = vcs["identifier"].(string)
+			oc.Branch, _ = vcs["branch"].(string)
+			vcsTokenID, _ = vcs["oauth-token-id"].(string)
+		}
+
+		created, err := c.CreateWorkspace2Context(ctx, oc, vcsTokenID)
+		if err != nil {
+			return err
+		}
+		ids.set(ws.Name, created.ID)
+
+		others := map[string]any{}
+		for k, v := range ws.Attributes {
+			if k != "vcs-repo" {
+				others[k] = v
+			}
+		}
+		if len(others) == 0 {
+			return nil
+		}
+		return c.UpdateWorkspaceAttributesContext(ctx, created.ID, others)
+	}
+}
+
+// workspaceState is the current configuration of a workspace, for comparison with its manifest
+type workspaceState struct {
+	variables map[string]Var               // Terraform variables, by key
+	varsets   map[string]bool              // by ID
+	teams     map[string]TeamWorkspaceData // by team ID
+	consumers map[string]bool              // by name
+	triggers  map[string]bool              // by source workspace name
+}
+
+// currentState gets the parts of a workspace's configuration that are mentioned in its manifest
+func (p *planner) currentState(ctx context.Context, ws WorkspaceManifest, exists bool) (workspaceState, error) {
+	s := workspaceState{
+		variables: map[string]Var{},
+		varsets:   map[string]bool{},
+		teams:     map[string]TeamWorkspaceData{},
+		consumers: map[string]bool{},
+		triggers:  map[string]bool{},
+	}
+	if !exists {
+		return s, nil
+	}
+	c := p.client
+	id, err := p.ids.get(ws.Name)
+	if err != nil {
+		return s, err
+	}
+
+	if len(ws.Variables) > 0 {
+		vars, err := c.GetVarsFromWorkspaceContext(ctx, p.organization, ws.Name)
+		if err != nil {
+			return s, err
+		}
+		for _, v := range vars {
+			if v.Category == "terraform" {
+				s.variables[v.Key] = v
+			}
+		}
+	}
+
+	if len(ws.VariableSets) > 0 {
+		sets, err := c.ListWorkspaceVariableSetsContext(ctx, id)
+		if err != nil {
+			return s, err
+		}
+		for _, set := range sets.Data {
+			s.varsets[set.ID] = true
+		}
+	}
+
+	if len(ws.TeamAccess) > 0 {
+		teams, err := c.GetTeamAccessFromContext(ctx, id)
+		if err != nil {
+			return s, err
+		}
+		for _, t := range teams.Data {
+			s.teams[t.Relationships.Team.Data.ID] = t
+		}
+	}
+
+	if len(ws.RemoteStateConsumers) > 0 {
+		consumers, err := c.ListRemoteStateConsumersContext(ctx, id)
+		if err != nil {
+			return s, err
+		}
+		for _, name := range consumers {
+			s.consumers[name] = true
+		}
+	}
+
+	if len(ws.RunTriggers) > 0 {
+		triggers, err := c.ListRunTriggersContext(ctx, ListRunTriggerConfig{WorkspaceID: id, Type: "inbound"})
+		if err != nil {
+			return s, err
+		}
+		for _, t := range triggers {
+			s.triggers[t.SourceName] = true
+		}
+	}
+
+	return s, nil
+}
+
+// diffAttributes returns the wanted attributes that differ from the current ones
+func diffAttributes(want map[string]any, current any) map[string]any {
+	currentMap, _ := current.(map[string]any)
+	diff := map[string]any{}
+	for k, v := range want {
+		if !attributeMatches(v, currentMap[k]) {
+			diff[k] = v
+		}
+	}
+	return diff
+}
+
+// attributeMatches compares a wanted attribute value with the current one. If the wanted value is a map, only the
+// keys in it are compared.
+func attributeMatches(want, current any) bool {
+	if wantMap, ok := want.(map[string]any); ok {
+		currentMap, ok := current.(map[string]any)
+		if !ok {
+			return false
+		}
+		for k, v := range wantMap {
+			if !attributeMatches(v, currentMap[k]) {
+				return false
+			}
+		}
+		return true
+	}
+	return attributeString(want) == attributeString(current)
+}
+
+// attributeString returns an attribute value in JSON format
+func attributeString(v any) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
+
+// describeAttributes lists the attributes of a new workspace
+func describeAttributes(attributes map[string]any) string {
+	keys := make([]string, 0, len(attributes))
+	for k := range attributes {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	details := make([]string, len(keys))
+	for i, k := range keys {
+		details[i] = k + ": " + attributeString(attributes[k])
+	}
+	return strings.Join(details, ", ")
+}
+
+// variableDetail describes a new variable, without showing a sensitive value
+func variableDetail(v Var) string {
+	if v.Sensitive {
+		return "(sensitive)"
+	}
+	return fmt.Sprintf("%q", v.Value)
+}
+
+// variableChange describes the difference between an existing variable and the wanted one, or returns "" if there
+// is no difference. The value of a sensitive variable can't be read, so it is not compared.
+func variableChange(old, want Var) string {
+	var details []string
+	if !old.Sensitive && !want.Sensitive && old.Value != want.Value {
+		details = append(details, fmt.Sprintf("value: %q -> %q", old.Value, want.Value))
+	}
+	if old.Sensitive != want.Sensitive {
+		details = append(details, fmt.Sprintf("sensitive: %t -> %t", old.Sensitive, want.Sensitive))
+	}
+	if old.Hcl != want.Hcl {
+		details = append(details, fmt.Sprintf("hcl: %t -> %t", old.Hcl, want.Hcl))
+	}
+	return strings.Join(details, ", ")
+}
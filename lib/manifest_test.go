@@ -0,0 +1,151 @@
+package lib
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadManifest(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest string
+		wantErr  string
+	}{
+		{
+			name: "yaml",
+			manifest: `
+workspaces:
+  - name: ws1
+    attributes:
+      terraform-version: "1.5.7"
+      vcs-repo:
+        identifier: org/repo
+    variables:
+      - key: region
+        value: us-east-1
+    team-access:
+      - team: developers
+        access: write
+`,
+		},
+		{
+			name:     "json",
+			manifest: `{"workspaces": [{"name": "ws1", "run-triggers": ["ws2"]}]}`,
+		},
+		{
+			name:     "empty",
+			manifest: ``,
+			wantErr:  "no workspaces",
+		},
+		{
+			name:     "unknown field",
+			manifest: `{"workspaces": [{"name": "ws1", "varibles": []}]}`,
+			wantErr:  "varibles",
+		},
+		{
+			name:     "duplicate",
+			manifest: `{"workspaces": [{"name": "ws1"}, {"name": "ws1"}]}`,
+			wantErr:  "more than once",
+		},
+		{
+			name:     "no access level",
+			manifest: `{"workspaces": [{"name": "ws1", "team-access": [{"team": "developers"}]}]}`,
+			wantErr:  "access level",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ReadManifest(strings.NewReader(tt.manifest))
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func Test_attributeMatches(t *testing.T) {
+	current := map[string]any{
+		"auto-apply":        false,
+		"terraform-version": "1.5.7",
+		"vcs-repo": map[string]any{
+			"identifier": "org/repo",
+			"branch":     "main",
+		},
+	}
+	require.True(t, attributeMatches("1.5.7", current["terraform-version"]))
+	require.False(t, attributeMatches(true, current["auto-apply"]))
+	require.True(t, attributeMatches(map[string]any{"branch": "main"}, current["vcs-repo"]))
+	require.False(t, attributeMatches(map[string]any{"branch": "develop"}, current["vcs-repo"]))
+	require.False(t, attributeMatches(map[string]any{"branch": "main"}, nil))
+	require.Equal(t, map[string]any{"auto-apply": true},
+		diffAttributes(map[string]any{"auto-apply": true, "terraform-version": "1.5.7"}, current))
+}
+
+func TestClient_PlanManifest(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case apiPath + "/organizations/org":
+			_, _ = w.Write([]byte(`{"data":{"id":"org"}}`))
+		case apiPath + "/organizations/org/workspaces/existing":
+			_, _ = w.Write([]byte(`{"data":{"id":"ws-1","attributes":{"name":"existing","auto-apply":false}}}`))
+		case apiPath + "/vars":
+			_, _ = w.Write([]byte(`{"data":[
+				{"id":"var-1","attributes":{"key":"same","value":"a","category":"terraform"}},
+				{"id":"var-2","attributes":{"key":"changed","value":"old","category":"terraform"}},
+				{"id":"var-3","attributes":{"key":"secret","value":"","sensitive":true,"category":"terraform"}}
+			]}`))
+		case apiPath + "/workspaces/ws-1/run-triggers":
+			_, _ = w.Write([]byte(`{"data":[]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	manifest, err := ReadManifest(strings.NewReader(`
+workspaces:
+  - name: existing
+    attributes:
+      auto-apply: true
+    variables:
+      - {key: same, value: a}
+      - {key: changed, value: new}
+      - {key: secret, value: b, sensitive: true}
+      - {key: added, value: c}
+    run-triggers: [new]
+  - name: new
+    attributes:
+      terraform-version: "1.5.7"
+`))
+	require.NoError(t, err)
+
+	c := newTestClient(server, "token")
+	plan, err := c.PlanManifestContext(context.Background(), "org", manifest)
+	require.NoError(t, err)
+
+	var got []string
+	for _, change := range plan.Changes {
+		got = append(got, strings.Join([]string{change.Workspace, change.Action, change.Resource, change.Name, change.Detail}, "|"))
+	}
+	require.Equal(t, []string{
+		`existing|update|workspace|existing|auto-apply: false -> true`,
+		`new|create|workspace|new|terraform-version: "1.5.7"`,
+		`existing|update|variable|changed|value: "old" -> "new"`,
+		`existing|create|variable|added|"c"`,
+		`existing|create|run-trigger|new|`,
+	}, got)
+
+	c = c.WithToken("token")
+	c.readOnly = true
+	var out bytes.Buffer
+	require.NoError(t, c.ApplyManifestPlanContext(context.Background(), plan, &out))
+	require.Equal(t, 5, strings.Count(out.String(), "\n"))
+}
@@ -0,0 +1,88 @@
+package lib
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// NotificationConfiguration is what is returned by the api for one notification configuration of a workspace. The
+// token of a generic webhook is never returned.
+type NotificationConfiguration struct {
+	ID         string `json:"id,omitempty"`
+	Attributes struct {
+		DestinationType string   `json:"destination-type"`
+		Enabled         bool     `json:"enabled"`
+		Name            string   `json:"name"`
+		URL             string   `json:"url,omitempty"`
+		Triggers        []string `json:"triggers"`
+		EmailAddresses  []string `json:"email-addresses,omitempty"`
+	} `json:"attributes"`
+	Relationships struct {
+		Users struct {
+			Data []ResourceIdentifier `json:"data"`
+		} `json:"users"`
+	} `json:"relationships"`
+}
+
+// ResourceIdentifier identifies an object in a relationship, e.g. a user who is sent notifications
+type ResourceIdentifier struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+}
+
+// ListNotificationConfigurations returns the notification configurations of a workspace
+// https://developer.hashicorp.com/terraform/cloud-docs/api-docs/notification-configurations#list-notification-configurations
+func (c *Client) ListNotificationConfigurations(workspaceID string) ([]NotificationConfiguration, error) {
+	return c.ListNotificationConfigurationsContext(context.Background(), workspaceID)
+}
+
+// ListNotificationConfigurationsContext is like ListNotificationConfigurations but uses ctx for its API calls
+func (c *Client) ListNotificationConfigurationsContext(ctx context.Context, workspaceID string) ([]NotificationConfiguration, error) {
+	u := c.NewTfcUrl("/workspaces/" + workspaceID + "/notification-configurations")
+
+	return getAllPages[NotificationConfiguration](ctx, c, u)
+}
+
+// CreateNotificationConfiguration adds a notification configuration to a workspace. The ID of nc is ignored.
+// https://developer.hashicorp.com/terraform/cloud-docs/api-docs/notification-configurations#create-a-notification-configuration
+func (c *Client) CreateNotificationConfiguration(workspaceID string, nc NotificationConfiguration) error {
+	return c.CreateNotificationConfigurationContext(context.Background(), workspaceID, nc)
+}
+
+// CreateNotificationConfigurationContext is like CreateNotificationConfiguration but uses ctx for its API calls
+func (c *Client) CreateNotificationConfigurationContext(ctx context.Context, workspaceID string, nc NotificationConfiguration) error {
+	nc.ID = ""
+	users := make([]ResourceIdentifier, len(nc.Relationships.Users.Data))
+	for i, user := range nc.Relationships.Users.Data {
+		users[i] = ResourceIdentifier{ID: user.ID, Type: "users"}
+	}
+	nc.Relationships.Users.Data = users
+	payload := struct {
+		Data struct {
+			Type string `json:"type"`
+			NotificationConfiguration
+		} `json:"data"`
+	}{}
+	payload.Data.Type = "notification-configurations"
+	payload.Data.NotificationConfiguration = nc
+	postData, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	if c.debug {
+		fmt.Printf("request body:\n    %s\n", postData)
+	}
+	if c.readOnly {
+		return nil
+	}
+
+	u := c.NewTfcUrl("/workspaces/" + workspaceID + "/notification-configurations")
+	resp, err := c.callAPI(ctx, http.MethodPost, u.String(), string(postData), nil)
+	if err != nil {
+		return fmt.Errorf("failed to create notification configuration %s: %w", nc.Attributes.Name, err)
+	}
+	return resp.Body.Close()
+}
@@ -6,14 +6,15 @@ import (
 
 // OpsConfig represents one row of the plan.csv file's contents
 type OpsConfig struct {
-	SourceOrg        string
-	SourceName       string
-	NewOrg           string
-	NewName          string
-	TerraformVersion string
-	RepoID           string
-	Branch           string
-	Directory        string
+	SourceOrg        string `yaml:"source-org"`
+	SourceName       string `yaml:"source-name"`
+	NewOrg           string `yaml:"new-org"`
+	NewName          string `yaml:"new-name"`
+	TerraformVersion string `yaml:"terraform-version"`
+	RepoID           string `yaml:"repo-id"`
+	Branch           string `yaml:"branch"`
+	Directory        string `yaml:"directory"`
+	VCSTokenID       string `yaml:"vcs-token-id"` // VCS token of the new organization, for a different account
 }
 
 // AsArray returns the values of the OpsConfig attributes
@@ -27,6 +28,7 @@ func (o *OpsConfig) AsArray() []string {
 		o.RepoID,
 		o.Branch,
 		o.Directory,
+		o.VCSTokenID,
 	}
 }
 
@@ -0,0 +1,102 @@
+package lib
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// pageInfo holds the JSON:API pagination details of a page of a list
+// https://developer.hashicorp.com/terraform/cloud-docs/api-docs#pagination
+type pageInfo struct {
+	Links struct {
+		Next string `json:"next"`
+	} `json:"links"`
+	Meta struct {
+		Pagination struct {
+			NextPage int `json:"next-page"`
+		} `json:"pagination"`
+	} `json:"meta"`
+}
+
+// errLastPage is returned by a forEachPage callback to stop before the last page without an error
+var errLastPage = errors.New("last page needed")
+
+// forEachPage gets each page of the list at u and calls fn with the body of the page. After each page, it follows
+// the `links.next` URL or, if there is none, the `meta.pagination.next-page` number. It stops after the last page,
+// or when fn returns an error. If fn returns errLastPage, forEachPage stops and returns nil. The page size is
+// pageSize, unless u already sets one.
+func (c *Client) forEachPage(ctx context.Context, u TfcUrl, fn func(page []byte) error) error {
+	if u.Query().Get(paramPageSize) == "" {
+		u.SetParam(paramPageSize, strconv.Itoa(pageSize))
+	}
+
+	for {
+		resp, err := c.callAPI(ctx, http.MethodGet, u.String(), "", nil)
+		if err != nil {
+			return err
+		}
+		body, err := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if err != nil {
+			return fmt.Errorf("failed to read page of %s: %w", u.Path, err)
+		}
+
+		if err := fn(body); errors.Is(err, errLastPage) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		next, ok := nextPage(u, body)
+		if !ok {
+			return nil
+		}
+		u = next
+	}
+}
+
+// nextPage returns the URL of the page after the one at u, given its body. Only the query of a `links.next` URL is
+// used, so all the pages are requested from the client's hostname. The second return value is false if there is no
+// next page, or if the next page is the same as the current one.
+func nextPage(u TfcUrl, body []byte) (TfcUrl, bool) {
+	var info pageInfo
+	if err := json.Unmarshal(body, &info); err != nil {
+		return u, false
+	}
+
+	current := u.String()
+	if info.Links.Next != "" {
+		link, err := url.Parse(info.Links.Next)
+		if err != nil {
+			return u, false
+		}
+		u.RawQuery = link.RawQuery
+	} else if info.Meta.Pagination.NextPage > 0 {
+		u.SetParam(paramPageNumber, strconv.Itoa(info.Meta.Pagination.NextPage))
+	} else {
+		return u, false
+	}
+	return u, u.String() != current
+}
+
+// getAllPages returns the `data` of every page of the list at u
+func getAllPages[T any](ctx context.Context, c *Client, u TfcUrl) ([]T, error) {
+	var all []T
+	err := c.forEachPage(ctx, u, func(page []byte) error {
+		var list struct {
+			Data []T `json:"data"`
+		}
+		if err := json.Unmarshal(page, &list); err != nil {
+			return fmt.Errorf("unexpected content in list: %w", err)
+		}
+		all = append(all, list.Data...)
+		return nil
+	})
+	return all, err
+}
@@ -0,0 +1,91 @@
+package lib
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_nextPage(t *testing.T) {
+	u := NewTfcUrl("/organizations/org/varsets")
+	u.SetParam(paramPageSize, "20")
+
+	next, ok := nextPage(u, []byte(`{"links":{"next":"https://example.com/api/v2/organizations/org/varsets?page%5Bnumber%5D=2&page%5Bsize%5D=20"}}`))
+	require.True(t, ok)
+	require.Equal(t, u.Host, next.Host)
+	require.Equal(t, "2", next.Query().Get(paramPageNumber))
+
+	next, ok = nextPage(u, []byte(`{"links":{"next":null},"meta":{"pagination":{"current-page":1,"next-page":3}}}`))
+	require.True(t, ok)
+	require.Equal(t, "3", next.Query().Get(paramPageNumber))
+
+	_, ok = nextPage(next, []byte(`{"meta":{"pagination":{"current-page":3,"next-page":3}}}`))
+	require.False(t, ok, "a next page that is the current page should stop the loop")
+
+	_, ok = nextPage(u, []byte(`{"data":[],"links":{"next":null},"meta":{"pagination":{"next-page":null}}}`))
+	require.False(t, ok)
+}
+
+func TestClient_GetAllVariableSets_pages(t *testing.T) {
+	const total = 25
+	var requests int
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		page, _ := strconv.Atoi(r.URL.Query().Get(paramPageNumber))
+		if page == 0 {
+			page = 1
+		}
+		size, _ := strconv.Atoi(r.URL.Query().Get(paramPageSize))
+		require.Equal(t, pageSize, size)
+
+		var data []string
+		for i := (page-1)*size + 1; i <= total && i <= page*size; i++ {
+			data = append(data, fmt.Sprintf(`{"id":"varset-%d","attributes":{"name":"set-%d"}}`, i, i))
+		}
+		next := "null"
+		if page*size < total {
+			next = fmt.Sprintf(`"https://app.terraform.io%s?page%%5Bnumber%%5D=%d&page%%5Bsize%%5D=%d"`,
+				r.URL.Path, page+1, size)
+		}
+		_, _ = fmt.Fprintf(w, `{"data":[%s],"links":{"next":%s}}`, strings.Join(data, ","), next)
+	}))
+	defer server.Close()
+	c := newTestClient(server, "token")
+
+	sets, err := c.GetAllVariableSets("org")
+	require.NoError(t, err)
+	require.Len(t, sets.Data, total)
+	require.Equal(t, "set-25", sets.Data[total-1].Attributes.Name)
+	require.Equal(t, 2, requests)
+}
+
+func TestClient_GetAllWorkspaces_pages(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get(paramPageNumber) {
+		case "":
+			_, _ = w.Write([]byte(`{"data":[{"id":"ws-1","attributes":{"name":"one"}}],
+				"meta":{"pagination":{"current-page":1,"next-page":2}}}`))
+		case "2":
+			_, _ = w.Write([]byte(`{"data":[{"id":"ws-2","attributes":{"name":"two"}}],
+				"meta":{"pagination":{"current-page":2,"next-page":null}}}`))
+		default:
+			t.Errorf("unexpected request for %s", r.URL)
+		}
+	}))
+	defer server.Close()
+	c := newTestClient(server, "token")
+
+	workspaces, err := c.GetAllWorkspaces("org")
+	require.NoError(t, err)
+	require.Len(t, workspaces, 2)
+	require.Equal(t, "ws-2", workspaces[1].ID)
+
+	ws, err := c.FindWorkspaces("org", "")
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"ws-1": "one", "ws-2": "two"}, ws)
+}
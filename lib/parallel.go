@@ -0,0 +1,129 @@
+package lib
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sync"
+)
+
+// DefaultParallelism is the number of workspaces processed at once if ClientConfig.Parallelism is not set
+const DefaultParallelism = 4
+
+// ForEachWorkspace calls fn for each of the named workspaces, running up to the client's parallelism limit at once.
+// Anything fn writes to its io.Writer is copied to out in the same order as names, as soon as that workspace and all
+// those before it are finished, so the output is the same as if the workspaces were processed one at a time.
+//
+// If a call fails or ctx is canceled, no more calls are started and the error is an *IncompleteError listing the
+// workspaces that were and were not completed.
+func (c *Client) ForEachWorkspace(ctx context.Context, names []string, out io.Writer,
+	fn func(ctx context.Context, name string, w io.Writer) error,
+) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type result struct {
+		output bytes.Buffer
+		err    error
+		done   chan struct{}
+	}
+	results := make([]*result, len(names))
+	for i := range results {
+		results[i] = &result{done: make(chan struct{})}
+	}
+
+	var mu sync.Mutex
+	var firstErr error
+	fail := func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if firstErr == nil {
+			firstErr = err
+		}
+		cancel()
+	}
+
+	next := make(chan int)
+	go func() {
+		defer close(next)
+		for i := range names {
+			if ctx.Err() == nil {
+				select {
+				case next <- i:
+					continue
+				case <-ctx.Done():
+				}
+			}
+			// record the cancellation, in case it came from the parent context rather than a failed call
+			fail(ctx.Err())
+			// mark this workspace and the rest as not started
+			for _, r := range results[i:] {
+				r.err = ctx.Err()
+				close(r.done)
+			}
+			return
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for n := 0; n < c.parallelism && n < len(names); n++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				r := results[i]
+				// the feeder may still send after a cancel, so check again before starting
+				if r.err = ctx.Err(); r.err == nil {
+					r.err = fn(ctx, names[i], &r.output)
+				}
+				if r.err != nil {
+					fail(r.err)
+				}
+				close(r.done)
+			}
+		}()
+	}
+
+	var processed, remaining []string
+	for i, r := range results {
+		<-r.done
+		if out != nil {
+			_, _ = r.output.WriteTo(out)
+		}
+		if r.err == nil {
+			processed = append(processed, names[i])
+		} else {
+			remaining = append(remaining, names[i])
+		}
+	}
+	wg.Wait()
+
+	if len(remaining) > 0 {
+		mu.Lock()
+		defer mu.Unlock()
+		return &IncompleteError{Processed: processed, Remaining: remaining, Err: firstErr}
+	}
+	return nil
+}
+
+// orgCache remembers which organizations are known to exist, so that the check is only made once
+type orgCache struct {
+	sync.Mutex
+	exists map[string]bool
+}
+
+func newOrgCache() *orgCache {
+	return &orgCache{exists: map[string]bool{}}
+}
+
+func (o *orgCache) get(organization string) bool {
+	o.Lock()
+	defer o.Unlock()
+	return o.exists[organization]
+}
+
+func (o *orgCache) set(organization string) {
+	o.Lock()
+	defer o.Unlock()
+	o.exists[organization] = true
+}
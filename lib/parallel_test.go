@@ -0,0 +1,122 @@
+package lib
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_ForEachWorkspace(t *testing.T) {
+	names := []string{"ws1", "ws2", "ws3", "ws4", "ws5", "ws6", "ws7", "ws8"}
+	c := NewClient(ClientConfig{Parallelism: 3})
+
+	var running, maxRunning int32
+	var out bytes.Buffer
+	err := c.ForEachWorkspace(context.Background(), names, &out, func(ctx context.Context, name string, w io.Writer) error {
+		n := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+		for {
+			m := atomic.LoadInt32(&maxRunning)
+			if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+				break
+			}
+		}
+		// finish later workspaces first, to check the output is still in order
+		time.Sleep(time.Duration(len(names)-int(name[2]-'0')) * time.Millisecond)
+		fmt.Fprintf(w, "%s\n", name)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, strings.Join(names, "\n")+"\n", out.String())
+	require.LessOrEqual(t, maxRunning, int32(3))
+}
+
+func TestClient_ForEachWorkspaceError(t *testing.T) {
+	names := []string{"ws1", "ws2", "ws3", "ws4", "ws5", "ws6"}
+	c := NewClient(ClientConfig{Parallelism: 1})
+	failure := errors.New("failed")
+
+	var out bytes.Buffer
+	var started []string
+	err := c.ForEachWorkspace(context.Background(), names, &out, func(ctx context.Context, name string, w io.Writer) error {
+		started = append(started, name)
+		fmt.Fprintf(w, "%s\n", name)
+		if name == "ws3" {
+			return failure
+		}
+		return nil
+	})
+
+	var incomplete *IncompleteError
+	require.ErrorAs(t, err, &incomplete)
+	require.ErrorIs(t, err, failure)
+	require.Equal(t, []string{"ws1", "ws2", "ws3"}, started)
+	require.Equal(t, []string{"ws1", "ws2"}, incomplete.Processed)
+	require.Equal(t, []string{"ws3", "ws4", "ws5", "ws6"}, incomplete.Remaining)
+	require.Equal(t, "ws1\nws2\nws3\n", out.String())
+}
+
+func TestClient_ForEachWorkspaceCanceled(t *testing.T) {
+	names := []string{"ws1", "ws2", "ws3", "ws4"}
+	c := NewClient(ClientConfig{Parallelism: 1})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var started []string
+	err := c.ForEachWorkspace(ctx, names, nil, func(ctx context.Context, name string, w io.Writer) error {
+		started = append(started, name)
+		if name == "ws2" {
+			cancel()
+		}
+		return nil
+	})
+
+	var incomplete *IncompleteError
+	require.ErrorAs(t, err, &incomplete)
+	require.ErrorIs(t, err, context.Canceled)
+	require.ErrorIs(t, incomplete.Err, context.Canceled)
+	require.Equal(t, []string{"ws1", "ws2"}, started)
+	require.Equal(t, []string{"ws1", "ws2"}, incomplete.Processed)
+	require.Equal(t, []string{"ws3", "ws4"}, incomplete.Remaining)
+
+	err = c.ForEachWorkspace(ctx, names, nil, func(ctx context.Context, name string, w io.Writer) error {
+		t.Errorf("workspace %s started after the context was canceled", name)
+		return nil
+	})
+	require.ErrorAs(t, err, &incomplete)
+	require.ErrorIs(t, incomplete.Err, context.Canceled)
+	require.Empty(t, incomplete.Processed)
+	require.Equal(t, names, incomplete.Remaining)
+}
+
+func TestClient_GetVarsFromWorkspaceChecksOrganizationOnce(t *testing.T) {
+	var mu sync.Mutex
+	requests := map[string]int{}
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests[r.URL.Path]++
+		mu.Unlock()
+		_, _ = w.Write([]byte(`{"data":[]}`))
+	}))
+	defer server.Close()
+
+	c := newTestClient(server, "token")
+	for i := 0; i < 3; i++ {
+		_, err := c.GetVarsFromWorkspace("org", "ws")
+		assert.NoError(t, err)
+	}
+	require.Equal(t, 1, requests[apiPath+"/organizations/org"])
+}
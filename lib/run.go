@@ -1,7 +1,12 @@
 package lib
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/Jeffail/gabs/v2"
 )
@@ -13,13 +18,42 @@ type RunConfig struct {
 
 // CreateRun creates a Run, which starts a Plan, which can later be Applied.
 // https://developer.hashicorp.com/terraform/cloud-docs/api-docs/run
-func CreateRun(config RunConfig) error {
-	u := NewTfcUrl("/runs")
+func (c *Client) CreateRun(config RunConfig) error {
+	return c.CreateRunContext(context.Background(), config)
+}
+
+// CreateRunContext is like CreateRun but uses ctx for its API calls
+func (c *Client) CreateRunContext(ctx context.Context, config RunConfig) error {
+	u := c.NewTfcUrl("/runs")
 	payload := buildRunPayload(config.Message, config.WorkspaceID)
-	_, err := callAPI(http.MethodPost, u.String(), payload, nil)
+	_, err := c.callAPI(ctx, http.MethodPost, u.String(), payload, nil)
 	return err
 }
 
+// CreateRun2 creates a Run, which starts a Plan, which can later be Applied. Returns the properties of the new Run.
+func (c *Client) CreateRun2(config RunConfig) (Run, error) {
+	return c.CreateRun2Context(context.Background(), config)
+}
+
+// CreateRun2Context is like CreateRun2 but uses ctx for its API calls
+func (c *Client) CreateRun2Context(ctx context.Context, config RunConfig) (Run, error) {
+	u := c.NewTfcUrl("/runs")
+	payload := buildRunPayload(config.Message, config.WorkspaceID)
+	resp, err := c.callAPI(ctx, http.MethodPost, u.String(), payload, nil)
+	if err != nil {
+		return Run{}, err
+	}
+	defer resp.Body.Close()
+
+	var runData struct {
+		Data Run `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&runData); err != nil {
+		return Run{}, fmt.Errorf("error getting created run data: %w", err)
+	}
+	return runData.Data, nil
+}
+
 func buildRunPayload(message, workspaceID string) string {
 	data := gabs.New()
 
@@ -38,3 +72,187 @@ func buildRunPayload(message, workspaceID string) string {
 
 	return data.String()
 }
+
+// Run is what is returned by the api for one run
+type Run struct {
+	ID         string `json:"id"`
+	Attributes struct {
+		Status     string    `json:"status"`
+		Message    string    `json:"message"`
+		Source     string    `json:"source"`
+		CreatedAt  time.Time `json:"created-at"`
+		IsDestroy  bool      `json:"is-destroy"`
+		HasChanges bool      `json:"has-changes"`
+		Actions    struct {
+			IsCancelable  bool `json:"is-cancelable"`
+			IsConfirmable bool `json:"is-confirmable"`
+			IsDiscardable bool `json:"is-discardable"`
+		} `json:"actions"`
+	} `json:"attributes"`
+	Relationships struct {
+		Workspace struct {
+			Data struct {
+				ID string `json:"id"`
+			} `json:"data"`
+		} `json:"workspace"`
+		Plan struct {
+			Data struct {
+				ID string `json:"id"`
+			} `json:"data"`
+		} `json:"plan"`
+	} `json:"relationships"`
+}
+
+type ListRunsConfig struct {
+	WorkspaceID string
+	Limit       int // maximum number of runs to return, most recent first. If 0, all runs are returned.
+}
+
+// ListRuns returns the most recent runs of a workspace
+// https://developer.hashicorp.com/terraform/cloud-docs/api-docs/run#list-runs-in-a-workspace
+func (c *Client) ListRuns(config ListRunsConfig) ([]Run, error) {
+	return c.ListRunsContext(context.Background(), config)
+}
+
+// ListRunsContext is like ListRuns but uses ctx for its API calls
+func (c *Client) ListRunsContext(ctx context.Context, config ListRunsConfig) ([]Run, error) {
+	u := c.NewTfcUrl("/workspaces/" + config.WorkspaceID + "/runs")
+	if config.Limit > 0 && config.Limit < pageSize {
+		u.SetParam(paramPageSize, strconv.Itoa(config.Limit))
+	}
+
+	var runs []Run
+	err := c.forEachPage(ctx, u, func(page []byte) error {
+		var runList struct {
+			Data []Run `json:"data"`
+		}
+		if err := json.Unmarshal(page, &runList); err != nil {
+			return fmt.Errorf("unexpected content retrieving run list: %w", err)
+		}
+		runs = append(runs, runList.Data...)
+		if config.Limit > 0 && len(runs) >= config.Limit {
+			runs = runs[:config.Limit]
+			return errLastPage
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return runs, nil
+}
+
+// GetRun returns the properties of one run
+func (c *Client) GetRun(runID string) (Run, error) {
+	return c.GetRunContext(context.Background(), runID)
+}
+
+// GetRunContext is like GetRun but uses ctx for its API calls
+func (c *Client) GetRunContext(ctx context.Context, runID string) (Run, error) {
+	u := c.NewTfcUrl("/runs/" + runID)
+
+	resp, err := c.callAPI(ctx, http.MethodGet, u.String(), "", nil)
+	if err != nil {
+		return Run{}, err
+	}
+	defer resp.Body.Close()
+
+	var runData struct {
+		Data Run `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&runData); err != nil {
+		return Run{}, fmt.Errorf("unexpected content retrieving run %s: %w", runID, err)
+	}
+	return runData.Data, nil
+}
+
+// Plan is what is returned by the api for the plan of a run
+type Plan struct {
+	ID         string `json:"id"`
+	Attributes struct {
+		Status               string `json:"status"`
+		HasChanges           bool   `json:"has-changes"`
+		ResourceAdditions    int    `json:"resource-additions"`
+		ResourceChanges      int    `json:"resource-changes"`
+		ResourceDestructions int    `json:"resource-destructions"`
+	} `json:"attributes"`
+}
+
+// GetPlan returns the properties of a plan, including the number of resources it adds, changes and destroys
+func (c *Client) GetPlan(planID string) (Plan, error) {
+	return c.GetPlanContext(context.Background(), planID)
+}
+
+// GetPlanContext is like GetPlan but uses ctx for its API calls
+func (c *Client) GetPlanContext(ctx context.Context, planID string) (Plan, error) {
+	u := c.NewTfcUrl("/plans/" + planID)
+
+	resp, err := c.callAPI(ctx, http.MethodGet, u.String(), "", nil)
+	if err != nil {
+		return Plan{}, err
+	}
+	defer resp.Body.Close()
+
+	var planData struct {
+		Data Plan `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&planData); err != nil {
+		return Plan{}, fmt.Errorf("unexpected content retrieving plan %s: %w", planID, err)
+	}
+	return planData.Data, nil
+}
+
+// ApplyRun applies a run that is waiting for confirmation. The comment is optional.
+func (c *Client) ApplyRun(runID, comment string) error {
+	return c.ApplyRunContext(context.Background(), runID, comment)
+}
+
+// ApplyRunContext is like ApplyRun but uses ctx for its API calls
+func (c *Client) ApplyRunContext(ctx context.Context, runID, comment string) error {
+	return c.runAction(ctx, runID, "apply", comment)
+}
+
+// DiscardRun skips the apply of a run that is waiting for confirmation or a policy override. The comment is
+// optional.
+func (c *Client) DiscardRun(runID, comment string) error {
+	return c.DiscardRunContext(context.Background(), runID, comment)
+}
+
+// DiscardRunContext is like DiscardRun but uses ctx for its API calls
+func (c *Client) DiscardRunContext(ctx context.Context, runID, comment string) error {
+	return c.runAction(ctx, runID, "discard", comment)
+}
+
+// CancelRun interrupts a run that is planning or applying. The comment is optional.
+func (c *Client) CancelRun(runID, comment string) error {
+	return c.CancelRunContext(context.Background(), runID, comment)
+}
+
+// CancelRunContext is like CancelRun but uses ctx for its API calls
+func (c *Client) CancelRunContext(ctx context.Context, runID, comment string) error {
+	return c.runAction(ctx, runID, "cancel", comment)
+}
+
+// runAction calls one of the run action endpoints, e.g. "apply"
+// https://developer.hashicorp.com/terraform/cloud-docs/api-docs/run#apply-a-run
+func (c *Client) runAction(ctx context.Context, runID, action, comment string) error {
+	data := gabs.New()
+	if comment != "" {
+		_, _ = data.Set(comment, "comment")
+	}
+	postData := data.String()
+
+	if c.debug {
+		fmt.Printf("request body:\n    %s\n", postData)
+	}
+	if c.readOnly {
+		return nil
+	}
+
+	u := c.NewTfcUrl(fmt.Sprintf("/runs/%s/actions/%s", runID, action))
+	resp, err := c.callAPI(ctx, http.MethodPost, u.String(), postData, nil)
+	if err != nil {
+		return fmt.Errorf("failed to %s run %s: %w", action, runID, err)
+	}
+	return resp.Body.Close()
+}
@@ -1,7 +1,16 @@
 package lib
 
 import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func Test_buildRunPayload(t *testing.T) {
@@ -10,3 +19,75 @@ func Test_buildRunPayload(t *testing.T) {
 		t.Fatalf("did not get expected result, got %s", got)
 	}
 }
+
+func TestClient_runActions(t *testing.T) {
+	var gotPath, gotBody string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		body, _ := io.ReadAll(r.Body)
+		gotBody = string(body)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	c := newTestClient(server, "token")
+	require.NoError(t, c.ApplyRun("run-1", "looks good"))
+	require.Equal(t, apiPath+"/runs/run-1/actions/apply", gotPath)
+	require.Equal(t, `{"comment":"looks good"}`, gotBody)
+
+	require.NoError(t, c.DiscardRun("run-2", ""))
+	require.Equal(t, apiPath+"/runs/run-2/actions/discard", gotPath)
+	require.Equal(t, `{}`, gotBody)
+
+	gotPath = ""
+	c.readOnly = true
+	require.NoError(t, c.CancelRun("run-3", ""))
+	require.Empty(t, gotPath)
+}
+
+func TestClient_ListRuns(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "2", r.URL.Query().Get(paramPageSize))
+		_, _ = w.Write([]byte(`{"data":[{"id":"run-1","attributes":{"status":"planned",
+			"created-at":"2023-06-20T08:56:50.996Z","actions":{"is-confirmable":true}},
+			"relationships":{"plan":{"data":{"id":"plan-1"}}}}]}`))
+	}))
+	defer server.Close()
+
+	runs, err := newTestClient(server, "token").ListRuns(ListRunsConfig{WorkspaceID: "ws-1", Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, runs, 1)
+	require.Equal(t, "run-1", runs[0].ID)
+	require.Equal(t, "planned", runs[0].Attributes.Status)
+	require.True(t, runs[0].Attributes.Actions.IsConfirmable)
+	require.Equal(t, "plan-1", runs[0].Relationships.Plan.Data.ID)
+}
+
+func TestClient_ListRunsPages(t *testing.T) {
+	const total = 30
+	var requests int
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		page, _ := strconv.Atoi(r.URL.Query().Get(paramPageNumber))
+		if page == 0 {
+			page = 1
+		}
+		size, _ := strconv.Atoi(r.URL.Query().Get(paramPageSize))
+		assert.Equal(t, pageSize, size)
+
+		var data []string
+		for i := (page-1)*size + 1; i <= total && i <= page*size; i++ {
+			data = append(data, fmt.Sprintf(`{"id":"run-%d"}`, i))
+		}
+		_, _ = fmt.Fprintf(w, `{"data":[%s],"meta":{"pagination":{"next-page":%d}}}`,
+			strings.Join(data, ","), page+1)
+	}))
+	defer server.Close()
+	c := newTestClient(server, "token")
+
+	runs, err := c.ListRuns(ListRunsConfig{WorkspaceID: "ws-1", Limit: 25})
+	require.NoError(t, err)
+	require.Len(t, runs, 25)
+	require.Equal(t, "run-25", runs[24].ID)
+	require.Equal(t, 2, requests)
+}
@@ -1,6 +1,8 @@
 package lib
 
 import (
+	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"net/http"
@@ -14,10 +16,15 @@ type RunTriggerConfig struct {
 	SourceWorkspaceID string
 }
 
-func CreateRunTrigger(config RunTriggerConfig) error {
-	u := NewTfcUrl("/workspaces/" + config.WorkspaceID + "/run-triggers")
+func (c *Client) CreateRunTrigger(config RunTriggerConfig) error {
+	return c.CreateRunTriggerContext(context.Background(), config)
+}
+
+// CreateRunTriggerContext is like CreateRunTrigger but uses ctx for its API calls
+func (c *Client) CreateRunTriggerContext(ctx context.Context, config RunTriggerConfig) error {
+	u := c.NewTfcUrl("/workspaces/" + config.WorkspaceID + "/run-triggers")
 	payload := buildRunTriggerPayload(config.SourceWorkspaceID)
-	_, err := callAPI(http.MethodPost, u.String(), payload, nil)
+	_, err := c.callAPI(ctx, http.MethodPost, u.String(), payload, nil)
 	return err
 }
 
@@ -46,8 +53,13 @@ type FindRunTriggerConfig struct {
 
 // FindRunTrigger searches all the run triggers inbound to the given WorkspaceID. If a run trigger is configured for
 // the given SourceWorkspaceID, that trigger is returned. Otherwise, nil is returned.
-func FindRunTrigger(config FindRunTriggerConfig) (*RunTrigger, error) {
-	triggers, err := ListRunTriggers(ListRunTriggerConfig{
+func (c *Client) FindRunTrigger(config FindRunTriggerConfig) (*RunTrigger, error) {
+	return c.FindRunTriggerContext(context.Background(), config)
+}
+
+// FindRunTriggerContext is like FindRunTrigger but uses ctx for its API calls
+func (c *Client) FindRunTriggerContext(ctx context.Context, config FindRunTriggerConfig) (*RunTrigger, error) {
+	triggers, err := c.ListRunTriggersContext(ctx, ListRunTriggerConfig{
 		WorkspaceID: config.WorkspaceID,
 		Type:        "inbound",
 	})
@@ -63,6 +75,7 @@ func FindRunTrigger(config FindRunTriggerConfig) (*RunTrigger, error) {
 }
 
 type RunTrigger struct {
+	ID            string
 	CreatedAt     time.Time
 	SourceName    string
 	SourceID      string
@@ -77,17 +90,28 @@ type ListRunTriggerConfig struct {
 
 // ListRunTriggers returns a list of run triggers configured for the given workspace
 // https://developer.hashicorp.com/terraform/cloud-docs/api-docs/run-triggers#list-run-triggers
-func ListRunTriggers(config ListRunTriggerConfig) ([]RunTrigger, error) {
-	u := NewTfcUrl("/workspaces/" + config.WorkspaceID + "/run-triggers")
+func (c *Client) ListRunTriggers(config ListRunTriggerConfig) ([]RunTrigger, error) {
+	return c.ListRunTriggersContext(context.Background(), config)
+}
+
+// ListRunTriggersContext is like ListRunTriggers but uses ctx for its API calls
+func (c *Client) ListRunTriggersContext(ctx context.Context, config ListRunTriggerConfig) ([]RunTrigger, error) {
+	u := c.NewTfcUrl("/workspaces/" + config.WorkspaceID + "/run-triggers")
 	u.SetParam(paramFilterRunTriggerType, config.Type)
 
-	resp, err := callAPI(http.MethodGet, u.String(), "", nil)
+	var triggers []RunTrigger
+	err := c.forEachPage(ctx, u, func(page []byte) error {
+		pageTriggers, err := parseRunTriggerListResponse(bytes.NewReader(page))
+		if err != nil {
+			return err
+		}
+		triggers = append(triggers, pageTriggers...)
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-
-	return parseRunTriggerListResponse(resp.Body)
+	return triggers, nil
 }
 
 func parseRunTriggerListResponse(r io.Reader) ([]RunTrigger, error) {
@@ -100,6 +124,7 @@ func parseRunTriggerListResponse(r io.Reader) ([]RunTrigger, error) {
 	triggers := make([]RunTrigger, len(attributes))
 	for i, attr := range attributes {
 		trigger := RunTrigger{
+			ID:            attr.Path("id").Data().(string),
 			SourceID:      attr.Path("relationships.sourceable.data.id").Data().(string),
 			SourceName:    attr.Path("attributes.sourceable-name").Data().(string),
 			WorkspaceID:   attr.Path("relationships.workspace.data.id").Data().(string),
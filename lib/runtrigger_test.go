@@ -19,6 +19,7 @@ func Test_parseRunTriggerListResponse(t *testing.T) {
 	r := bytes.NewReader([]byte(listTriggerSampleBody))
 	triggers, err := parseRunTriggerListResponse(r)
 	require.NoError(t, err)
+	require.Equal(t, triggers[0].ID, "rt-abcdefghijklmnop")
 	require.Equal(t, triggers[0].WorkspaceID, "ws-abcdefghijklmnop")
 	require.Equal(t, triggers[0].SourceID, "ws-qrstuvwxyzABCDEF")
 	require.Equal(t, triggers[0].WorkspaceName, "a-workspace-name")
@@ -0,0 +1,53 @@
+package lib
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// ReadSecrets reads variable values, by key, from r, which has one `key=value` pair per line. Blank lines and lines
+// starting with `#` are ignored. A reference to an environment variable in a value, like `$NAME` or `${NAME}`, is
+// replaced by its value, so that a secret can be mapped from the environment rather than written in the file. Use
+// `$$` for a literal `$`.
+func ReadSecrets(r io.Reader) (map[string]string, error) {
+	secrets := map[string]string{}
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		key, value, found := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if !found || key == "" {
+			return nil, fmt.Errorf("line %d: expected key=value", n)
+		}
+
+		var missing []string
+		value = os.Expand(strings.TrimSpace(value), func(name string) string {
+			if name == "$" {
+				return "$"
+			}
+			v, ok := os.LookupEnv(name)
+			if !ok {
+				missing = append(missing, name)
+			}
+			return v
+		})
+		if len(missing) > 0 {
+			return nil, fmt.Errorf("line %d: environment variable %s is not set", n, strings.Join(missing, ", "))
+		}
+		if _, ok := secrets[key]; ok {
+			return nil, fmt.Errorf("line %d: duplicate key %s", n, key)
+		}
+		secrets[key] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return secrets, nil
+}
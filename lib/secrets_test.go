@@ -0,0 +1,34 @@
+package lib
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadSecrets(t *testing.T) {
+	t.Setenv("TFC_OPS_TEST_SECRET", "from-env")
+
+	secrets, err := ReadSecrets(strings.NewReader(`
+# database
+db_password = p@ss=word
+api_key=${TFC_OPS_TEST_SECRET}
+price=$$5
+`))
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"db_password": "p@ss=word",
+		"api_key":     "from-env",
+		"price":       "$5",
+	}, secrets)
+
+	_, err = ReadSecrets(strings.NewReader("api_key=$TFC_OPS_TEST_UNSET"))
+	require.ErrorContains(t, err, "line 1: environment variable TFC_OPS_TEST_UNSET is not set")
+
+	_, err = ReadSecrets(strings.NewReader("a=1\n\njust-a-key"))
+	require.ErrorContains(t, err, "line 3: expected key=value")
+
+	_, err = ReadSecrets(strings.NewReader("a=1\na=2"))
+	require.ErrorContains(t, err, "line 2: duplicate key a")
+}
@@ -0,0 +1,260 @@
+package lib
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/Jeffail/gabs/v2"
+)
+
+// StateVersion is what is returned by the api for one version of a workspace's state
+type StateVersion struct {
+	ID         string `json:"id"`
+	Attributes struct {
+		Serial                 int64     `json:"serial"`
+		CreatedAt              time.Time `json:"created-at"`
+		HostedStateDownloadURL string    `json:"hosted-state-download-url"`
+	} `json:"attributes"`
+}
+
+// stateFile holds the fields of a Terraform state file that identify its version
+type stateFile struct {
+	Serial  int64  `json:"serial"`
+	Lineage string `json:"lineage"`
+}
+
+func parseStateFile(state []byte) (stateFile, error) {
+	var s stateFile
+	if err := json.Unmarshal(state, &s); err != nil {
+		return s, fmt.Errorf("invalid state file: %w", err)
+	}
+	if s.Lineage == "" {
+		return s, errors.New("invalid state file: no lineage")
+	}
+	return s, nil
+}
+
+func stateMD5(state []byte) string {
+	sum := md5.Sum(state)
+	return hex.EncodeToString(sum[:])
+}
+
+// GetCurrentStateVersion returns the current state version of a workspace, or nil if the workspace has no state
+// https://developer.hashicorp.com/terraform/cloud-docs/api-docs/state-versions#fetch-the-current-state-version-for-a-workspace
+func (c *Client) GetCurrentStateVersion(workspaceID string) (*StateVersion, error) {
+	return c.GetCurrentStateVersionContext(context.Background(), workspaceID)
+}
+
+// GetCurrentStateVersionContext is like GetCurrentStateVersion but uses ctx for its API calls
+func (c *Client) GetCurrentStateVersionContext(ctx context.Context, workspaceID string) (*StateVersion, error) {
+	u := c.NewTfcUrl("/workspaces/" + workspaceID + "/current-state-version")
+
+	resp, err := c.callAPI(ctx, http.MethodGet, u.String(), "", nil)
+	if errors.Is(err, ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var svData struct {
+		Data StateVersion `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&svData); err != nil {
+		return nil, fmt.Errorf("unexpected content retrieving current state version: %w", err)
+	}
+	return &svData.Data, nil
+}
+
+// DownloadState returns the content of a state file, given the hosted-state-download-url of a state version
+func (c *Client) DownloadState(url string) ([]byte, error) {
+	return c.DownloadStateContext(context.Background(), url)
+}
+
+// DownloadStateContext is like DownloadState but uses ctx for its API calls
+func (c *Client) DownloadStateContext(ctx context.Context, url string) ([]byte, error) {
+	resp, err := c.callAPI(ctx, http.MethodGet, url, "", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	state, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error downloading state: %w", err)
+	}
+	return state, nil
+}
+
+// CreateStateVersion uploads a state file to a workspace, which must be locked by the caller. The serial and lineage
+// are taken from the state file itself.
+// https://developer.hashicorp.com/terraform/cloud-docs/api-docs/state-versions#create-a-state-version
+func (c *Client) CreateStateVersion(workspaceID string, state []byte) (StateVersion, error) {
+	return c.CreateStateVersionContext(context.Background(), workspaceID, state)
+}
+
+// CreateStateVersionContext is like CreateStateVersion but uses ctx for its API calls
+func (c *Client) CreateStateVersionContext(ctx context.Context, workspaceID string, state []byte) (StateVersion, error) {
+	s, err := parseStateFile(state)
+	if err != nil {
+		return StateVersion{}, err
+	}
+
+	data := gabs.New()
+	_, _ = data.SetP("state-versions", "data.type")
+	_, _ = data.SetP(s.Serial, "data.attributes.serial")
+	_, _ = data.SetP(s.Lineage, "data.attributes.lineage")
+	_, _ = data.SetP(stateMD5(state), "data.attributes.md5")
+	_, _ = data.SetP(base64.StdEncoding.EncodeToString(state), "data.attributes.state")
+
+	if c.debug {
+		fmt.Printf("uploading state version with serial %d and lineage %s\n", s.Serial, s.Lineage)
+	}
+	if c.readOnly {
+		return StateVersion{}, nil
+	}
+
+	u := c.NewTfcUrl("/workspaces/" + workspaceID + "/state-versions")
+	resp, err := c.callAPI(ctx, http.MethodPost, u.String(), data.String(), nil)
+	if err != nil {
+		return StateVersion{}, err
+	}
+	defer resp.Body.Close()
+
+	var svData struct {
+		Data StateVersion `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&svData); err != nil {
+		return StateVersion{}, fmt.Errorf("error getting created state version data: %w", err)
+	}
+	return svData.Data, nil
+}
+
+// LockWorkspace locks a workspace, so that no runs or state changes can be made by anyone else
+// https://developer.hashicorp.com/terraform/cloud-docs/api-docs/workspaces#lock-a-workspace
+func (c *Client) LockWorkspace(workspaceID, reason string) error {
+	return c.LockWorkspaceContext(context.Background(), workspaceID, reason)
+}
+
+// LockWorkspaceContext is like LockWorkspace but uses ctx for its API calls
+func (c *Client) LockWorkspaceContext(ctx context.Context, workspaceID, reason string) error {
+	data := gabs.New()
+	_, _ = data.Set(reason, "reason")
+	return c.workspaceAction(ctx, workspaceID, "lock", data.String())
+}
+
+// UnlockWorkspace unlocks a workspace that was locked by the same user
+func (c *Client) UnlockWorkspace(workspaceID string) error {
+	return c.UnlockWorkspaceContext(context.Background(), workspaceID)
+}
+
+// UnlockWorkspaceContext is like UnlockWorkspace but uses ctx for its API calls
+func (c *Client) UnlockWorkspaceContext(ctx context.Context, workspaceID string) error {
+	return c.workspaceAction(ctx, workspaceID, "unlock", "")
+}
+
+// workspaceAction calls one of the workspace action endpoints, e.g. "lock"
+func (c *Client) workspaceAction(ctx context.Context, workspaceID, action, postData string) error {
+	if c.readOnly {
+		return nil
+	}
+
+	u := c.NewTfcUrl(fmt.Sprintf("/workspaces/%s/actions/%s", workspaceID, action))
+	resp, err := c.callAPI(ctx, http.MethodPost, u.String(), postData, nil)
+	if err != nil {
+		return fmt.Errorf("failed to %s workspace %s: %w", action, workspaceID, err)
+	}
+	return resp.Body.Close()
+}
+
+// CopyState copies the current state of a workspace to another workspace, which may be in a different organization
+// or account. The destination client makes the API calls for the destination workspace. The destination workspace is
+// locked while the state is uploaded, and the upload is verified by reading back its current state. Nothing is done
+// if the source workspace has no state.
+func (c *Client) CopyState(sourceWorkspaceID string, destination *Client, destinationWorkspaceID string) error {
+	return c.CopyStateContext(context.Background(), sourceWorkspaceID, destination, destinationWorkspaceID)
+}
+
+// CopyStateContext is like CopyState but uses ctx for its API calls
+func (c *Client) CopyStateContext(ctx context.Context, sourceWorkspaceID string, destination *Client, destinationWorkspaceID string) error {
+	sv, err := c.GetCurrentStateVersionContext(ctx, sourceWorkspaceID)
+	if err != nil {
+		return fmt.Errorf("error getting current state version of %s: %w", sourceWorkspaceID, err)
+	}
+	if sv == nil {
+		return nil
+	}
+
+	state, err := c.DownloadStateContext(ctx, sv.Attributes.HostedStateDownloadURL)
+	if err != nil {
+		return err
+	}
+
+	if destination.readOnly {
+		return nil
+	}
+	return destination.uploadState(ctx, destinationWorkspaceID, state,
+		"tfc-ops is copying state from workspace "+sourceWorkspaceID)
+}
+
+// uploadState locks a workspace, uploads a state file to it, verifies the upload and unlocks the workspace
+func (c *Client) uploadState(ctx context.Context, workspaceID string, state []byte, reason string) (err error) {
+	s, err := parseStateFile(state)
+	if err != nil {
+		return err
+	}
+
+	if err := c.LockWorkspaceContext(ctx, workspaceID, reason); err != nil {
+		return err
+	}
+	defer func() {
+		// don't use ctx, so that the workspace isn't left locked if it was canceled
+		if unlockErr := c.UnlockWorkspaceContext(context.Background(), workspaceID); err == nil {
+			err = unlockErr
+		}
+	}()
+
+	if _, err := c.CreateStateVersionContext(ctx, workspaceID, state); err != nil {
+		return fmt.Errorf("error uploading state to %s: %w", workspaceID, err)
+	}
+	return c.verifyState(ctx, workspaceID, s, stateMD5(state))
+}
+
+// verifyState checks that the current state of a workspace has the given serial, lineage and MD5 checksum
+func (c *Client) verifyState(ctx context.Context, workspaceID string, want stateFile, wantMD5 string) error {
+	sv, err := c.GetCurrentStateVersionContext(ctx, workspaceID)
+	if err != nil {
+		return fmt.Errorf("error verifying state of %s: %w", workspaceID, err)
+	}
+	if sv == nil {
+		return fmt.Errorf("state of %s was not saved", workspaceID)
+	}
+	if sv.Attributes.Serial != want.Serial {
+		return fmt.Errorf("state of %s has serial %d, expected %d", workspaceID, sv.Attributes.Serial, want.Serial)
+	}
+
+	state, err := c.DownloadStateContext(ctx, sv.Attributes.HostedStateDownloadURL)
+	if err != nil {
+		return fmt.Errorf("error verifying state of %s: %w", workspaceID, err)
+	}
+	got, err := parseStateFile(state)
+	if err != nil {
+		return fmt.Errorf("error verifying state of %s: %w", workspaceID, err)
+	}
+	if got.Lineage != want.Lineage {
+		return fmt.Errorf("state of %s has lineage %s, expected %s", workspaceID, got.Lineage, want.Lineage)
+	}
+	if gotMD5 := stateMD5(state); gotMD5 != wantMD5 {
+		return fmt.Errorf("state of %s has MD5 checksum %s, expected %s", workspaceID, gotMD5, wantMD5)
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package lib
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Jeffail/gabs/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testState = `{"version":4,"serial":7,"lineage":"lineage-1","outputs":{},"resources":[]}`
+
+// newStateServer returns a fake API that keeps the state of each workspace in states and records the calls made to it
+func newStateServer(t *testing.T, states map[string][]byte, calls *[]string) *httptest.Server {
+	var server *httptest.Server
+	server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*calls = append(*calls, r.Method+" "+strings.TrimPrefix(r.URL.Path, apiPath))
+		parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, apiPath), "/"), "/")
+
+		switch {
+		case parts[0] == "state":
+			_, _ = w.Write(states[parts[1]])
+		case len(parts) == 3 && parts[2] == "current-state-version":
+			state, ok := states[parts[1]]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			s, err := parseStateFile(state)
+			assert.NoError(t, err)
+			fmt.Fprintf(w, `{"data":{"id":"sv-1","attributes":{"serial":%d,"hosted-state-download-url":"%s/state/%s"}}}`,
+				s.Serial, server.URL, parts[1])
+		case len(parts) == 3 && parts[2] == "state-versions":
+			body, _ := io.ReadAll(r.Body)
+			data, err := gabs.ParseJSON(body)
+			if !assert.NoError(t, err) {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			attributes := data.Path("data.attributes")
+			encoded, _ := attributes.Path("state").Data().(string)
+			state, err := base64.StdEncoding.DecodeString(encoded)
+			assert.NoError(t, err)
+			assert.Equal(t, stateMD5(state), attributes.Path("md5").Data())
+			assert.Equal(t, "lineage-1", attributes.Path("lineage").Data())
+			assert.Equal(t, float64(7), attributes.Path("serial").Data())
+			states[parts[1]] = state
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"data":{"id":"sv-2"}}`))
+		case len(parts) == 4 && parts[2] == "actions":
+			_, _ = w.Write([]byte(`{}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	return server
+}
+
+func TestClient_CopyState(t *testing.T) {
+	states := map[string][]byte{"ws-source": []byte(testState)}
+	var calls []string
+	server := newStateServer(t, states, &calls)
+	defer server.Close()
+
+	c := newTestClient(server, "source-token")
+	require.NoError(t, c.CopyState("ws-source", c.WithToken("destination-token"), "ws-new"))
+	require.Equal(t, testState, string(states["ws-new"]))
+	require.Equal(t, []string{
+		"GET /workspaces/ws-source/current-state-version",
+		"GET /state/ws-source",
+		"POST /workspaces/ws-new/actions/lock",
+		"POST /workspaces/ws-new/state-versions",
+		"GET /workspaces/ws-new/current-state-version",
+		"GET /state/ws-new",
+		"POST /workspaces/ws-new/actions/unlock",
+	}, calls)
+}
+
+func TestClient_CopyStateNoState(t *testing.T) {
+	states := map[string][]byte{}
+	var calls []string
+	server := newStateServer(t, states, &calls)
+	defer server.Close()
+
+	c := newTestClient(server, "token")
+	require.NoError(t, c.CopyState("ws-source", c, "ws-new"))
+	require.Equal(t, []string{"GET /workspaces/ws-source/current-state-version"}, calls)
+}
+
+func TestClient_CopyStateUnlocksOnError(t *testing.T) {
+	var calls []string
+	var server *httptest.Server
+	server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+strings.TrimPrefix(r.URL.Path, apiPath))
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/state-versions"):
+			w.WriteHeader(http.StatusUnprocessableEntity)
+		case strings.HasSuffix(r.URL.Path, "/current-state-version"):
+			fmt.Fprintf(w, `{"data":{"attributes":{"serial":7,"hosted-state-download-url":"%s/state"}}}`, server.URL)
+		default:
+			_, _ = w.Write([]byte(testState))
+		}
+	}))
+	defer server.Close()
+
+	c := newTestClient(server, "token")
+	err := c.CopyState("ws-source", c, "ws-new")
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	require.Equal(t, http.StatusUnprocessableEntity, apiErr.StatusCode)
+	require.Equal(t, "POST /workspaces/ws-new/actions/unlock", calls[len(calls)-1])
+}
+
+func Test_parseStateFile(t *testing.T) {
+	s, err := parseStateFile([]byte(testState))
+	require.NoError(t, err)
+	require.Equal(t, stateFile{Serial: 7, Lineage: "lineage-1"}, s)
+
+	_, err = parseStateFile([]byte(`{"serial":7}`))
+	require.ErrorContains(t, err, "no lineage")
+
+	_, err = parseStateFile([]byte(`not json`))
+	require.ErrorContains(t, err, "invalid state file")
+}
@@ -5,7 +5,10 @@ import (
 )
 
 const (
-	baseURL = "https://app.terraform.io/api/v2"
+	// DefaultHostname is the hostname of HCP Terraform (Terraform Cloud)
+	DefaultHostname = "app.terraform.io"
+
+	apiPath = "/api/v2"
 
 	pageSize = 20
 
@@ -16,15 +19,21 @@ const (
 	paramPageNumber             = "page[number]"
 	paramFilterRunTriggerType   = "filter[run-trigger][type]"
 	paramSearchName             = "search[name]"
+	paramFilterNames            = "filter[names]"
 )
 
 type TfcUrl struct {
 	url.URL
 }
 
-// NewTfcUrl creates a url.URL object for the Terraform Cloud API.
+// NewTfcUrl creates a url.URL object for the Terraform Cloud API on the hostname of the default client.
 func NewTfcUrl(path string) TfcUrl {
-	newURL, _ := url.Parse(baseURL + path)
+	return defaultClient.NewTfcUrl(path)
+}
+
+// NewTfcUrl creates a url.URL object for the Terraform Cloud API on the client's hostname.
+func (c *Client) NewTfcUrl(path string) TfcUrl {
+	newURL, _ := url.Parse(c.baseURL() + path)
 	v := url.Values{}
 	newURL.RawQuery = v.Encode()
 	tfcUrl := TfcUrl{
@@ -38,3 +47,8 @@ func (t *TfcUrl) SetParam(name, value string) {
 	values.Set(name, value)
 	t.RawQuery = values.Encode()
 }
+
+// baseURL returns the root of the API for the client's hostname
+func (c *Client) baseURL() string {
+	return "https://" + c.hostname + apiPath
+}
@@ -8,8 +8,13 @@ import (
 
 func TestNewTfcUrl(t *testing.T) {
 	orgs := NewTfcUrl("/organizations")
-	require.Equal(t, baseURL+"/organizations", orgs.String())
+	require.Equal(t, "https://app.terraform.io/api/v2/organizations", orgs.String())
 
 	withQuery := NewTfcUrl("/organizations?q=foo")
-	require.Equal(t, baseURL+"/organizations", withQuery.String())
+	require.Equal(t, "https://app.terraform.io/api/v2/organizations", withQuery.String())
+
+	SetHostname("tfe.example.com")
+	defer SetHostname("")
+	tfe := NewTfcUrl("/organizations")
+	require.Equal(t, "https://tfe.example.com/api/v2/organizations", tfe.String())
 }
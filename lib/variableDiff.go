@@ -0,0 +1,152 @@
+package lib
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// Status of a variable in a VariableDiff
+const (
+	VariableOnlyInFrom = "only-in-from"
+	VariableOnlyInTo   = "only-in-to"
+	VariableChanged    = "changed"
+
+	// VariableUnknown is the status of a variable that is sensitive in either workspace and has no other
+	// differences. The value of a sensitive variable can't be read, so it is not known whether it differs.
+	VariableUnknown = "unknown"
+)
+
+// VariableDiff is a difference in one variable between two workspaces
+type VariableDiff struct {
+	Key      string   `json:"key" yaml:"key"`
+	Category string   `json:"category" yaml:"category"`
+	Status   string   `json:"status" yaml:"status"`
+	Changes  []string `json:"changes,omitempty" yaml:"changes,omitempty"` // e.g. `value: "a" -> "b"`
+}
+
+// HasVariableDifferences returns true if any of diffs is a known difference, i.e. not only an unknown sensitive value
+func HasVariableDifferences(diffs []VariableDiff) bool {
+	for _, d := range diffs {
+		if d.Status != VariableUnknown {
+			return true
+		}
+	}
+	return false
+}
+
+// DiffWorkspaceVariables compares the variables of two workspaces, which may be in different organizations
+func (c *Client) DiffWorkspaceVariables(fromOrg, fromWorkspace, toOrg, toWorkspace string) ([]VariableDiff, error) {
+	return c.DiffWorkspaceVariablesContext(context.Background(), fromOrg, fromWorkspace, toOrg, toWorkspace)
+}
+
+// DiffWorkspaceVariablesContext is like DiffWorkspaceVariables but uses ctx for its API calls
+func (c *Client) DiffWorkspaceVariablesContext(ctx context.Context, fromOrg, fromWorkspace, toOrg, toWorkspace string) ([]VariableDiff, error) {
+	from, err := c.GetVarsFromWorkspaceContext(ctx, fromOrg, fromWorkspace)
+	if err != nil {
+		return nil, err
+	}
+	to, err := c.GetVarsFromWorkspaceContext(ctx, toOrg, toWorkspace)
+	if err != nil {
+		return nil, err
+	}
+	return DiffVariables(from, to), nil
+}
+
+// DiffVariables compares two lists of variables, and returns the differences sorted by key and category. Variables
+// are matched by key and category. A variable that is in only one list is matched by key alone to a variable with a
+// different category in the other list, so that a change of category is reported as such.
+func DiffVariables(from, to []Var) []VariableDiff {
+	pairs, toUsed := pairVariables(from, to)
+
+	var diffs []VariableDiff
+	for i, f := range from {
+		if pairs[i] < 0 {
+			diffs = append(diffs, VariableDiff{Key: f.Key, Category: varCategory(f), Status: VariableOnlyInFrom})
+			continue
+		}
+		if d, ok := diffVariable(f, to[pairs[i]]); ok {
+			diffs = append(diffs, d)
+		}
+	}
+	for j, t := range to {
+		if !toUsed[j] {
+			diffs = append(diffs, VariableDiff{Key: t.Key, Category: varCategory(t), Status: VariableOnlyInTo})
+		}
+	}
+
+	sort.SliceStable(diffs, func(i, j int) bool {
+		if diffs[i].Key != diffs[j].Key {
+			return diffs[i].Key < diffs[j].Key
+		}
+		return diffs[i].Category < diffs[j].Category
+	})
+	return diffs
+}
+
+// pairVariables matches the variables in two lists, as described for DiffVariables. It returns the index in to of
+// the variable matching each variable in from, or -1 if there is none, and whether each variable in to was matched.
+func pairVariables(from, to []Var) ([]int, []bool) {
+	toUsed := make([]bool, len(to))
+	pairs := make([]int, len(from))
+	for i, f := range from {
+		pairs[i] = -1
+		for j, t := range to {
+			if !toUsed[j] && f.Key == t.Key && varCategory(f) == varCategory(t) {
+				pairs[i] = j
+				toUsed[j] = true
+				break
+			}
+		}
+	}
+	for i, f := range from {
+		if pairs[i] >= 0 {
+			continue
+		}
+		for j, t := range to {
+			if !toUsed[j] && f.Key == t.Key {
+				pairs[i] = j
+				toUsed[j] = true
+				break
+			}
+		}
+	}
+	return pairs, toUsed
+}
+
+// diffVariable describes the differences between two variables with the same key, and returns false if there are
+// none
+func diffVariable(from, to Var) (VariableDiff, bool) {
+	d := VariableDiff{Key: from.Key, Category: varCategory(from), Status: VariableChanged}
+	if varCategory(from) != varCategory(to) {
+		d.Changes = append(d.Changes, fmt.Sprintf("category: %s -> %s", varCategory(from), varCategory(to)))
+	}
+	if !from.Sensitive && !to.Sensitive && from.Value != to.Value {
+		d.Changes = append(d.Changes, fmt.Sprintf("value: %q -> %q", from.Value, to.Value))
+	}
+	if from.Sensitive != to.Sensitive {
+		d.Changes = append(d.Changes, fmt.Sprintf("sensitive: %t -> %t", from.Sensitive, to.Sensitive))
+	}
+	if from.Hcl != to.Hcl {
+		d.Changes = append(d.Changes, fmt.Sprintf("hcl: %t -> %t", from.Hcl, to.Hcl))
+	}
+	if from.Description != to.Description {
+		d.Changes = append(d.Changes, fmt.Sprintf("description: %q -> %q", from.Description, to.Description))
+	}
+
+	if from.Sensitive || to.Sensitive {
+		if len(d.Changes) == 0 {
+			d.Status = VariableUnknown
+		}
+		d.Changes = append(d.Changes, "value: unknown, cannot compare")
+	}
+	return d, len(d.Changes) > 0
+}
+
+// varCategory returns the category of a variable, which is "terraform" if it is not set
+func varCategory(v Var) string {
+	if v.Category == "" {
+		return "terraform"
+	}
+	return v.Category
+}
@@ -0,0 +1,50 @@
+package lib
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffVariables(t *testing.T) {
+	from := []Var{
+		{Key: "region", Value: "us-east-1", Category: "terraform"},
+		{Key: "same", Value: "1"},
+		{Key: "old", Value: "x", Category: "terraform"},
+		{Key: "TOKEN", Sensitive: true, Category: "env"},
+		{Key: "tags", Value: `{a="b"}`, Hcl: true, Category: "terraform"},
+		{Key: "moved", Value: "m", Category: "terraform"},
+		{Key: "secret", Sensitive: true, Category: "terraform"},
+	}
+	to := []Var{
+		{Key: "moved", Value: "m", Category: "env"},
+		{Key: "region", Value: "us-west-2", Category: "terraform", Description: "AWS region"},
+		{Key: "same", Value: "1", Category: "terraform"},
+		{Key: "new", Value: "y", Category: "env"},
+		{Key: "TOKEN", Sensitive: true, Category: "env"},
+		{Key: "tags", Value: `{a="b"}`, Category: "terraform"},
+		{Key: "secret", Value: "visible", Category: "terraform"},
+	}
+
+	diffs := DiffVariables(from, to)
+	require.Equal(t, []VariableDiff{
+		{Key: "TOKEN", Category: "env", Status: VariableUnknown, Changes: []string{"value: unknown, cannot compare"}},
+		{Key: "moved", Category: "terraform", Status: VariableChanged, Changes: []string{"category: terraform -> env"}},
+		{Key: "new", Category: "env", Status: VariableOnlyInTo},
+		{Key: "old", Category: "terraform", Status: VariableOnlyInFrom},
+		{Key: "region", Category: "terraform", Status: VariableChanged, Changes: []string{
+			`value: "us-east-1" -> "us-west-2"`, `description: "" -> "AWS region"`,
+		}},
+		{Key: "secret", Category: "terraform", Status: VariableChanged, Changes: []string{
+			"sensitive: true -> false", "value: unknown, cannot compare",
+		}},
+		{Key: "tags", Category: "terraform", Status: VariableChanged, Changes: []string{"hcl: true -> false"}},
+	}, diffs)
+	require.True(t, HasVariableDifferences(diffs))
+
+	diffs = DiffVariables(from[3:4], to[4:5])
+	require.Len(t, diffs, 1)
+	require.False(t, HasVariableDifferences(diffs))
+
+	require.Empty(t, DiffVariables(from[1:2], to[2:3]))
+}
@@ -0,0 +1,149 @@
+package lib
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// Scope of the variable set that a VariableValue comes from
+const (
+	VariableSetScopeWorkspace = "workspace" // applied to the workspace
+	VariableSetScopeProject   = "project"   // applied to the project of the workspace
+	VariableSetScopeGlobal    = "global"    // applied to all the workspaces in the organization
+)
+
+// VariableValue is one of the values of a variable in a workspace, and where it comes from
+type VariableValue struct {
+	Var
+	VariableSet string // name of the variable set, or empty for a workspace variable
+	Scope       string // scope of the variable set, e.g. VariableSetScopeProject
+	Priority    bool   // the variable set overrides workspace variables
+}
+
+// Source returns "workspace" for a workspace variable, or the name of the variable set
+func (v VariableValue) Source() string {
+	if v.VariableSet == "" {
+		return "workspace"
+	}
+	return v.VariableSet
+}
+
+// precedence returns the rank of v among the values of a variable: the value with the highest rank is used. Priority
+// variable sets override workspace variables, which override the other variable sets. Variable sets applied to the
+// workspace override those applied to its project, which override global variable sets.
+// https://developer.hashicorp.com/terraform/cloud-docs/workspaces/variables#precedence
+func (v VariableValue) precedence() int {
+	if v.VariableSet == "" {
+		return 3
+	}
+	rank := map[string]int{VariableSetScopeGlobal: 0, VariableSetScopeProject: 1, VariableSetScopeWorkspace: 2}[v.Scope]
+	if v.Priority {
+		rank += 4
+	}
+	return rank
+}
+
+// EffectiveVariable holds the values of a variable with a given key and category in a workspace. Values are in order
+// of precedence: the first is the value that runs receive, and shadows all the others.
+type EffectiveVariable struct {
+	Key      string
+	Category string
+	Values   []VariableValue
+}
+
+// GetEffectiveVariables returns the variables that runs in a workspace receive, from the workspace and from the
+// variable sets applied to it, with the values that they shadow. They are sorted by key and category.
+func (c *Client) GetEffectiveVariables(organization, wsName string) ([]EffectiveVariable, error) {
+	return c.GetEffectiveVariablesContext(context.Background(), organization, wsName)
+}
+
+// GetEffectiveVariablesContext is like GetEffectiveVariables but uses ctx for its API calls
+func (c *Client) GetEffectiveVariablesContext(ctx context.Context, organization, wsName string) ([]EffectiveVariable, error) {
+	ws, err := c.GetWorkspaceByNameContext(ctx, organization, wsName)
+	if err != nil {
+		return nil, fmt.Errorf("error getting workspace %s: %w", wsName, err)
+	}
+	wsVars, err := c.GetVarsFromWorkspaceContext(ctx, organization, wsName)
+	if err != nil {
+		return nil, err
+	}
+	sets, err := c.ListWorkspaceVariableSetsContext(ctx, ws.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting the variable sets of %s: %w", wsName, err)
+	}
+
+	setVars := map[string][]Var{}
+	for _, vs := range sets.Data {
+		if setVars[vs.ID], err = c.ListVariableSetVariablesContext(ctx, vs.ID); err != nil {
+			return nil, fmt.Errorf("error getting the variables of variable set %s: %w", vs.Attributes.Name, err)
+		}
+	}
+	return EffectiveVariables(ws.ID, wsVars, sets.Data, setVars), nil
+}
+
+// EffectiveVariables merges the variables of a workspace with those of the variable sets applied to it, given as a
+// map of variable lists with the variable set ID as the key. Variables are matched by key and category. The result
+// is sorted by key and category.
+func EffectiveVariables(workspaceID string, wsVars []Var, sets []VariableSet, setVars map[string][]Var) []EffectiveVariable {
+	var values []VariableValue
+	for _, v := range wsVars {
+		values = append(values, VariableValue{Var: v})
+	}
+	for _, vs := range sets {
+		scope := variableSetScope(vs, workspaceID)
+		for _, v := range setVars[vs.ID] {
+			values = append(values, VariableValue{
+				Var:         v,
+				VariableSet: vs.Attributes.Name,
+				Scope:       scope,
+				Priority:    vs.Attributes.Priority,
+			})
+		}
+	}
+
+	// Among variable sets of the same rank, the one with the lexically first name is used
+	sort.SliceStable(values, func(i, j int) bool {
+		if values[i].precedence() != values[j].precedence() {
+			return values[i].precedence() > values[j].precedence()
+		}
+		return values[i].VariableSet < values[j].VariableSet
+	})
+
+	var effective []EffectiveVariable
+	index := map[[2]string]int{}
+	for _, v := range values {
+		id := [2]string{v.Key, varCategory(v.Var)}
+		i, ok := index[id]
+		if !ok {
+			i = len(effective)
+			index[id] = i
+			effective = append(effective, EffectiveVariable{Key: v.Key, Category: varCategory(v.Var)})
+		}
+		effective[i].Values = append(effective[i].Values, v)
+	}
+
+	sort.Slice(effective, func(i, j int) bool {
+		if effective[i].Key != effective[j].Key {
+			return effective[i].Key < effective[j].Key
+		}
+		return effective[i].Category < effective[j].Category
+	})
+	return effective
+}
+
+// variableSetScope returns the scope of a variable set applied to a workspace
+func variableSetScope(vs VariableSet, workspaceID string) string {
+	if vs.Attributes.Global {
+		return VariableSetScopeGlobal
+	}
+	for _, ws := range vs.Relationships.Workspaces.Data {
+		if ws.ID == workspaceID {
+			return VariableSetScopeWorkspace
+		}
+	}
+	if len(vs.Relationships.Projects.Data) > 0 {
+		return VariableSetScopeProject
+	}
+	return VariableSetScopeWorkspace
+}
@@ -0,0 +1,75 @@
+package lib
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestVariableSet(id, name string, global, priority bool, workspaceIDs, projectIDs []string) VariableSet {
+	vs := VariableSet{ID: id}
+	vs.Attributes.Name = name
+	vs.Attributes.Global = global
+	vs.Attributes.Priority = priority
+	for _, wsID := range workspaceIDs {
+		vs.Relationships.Workspaces.Data = append(vs.Relationships.Workspaces.Data, struct {
+			ID   string `json:"id"`
+			Type string `json:"type"`
+		}{ID: wsID, Type: "workspaces"})
+	}
+	for _, prjID := range projectIDs {
+		vs.Relationships.Projects.Data = append(vs.Relationships.Projects.Data, struct {
+			ID   string `json:"id"`
+			Type string `json:"type"`
+		}{ID: prjID, Type: "projects"})
+	}
+	return vs
+}
+
+func TestEffectiveVariables(t *testing.T) {
+	sets := []VariableSet{
+		newTestVariableSet("vs-global", "a-global", true, false, nil, nil),
+		newTestVariableSet("vs-project", "b-project", false, false, nil, []string{"prj-1"}),
+		newTestVariableSet("vs-direct", "z-direct", false, false, []string{"ws-1"}, nil),
+		newTestVariableSet("vs-other", "c-direct", false, false, []string{"ws-1", "ws-2"}, nil),
+		newTestVariableSet("vs-priority", "p-global", true, true, nil, nil),
+	}
+	setVars := map[string][]Var{
+		"vs-global":   {{Key: "region", Value: "global"}, {Key: "REGION", Value: "env", Category: "env"}},
+		"vs-project":  {{Key: "region", Value: "project", Category: "terraform"}, {Key: "size", Value: "small"}},
+		"vs-direct":   {{Key: "size", Value: "z"}},
+		"vs-other":    {{Key: "size", Value: "c"}},
+		"vs-priority": {{Key: "owner", Value: "ops"}},
+	}
+	wsVars := []Var{
+		{Key: "region", Value: "workspace", Category: "terraform"},
+		{Key: "owner", Value: "dev", Category: "terraform"},
+	}
+
+	got := EffectiveVariables("ws-1", wsVars, sets, setVars)
+
+	type summary struct {
+		Key, Category string
+		Sources       []string
+	}
+	var summaries []summary
+	for _, v := range got {
+		s := summary{Key: v.Key, Category: v.Category}
+		for _, value := range v.Values {
+			s.Sources = append(s.Sources, value.Source()+"="+value.Value)
+		}
+		summaries = append(summaries, s)
+	}
+	require.Equal(t, []summary{
+		{Key: "REGION", Category: "env", Sources: []string{"a-global=env"}},
+		{Key: "owner", Category: "terraform", Sources: []string{"p-global=ops", "workspace=dev"}},
+		{Key: "region", Category: "terraform", Sources: []string{"workspace=workspace", "b-project=project", "a-global=global"}},
+		{Key: "size", Category: "terraform", Sources: []string{"c-direct=c", "z-direct=z", "b-project=small"}},
+	}, summaries)
+
+	require.Equal(t, VariableSetScopeGlobal, got[1].Values[0].Scope)
+	require.True(t, got[1].Values[0].Priority)
+	require.Equal(t, VariableSetScopeProject, got[2].Values[1].Scope)
+	require.Equal(t, VariableSetScopeWorkspace, got[3].Values[0].Scope)
+	require.Empty(t, EffectiveVariables("ws-1", nil, nil, nil))
+}
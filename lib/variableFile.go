@@ -0,0 +1,602 @@
+package lib
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Formats of a file of variables
+const (
+	// VariableFormatTFVars is a Terraform .tfvars file, which holds terraform variables
+	VariableFormatTFVars = "tfvars"
+
+	// VariableFormatEnv is a dotenv file of "KEY=value" lines, which holds environment variables
+	VariableFormatEnv = "env"
+
+	// VariableFormatJSON is a JSON array of variables of both categories, with all their settings
+	VariableFormatJSON = "json"
+)
+
+// VariableFormats lists the formats of a file of variables
+var VariableFormats = []string{VariableFormatTFVars, VariableFormatEnv, VariableFormatJSON}
+
+// validateVariableFormat returns an error if format is not one of VariableFormats
+func validateVariableFormat(format string) error {
+	if !isOneOf(format, VariableFormats) {
+		return fmt.Errorf("invalid variable file format '%s', must be one of: %s", format, strings.Join(VariableFormats, ", "))
+	}
+	return nil
+}
+
+// WriteVariables writes variables to w in the given format. A tfvars file only gets the terraform variables, and an
+// env file only gets the environment variables. The value of a sensitive variable can't be read, so it is written as
+// a comment, or with PlaceholderValue as its value if placeholders is true. Variables are sorted by key.
+func WriteVariables(w io.Writer, format string, vars []Var, placeholders bool) error {
+	if err := validateVariableFormat(format); err != nil {
+		return err
+	}
+
+	sorted := make([]Var, len(vars))
+	copy(sorted, vars)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Key < sorted[j].Key })
+
+	if format == VariableFormatJSON {
+		records := []Var{}
+		for _, v := range sorted {
+			v.Category = varCategory(v)
+			if v.Sensitive && placeholders {
+				v.Value = PlaceholderValue
+			}
+			records = append(records, v)
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(records)
+	}
+
+	bw := bufio.NewWriter(w)
+	for _, v := range sorted {
+		if (varCategory(v) == "env") != (format == VariableFormatEnv) {
+			continue
+		}
+		if v.Sensitive && !placeholders {
+			fmt.Fprintf(bw, "# %s is sensitive, its value can't be exported\n", v.Key)
+			continue
+		}
+		value := v.Value
+		if v.Sensitive {
+			value = PlaceholderValue
+		}
+		if format == VariableFormatEnv {
+			fmt.Fprintf(bw, "%s=%s\n", v.Key, dotenvValue(value))
+		} else if v.Hcl && !v.Sensitive {
+			fmt.Fprintf(bw, "%s = %s\n", v.Key, value)
+		} else {
+			fmt.Fprintf(bw, "%s = %s\n", v.Key, hclString(value))
+		}
+	}
+	return bw.Flush()
+}
+
+// dotenvPlain matches a value that can be written in a dotenv file without quotes
+var dotenvPlain = regexp.MustCompile(`^[A-Za-z0-9_./:@%+,=-]*$`)
+
+var dotenvReplacer = strings.NewReplacer(
+	`\`, `\\`,
+	`"`, `\"`,
+	"\n", `\n`,
+	"\r", `\r`,
+	"\t", `\t`,
+	"$", `\$`,
+)
+
+// dotenvValue returns s as a value in a dotenv file, quoted if necessary
+func dotenvValue(s string) string {
+	if dotenvPlain.MatchString(s) {
+		return s
+	}
+	return `"` + dotenvReplacer.Replace(s) + `"`
+}
+
+// ReadVariables reads variables from r in the given format. Variables in a tfvars file are in the terraform
+// category, and those in an env file are in the env category. A value in a tfvars file that is not a string, number
+// or bool, such as a list or map, is read as an HCL variable.
+func ReadVariables(r io.Reader, format string) ([]Var, error) {
+	if err := validateVariableFormat(format); err != nil {
+		return nil, err
+	}
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var vars []Var
+	switch format {
+	case VariableFormatJSON:
+		err = json.Unmarshal(data, &vars)
+		for i := range vars {
+			vars[i].Category = varCategory(vars[i])
+		}
+	case VariableFormatEnv:
+		vars, err = parseDotenv(string(data))
+	case VariableFormatTFVars:
+		vars, err = parseTFVars(string(data))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s file: %w", format, err)
+	}
+
+	seen := map[string]bool{}
+	for _, v := range vars {
+		if v.Key == "" {
+			return nil, fmt.Errorf("invalid %s file: variable with no key", format)
+		}
+		if seen[v.Category+":"+v.Key] {
+			return nil, fmt.Errorf("invalid %s file: variable %s is given more than once", format, v.Key)
+		}
+		seen[v.Category+":"+v.Key] = true
+	}
+	return vars, nil
+}
+
+// varParser reads a file one character at a time
+type varParser struct {
+	s    string
+	pos  int
+	line int
+}
+
+func (p *varParser) done() bool {
+	return p.pos >= len(p.s)
+}
+
+func (p *varParser) peek() byte {
+	if p.done() {
+		return 0
+	}
+	return p.s[p.pos]
+}
+
+func (p *varParser) next() byte {
+	b := p.s[p.pos]
+	p.pos++
+	if b == '\n' {
+		p.line++
+	}
+	return b
+}
+
+func (p *varParser) hasPrefix(prefix string) bool {
+	return strings.HasPrefix(p.s[p.pos:], prefix)
+}
+
+// skipLine skips to the start of the next line
+func (p *varParser) skipLine() {
+	for !p.done() && p.next() != '\n' {
+	}
+}
+
+// skipSpace skips spaces and tabs, but not newlines
+func (p *varParser) skipSpace() {
+	for p.peek() == ' ' || p.peek() == '\t' || p.peek() == '\r' {
+		p.next()
+	}
+}
+
+func (p *varParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("line %d: %s", p.line+1, fmt.Sprintf(format, args...))
+}
+
+// parseDotenv reads "KEY=value" lines. A value can be in double quotes, with backslash escapes, or in single quotes,
+// used literally. Either kind of quoted value can span several lines. Lines starting with "#" are comments, and
+// "export " before a key is ignored.
+func parseDotenv(s string) ([]Var, error) {
+	p := &varParser{s: s}
+	var vars []Var
+	for !p.done() {
+		p.skipSpace()
+		if p.peek() == '#' || p.peek() == '\n' || p.done() {
+			p.skipLine()
+			continue
+		}
+		if p.hasPrefix("export ") {
+			p.pos += len("export ")
+			p.skipSpace()
+		}
+
+		start := p.pos
+		for !p.done() && p.peek() != '=' && p.peek() != '\n' {
+			p.next()
+		}
+		if p.peek() != '=' {
+			return nil, p.errorf("expected KEY=value")
+		}
+		key := strings.TrimSpace(p.s[start:p.pos])
+		p.next()
+		p.skipSpace()
+
+		var value string
+		var err error
+		switch p.peek() {
+		case '"':
+			value, err = p.quoted('"', true)
+		case '\'':
+			value, err = p.quoted('\'', false)
+		default:
+			start := p.pos
+			for !p.done() && p.peek() != '\n' && !(p.peek() == '#' && p.pos > start && (p.s[p.pos-1] == ' ' || p.s[p.pos-1] == '\t')) {
+				p.next()
+			}
+			value = strings.TrimSpace(p.s[start:p.pos])
+		}
+		if err != nil {
+			return nil, err
+		}
+		vars = append(vars, Var{Key: key, Value: value, Category: "env"})
+
+		p.skipSpace()
+		if !p.done() && p.peek() != '\n' && p.peek() != '#' {
+			return nil, p.errorf("unexpected text after the value of %s", key)
+		}
+		p.skipLine()
+	}
+	return vars, nil
+}
+
+// quoted reads a quoted string, starting at the opening quote. If escapes is true, the escapes used by dotenvValue
+// are replaced.
+func (p *varParser) quoted(quote byte, escapes bool) (string, error) {
+	line := p.line
+	p.next()
+	var sb strings.Builder
+	for !p.done() {
+		b := p.next()
+		switch {
+		case b == quote:
+			return sb.String(), nil
+		case b == '\\' && escapes && !p.done():
+			e := p.next()
+			switch e {
+			case 'n':
+				sb.WriteByte('\n')
+			case 'r':
+				sb.WriteByte('\r')
+			case 't':
+				sb.WriteByte('\t')
+			default:
+				sb.WriteByte(e)
+			}
+		default:
+			sb.WriteByte(b)
+		}
+	}
+	return "", fmt.Errorf("line %d: no closing quote", line+1)
+}
+
+// tfvarsIdentifier matches the name of a variable in a tfvars file
+var tfvarsIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*`)
+
+// parseTFVars reads "key = value" attributes, as in a Terraform .tfvars file. A string, number or bool value is read
+// as the string it holds, and any other expression, such as a list, map or template, is read as the text of an HCL
+// value. Heredoc strings and comments are supported.
+func parseTFVars(s string) ([]Var, error) {
+	p := &varParser{s: s}
+	var vars []Var
+	for {
+		if err := p.skipSpaceAndComments(); err != nil {
+			return nil, err
+		}
+		if p.done() {
+			return vars, nil
+		}
+
+		key := tfvarsIdentifier.FindString(p.s[p.pos:])
+		if key == "" {
+			return nil, p.errorf("expected a variable name")
+		}
+		p.pos += len(key)
+		p.skipSpace()
+		if p.peek() != '=' {
+			return nil, p.errorf("expected '=' after %s", key)
+		}
+		p.next()
+		p.skipSpace()
+
+		v := Var{Key: key, Category: "terraform"}
+		var err error
+		if p.hasPrefix("<<") {
+			v.Value, err = p.heredoc()
+		} else {
+			v.Value, v.Hcl, err = p.expression()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+		vars = append(vars, v)
+	}
+}
+
+// skipSpaceAndComments skips whitespace, including newlines, and comments
+func (p *varParser) skipSpaceAndComments() error {
+	for !p.done() {
+		switch {
+		case p.peek() == ' ' || p.peek() == '\t' || p.peek() == '\r' || p.peek() == '\n':
+			p.next()
+		case p.peek() == '#' || p.hasPrefix("//"):
+			p.skipLine()
+		case p.hasPrefix("/*"):
+			end := strings.Index(p.s[p.pos+2:], "*/")
+			if end < 0 {
+				return p.errorf("unterminated comment")
+			}
+			for stop := p.pos + 2 + end + 2; p.pos < stop; {
+				p.next()
+			}
+		default:
+			return nil
+		}
+	}
+	return nil
+}
+
+// heredoc reads a heredoc string, starting at "<<"
+func (p *varParser) heredoc() (string, error) {
+	line := p.line
+	p.pos += 2
+	indented := p.peek() == '-'
+	if indented {
+		p.next()
+	}
+	marker := tfvarsIdentifier.FindString(p.s[p.pos:])
+	if marker == "" {
+		return "", p.errorf("expected a heredoc marker")
+	}
+	p.pos += len(marker)
+	p.skipSpace()
+	if p.peek() != '\n' {
+		return "", p.errorf("expected a new line after the heredoc marker")
+	}
+	p.next()
+
+	var lines []string
+	for !p.done() {
+		start := p.pos
+		p.skipLine()
+		l := strings.TrimRight(p.s[start:p.pos], "\r\n")
+		if strings.TrimSpace(l) == marker {
+			return heredocString(lines, indented), nil
+		}
+		lines = append(lines, l)
+	}
+	return "", fmt.Errorf("line %d: no closing %s for heredoc", line+1, marker)
+}
+
+// heredocString joins the lines of a heredoc. If indented is true, the indentation common to all lines is removed.
+func heredocString(lines []string, indented bool) string {
+	if indented {
+		indent := -1
+		for _, l := range lines {
+			if strings.TrimSpace(l) == "" {
+				continue
+			}
+			n := len(l) - len(strings.TrimLeft(l, " \t"))
+			if indent < 0 || n < indent {
+				indent = n
+			}
+		}
+		for i, l := range lines {
+			if len(l) >= indent && indent > 0 {
+				lines[i] = l[indent:]
+			}
+		}
+	}
+	if len(lines) == 0 {
+		return ""
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// expression reads a value up to the end of its line, or to the end of its last line if it has brackets that span
+// several lines. It returns the string held by a literal, or the text of any other expression with hcl true.
+func (p *varParser) expression() (string, bool, error) {
+	line := p.line
+	var sb strings.Builder
+	var closers []byte
+	for !p.done() {
+		b := p.peek()
+		switch {
+		case b == '"':
+			start := p.pos
+			if err := p.skipString(); err != nil {
+				return "", false, err
+			}
+			sb.WriteString(p.s[start:p.pos])
+			continue
+		case b == '#' || p.hasPrefix("//"):
+			p.skipLine()
+			if len(closers) == 0 {
+				return literalValue(strings.TrimSpace(sb.String()))
+			}
+			sb.WriteByte('\n')
+			continue
+		case b == '\n' && len(closers) == 0:
+			p.next()
+			return literalValue(strings.TrimSpace(sb.String()))
+		case b == '(' || b == '[' || b == '{':
+			closers = append(closers, map[byte]byte{'(': ')', '[': ']', '{': '}'}[b])
+		case b == ')' || b == ']' || b == '}':
+			if len(closers) == 0 || closers[len(closers)-1] != b {
+				return "", false, p.errorf("unexpected '%c'", b)
+			}
+			closers = closers[:len(closers)-1]
+		}
+		sb.WriteByte(p.next())
+	}
+	if len(closers) > 0 {
+		return "", false, fmt.Errorf("line %d: no closing '%c'", line+1, closers[len(closers)-1])
+	}
+	return literalValue(strings.TrimSpace(sb.String()))
+}
+
+// skipString skips a quoted HCL string, starting at the opening quote
+func (p *varParser) skipString() error {
+	line := p.line
+	p.next()
+	for !p.done() {
+		switch p.next() {
+		case '\\':
+			if !p.done() {
+				p.next()
+			}
+		case '"':
+			return nil
+		case '\n':
+			return fmt.Errorf("line %d: no closing quote", line+1)
+		}
+	}
+	return fmt.Errorf("line %d: no closing quote", line+1)
+}
+
+// tfvarsNumber matches a number in a tfvars file
+var tfvarsNumber = regexp.MustCompile(`^-?[0-9]+(\.[0-9]+)?([eE][+-]?[0-9]+)?$`)
+
+// literalValue returns the string held by an expression that is a string, number or bool literal, or else the
+// expression itself with hcl true
+func literalValue(expr string) (string, bool, error) {
+	if expr == "" {
+		return "", false, errors.New("no value")
+	}
+	if expr == "true" || expr == "false" || tfvarsNumber.MatchString(expr) {
+		return expr, false, nil
+	}
+	if s, ok := unquoteHCL(expr); ok {
+		return s, false, nil
+	}
+	return expr, true, nil
+}
+
+// unquoteHCL returns the string held by a quoted HCL string. It returns false if expr is not a single quoted string,
+// or if it is a template with interpolation.
+func unquoteHCL(expr string) (string, bool) {
+	if len(expr) < 2 || expr[0] != '"' || expr[len(expr)-1] != '"' {
+		return "", false
+	}
+	var sb strings.Builder
+	body := expr[1 : len(expr)-1]
+	for i := 0; i < len(body); i++ {
+		b := body[i]
+		switch {
+		case b == '"':
+			return "", false
+		case b == '\\' && i+1 < len(body):
+			i++
+			switch body[i] {
+			case 'n':
+				sb.WriteByte('\n')
+			case 'r':
+				sb.WriteByte('\r')
+			case 't':
+				sb.WriteByte('\t')
+			case 'u', 'U':
+				size := 4
+				if body[i] == 'U' {
+					size = 8
+				}
+				if i+size >= len(body) {
+					return "", false
+				}
+				r, err := strconv.ParseUint(body[i+1:i+1+size], 16, 32)
+				if err != nil {
+					return "", false
+				}
+				sb.WriteRune(rune(r))
+				i += size
+			default:
+				sb.WriteByte(body[i])
+			}
+		case (b == '$' || b == '%') && strings.HasPrefix(body[i+1:], string(b)+"{"):
+			sb.WriteString(string(b) + "{")
+			i += 2
+		case (b == '$' || b == '%') && strings.HasPrefix(body[i+1:], "{"):
+			return "", false
+		default:
+			sb.WriteByte(b)
+		}
+	}
+	return sb.String(), true
+}
+
+// PlanVariableImport compares variables read from a file in the given format with those of a workspace, and returns
+// the changes needed to create the new variables and update the others. The changes are made with
+// ApplyManifestPlan. Variables in the workspace that are not in the file are left as they are. Variables read from a
+// tfvars or env file have no description or sensitive setting, so those of an existing variable are kept. The keys of
+// variables with no value, i.e. PlaceholderValue or an empty sensitive value, are returned, and are not changed.
+func (c *Client) PlanVariableImport(organization, workspace, format string, vars []Var) (*ManifestPlan, []string, error) {
+	return c.PlanVariableImportContext(context.Background(), organization, workspace, format, vars)
+}
+
+// PlanVariableImportContext is like PlanVariableImport but uses ctx for its API calls
+func (c *Client) PlanVariableImportContext(ctx context.Context, organization, workspace, format string, vars []Var) (*ManifestPlan, []string, error) {
+	existing, err := c.GetVarsFromWorkspaceContext(ctx, organization, workspace)
+	if err != nil {
+		return nil, nil, err
+	}
+	changes, noValue := planVariableImport(organization, workspace, vars, existing, format != VariableFormatJSON)
+	return &ManifestPlan{Organization: organization, Changes: changes}, noValue, nil
+}
+
+// planVariableImport returns the changes needed to import vars into a workspace with the existing variables, and the
+// keys of the variables that have no value. If keepSettings is true, the description and sensitive setting of an
+// existing variable are kept.
+func planVariableImport(organization, workspace string, vars, existing []Var, keepSettings bool) ([]ManifestChange, []string) {
+	var changes []ManifestChange
+	var noValue []string
+	add := func(action, name, detail string, apply func(ctx context.Context, c *Client) error) {
+		changes = append(changes, variablePlanChange(workspace, action, name, detail, apply))
+	}
+
+	for _, v := range vars {
+		v := v
+		if v.Value == PlaceholderValue || (v.Sensitive && v.Value == "") {
+			noValue = append(noValue, v.Key)
+			continue
+		}
+
+		var old *Var
+		for i := range existing {
+			if existing[i].Key == v.Key && varCategory(existing[i]) == varCategory(v) {
+				old = &existing[i]
+				break
+			}
+		}
+		if old == nil {
+			add(ManifestActionCreate, v.Key, variableDetail(v), func(ctx context.Context, c *Client) error {
+				return c.CreateVariableContext(ctx, organization, workspace, v)
+			})
+			continue
+		}
+
+		if keepSettings {
+			v.Description = old.Description
+			v.Sensitive = old.Sensitive
+		}
+		d, changed := diffVariable(*old, v)
+		if !changed {
+			continue
+		}
+		id := old.ID
+		add(ManifestActionUpdate, v.Key, strings.Join(d.Changes, ", "), func(ctx context.Context, c *Client) error {
+			return c.UpdateVariableContext(ctx, organization, workspace, id, v)
+		})
+	}
+	return changes, noValue
+}
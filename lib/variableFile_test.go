@@ -0,0 +1,164 @@
+package lib
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var fileTestVars = []Var{
+	{Key: "region", Value: "us-east-1", Category: "terraform", Description: "AWS region"},
+	{Key: "tags", Value: "{\n  app = \"web\"\n}", Category: "terraform", Hcl: true},
+	{Key: "motd", Value: "say \"hi\"\n${name}", Category: "terraform"},
+	{Key: "db_password", Category: "terraform", Sensitive: true},
+	{Key: "AWS_PROFILE", Value: "prod", Category: "env"},
+	{Key: "GREETING", Value: "hello $USER\n# not a comment", Category: "env"},
+	{Key: "TOKEN", Category: "env", Sensitive: true},
+}
+
+func TestWriteVariables(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteVariables(&buf, VariableFormatTFVars, fileTestVars, false))
+	require.Equal(t, `# db_password is sensitive, its value can't be exported
+motd = "say \"hi\"\n$${name}"
+region = "us-east-1"
+tags = {
+  app = "web"
+}
+`, buf.String())
+
+	buf.Reset()
+	require.NoError(t, WriteVariables(&buf, VariableFormatEnv, fileTestVars, true))
+	require.Equal(t, `AWS_PROFILE=prod
+GREETING="hello \$USER\n# not a comment"
+TOKEN=REPLACE_THIS_VALUE
+`, buf.String())
+
+	buf.Reset()
+	require.NoError(t, WriteVariables(&buf, VariableFormatJSON, fileTestVars[4:5], false))
+	require.JSONEq(t, `[{"key":"AWS_PROFILE","value":"prod","description":"","sensitive":false,"category":"env","hcl":false}]`,
+		buf.String())
+
+	require.ErrorContains(t, WriteVariables(&buf, "yaml", nil, false), "invalid variable file format 'yaml'")
+}
+
+func TestReadVariables_roundTrip(t *testing.T) {
+	for _, format := range VariableFormats {
+		var buf bytes.Buffer
+		require.NoError(t, WriteVariables(&buf, format, fileTestVars, false))
+		got, err := ReadVariables(&buf, format)
+		require.NoError(t, err, format)
+
+		var want []Var
+		for _, v := range fileTestVars {
+			if v.Sensitive && format != VariableFormatJSON {
+				continue
+			}
+			if format != VariableFormatJSON {
+				if (v.Category == "env") != (format == VariableFormatEnv) {
+					continue
+				}
+				v.Description = ""
+			}
+			want = append(want, v)
+		}
+		require.ElementsMatch(t, want, got, format)
+	}
+}
+
+func TestReadVariables_tfvars(t *testing.T) {
+	vars, err := ReadVariables(strings.NewReader(`
+# a comment
+count   = 3 // another comment
+enabled = true
+name    = "web"
+/* a
+   block comment */
+zones = [
+  "a", # first
+  "b",
+]
+greeting = "hello ${var.name}"
+script = <<-EOT
+    echo "hi"
+      indented
+    EOT
+`), VariableFormatTFVars)
+	require.NoError(t, err)
+	require.Equal(t, []Var{
+		{Key: "count", Value: "3", Category: "terraform"},
+		{Key: "enabled", Value: "true", Category: "terraform"},
+		{Key: "name", Value: "web", Category: "terraform"},
+		{Key: "zones", Value: "[\n  \"a\", \n  \"b\",\n]", Category: "terraform", Hcl: true},
+		{Key: "greeting", Value: `"hello ${var.name}"`, Category: "terraform", Hcl: true},
+		{Key: "script", Value: "echo \"hi\"\n  indented\n", Category: "terraform"},
+	}, vars)
+
+	for input, msg := range map[string]string{
+		"name = \"web\nother = 1":  `name: line 1: no closing quote`,
+		"zones = [\"a\"":           `zones: line 1: no closing ']'`,
+		"= 1":                      `line 1: expected a variable name`,
+		"name \"web\"":             `line 1: expected '=' after name`,
+		"a = 1\na = 2":             `variable a is given more than once`,
+		"script = <<EOT\nno end\n": `script: line 1: no closing EOT for heredoc`,
+	} {
+		_, err := ReadVariables(strings.NewReader(input), VariableFormatTFVars)
+		require.ErrorContains(t, err, msg, input)
+	}
+}
+
+func TestReadVariables_env(t *testing.T) {
+	vars, err := ReadVariables(strings.NewReader(`# comment
+export AWS_PROFILE=prod
+EMPTY=
+PLAIN = some value # comment
+HASH=a#b
+SINGLE='$literal\n'
+MULTI="line one
+line two"
+`), VariableFormatEnv)
+	require.NoError(t, err)
+	require.Equal(t, []Var{
+		{Key: "AWS_PROFILE", Value: "prod", Category: "env"},
+		{Key: "EMPTY", Value: "", Category: "env"},
+		{Key: "PLAIN", Value: "some value", Category: "env"},
+		{Key: "HASH", Value: "a#b", Category: "env"},
+		{Key: "SINGLE", Value: `$literal\n`, Category: "env"},
+		{Key: "MULTI", Value: "line one\nline two", Category: "env"},
+	}, vars)
+
+	_, err = ReadVariables(strings.NewReader("NO_VALUE\n"), VariableFormatEnv)
+	require.ErrorContains(t, err, "line 1: expected KEY=value")
+	_, err = ReadVariables(strings.NewReader("A=\"x\" y\n"), VariableFormatEnv)
+	require.ErrorContains(t, err, "line 1: unexpected text after the value of A")
+}
+
+func Test_planVariableImport(t *testing.T) {
+	existing := []Var{
+		{ID: "var-1", Key: "region", Value: "us-east-1", Category: "terraform", Description: "AWS region"},
+		{ID: "var-2", Key: "TOKEN", Category: "env", Sensitive: true},
+		{ID: "var-3", Key: "same", Value: "s", Category: "terraform"},
+	}
+	vars := []Var{
+		{Key: "region", Value: "us-west-2", Category: "terraform"},
+		{Key: "TOKEN", Value: "secret", Category: "env"},
+		{Key: "same", Value: "s", Category: "terraform"},
+		{Key: "new", Value: "n", Category: "terraform"},
+		{Key: "unknown", Value: PlaceholderValue, Category: "terraform"},
+	}
+
+	changes, noValue := planVariableImport("org", "ws", vars, existing, true)
+	require.Equal(t, []string{"unknown"}, noValue)
+	require.Len(t, changes, 3)
+	require.Equal(t, ManifestChange{Workspace: "ws", Action: ManifestActionUpdate, Resource: ManifestResourceVariable,
+		Name: "region", Detail: `value: "us-east-1" -> "us-west-2"`}, withoutApply(changes[0]))
+	require.Equal(t, ManifestChange{Workspace: "ws", Action: ManifestActionUpdate, Resource: ManifestResourceVariable,
+		Name: "TOKEN", Detail: "value: unknown, cannot compare"}, withoutApply(changes[1]))
+	require.Equal(t, ManifestChange{Workspace: "ws", Action: ManifestActionCreate, Resource: ManifestResourceVariable,
+		Name: "new", Detail: `"n"`}, withoutApply(changes[2]))
+
+	changes, _ = planVariableImport("org", "ws", vars[:1], existing, false)
+	require.Equal(t, `value: "us-east-1" -> "us-west-2", description: "AWS region" -> ""`, changes[0].Detail)
+}
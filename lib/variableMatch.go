@@ -0,0 +1,194 @@
+package lib
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// VariableMatch selects variables by key, value, category and settings. Criteria that are not set match any
+// variable. A variable must match all the key criteria and all the value criteria, or, if Any is true, either all
+// the key criteria or all the value criteria. All the other criteria must always match.
+type VariableMatch struct {
+	KeyContains   string // case-sensitive
+	KeyRegex      string // regular expression, as used by the regexp package, e.g. "(?i)^aws_"
+	ValueContains string // case-sensitive
+	ValueRegex    string // regular expression, as used by the regexp package
+	Any           bool   // match the key criteria or the value criteria, rather than both
+
+	Category  string // "terraform" or "env", or empty to match both
+	Sensitive *bool  // if not nil, only match variables that are (or are not) sensitive
+	Hcl       *bool  // if not nil, only match variables that are (or are not) HCL
+}
+
+// variableMatcher is a VariableMatch with its regular expressions compiled
+type variableMatcher struct {
+	VariableMatch
+	keyRegex   *regexp.Regexp
+	valueRegex *regexp.Regexp
+}
+
+// compile returns an error if a regular expression or the category is invalid
+func (m VariableMatch) compile() (*variableMatcher, error) {
+	matcher := &variableMatcher{VariableMatch: m}
+	var err error
+	if m.KeyRegex != "" {
+		if matcher.keyRegex, err = regexp.Compile(m.KeyRegex); err != nil {
+			return nil, fmt.Errorf("invalid key regex: %w", err)
+		}
+	}
+	if m.ValueRegex != "" {
+		if matcher.valueRegex, err = regexp.Compile(m.ValueRegex); err != nil {
+			return nil, fmt.Errorf("invalid value regex: %w", err)
+		}
+	}
+	if m.Category != "" && m.Category != "terraform" && m.Category != "env" {
+		return nil, fmt.Errorf("invalid category '%s', must be 'terraform' or 'env'", m.Category)
+	}
+	return matcher, nil
+}
+
+// matches returns true if v matches all the criteria
+func (m *variableMatcher) matches(v Var) bool {
+	if m.Category != "" && varCategory(v) != m.Category {
+		return false
+	}
+	if m.Sensitive != nil && v.Sensitive != *m.Sensitive {
+		return false
+	}
+	if m.Hcl != nil && v.Hcl != *m.Hcl {
+		return false
+	}
+
+	hasKey := m.KeyContains != "" || m.keyRegex != nil
+	hasValue := m.ValueContains != "" || m.valueRegex != nil
+	keyMatch := (m.KeyContains == "" || strings.Contains(v.Key, m.KeyContains)) &&
+		(m.keyRegex == nil || m.keyRegex.MatchString(v.Key))
+	valueMatch := (m.ValueContains == "" || strings.Contains(v.Value, m.ValueContains)) &&
+		(m.valueRegex == nil || m.valueRegex.MatchString(v.Value))
+
+	if m.Any && hasKey && hasValue {
+		return keyMatch || valueMatch
+	}
+	return keyMatch && valueMatch
+}
+
+// FilterVariables returns the variables that match m
+func FilterVariables(vars []Var, m VariableMatch) ([]Var, error) {
+	matcher, err := m.compile()
+	if err != nil {
+		return nil, err
+	}
+	var matched []Var
+	for _, v := range vars {
+		if matcher.matches(v) {
+			matched = append(matched, v)
+		}
+	}
+	return matched, nil
+}
+
+// exactKeyMatch returns a VariableMatch for a key or value that is an exact case-insensitive match of s
+func exactKeyMatch(s string, onValue bool) VariableMatch {
+	regex := "(?i)^" + regexp.QuoteMeta(s) + "$"
+	if onValue {
+		return VariableMatch{ValueRegex: regex}
+	}
+	return VariableMatch{KeyRegex: regex}
+}
+
+// IsWorkspacePattern returns true if name has any of the special characters of a glob pattern, as used by
+// path.Match. These characters are not allowed in a workspace name.
+func IsWorkspacePattern(name string) bool {
+	return strings.ContainsAny(name, `*?[\`)
+}
+
+// MatchWorkspaces returns the names that match a glob pattern, as used by path.Match, e.g. "app-*-prod"
+func MatchWorkspaces(pattern string, names []string) ([]string, error) {
+	var matched []string
+	for _, name := range names {
+		ok, err := path.Match(pattern, name)
+		if err != nil {
+			return nil, fmt.Errorf("invalid workspace pattern '%s': %w", pattern, err)
+		}
+		if ok {
+			matched = append(matched, name)
+		}
+	}
+	return matched, nil
+}
+
+// FindVariables returns the variables in the given workspace that match m
+func (c *Client) FindVariables(organization, wsName string, m VariableMatch) ([]Var, error) {
+	return c.FindVariablesContext(context.Background(), organization, wsName, m)
+}
+
+// FindVariablesContext is like FindVariables but uses ctx for its API calls
+func (c *Client) FindVariablesContext(ctx context.Context, organization, wsName string, m VariableMatch) ([]Var, error) {
+	if _, err := m.compile(); err != nil {
+		return nil, err
+	}
+	vars, err := c.GetVarsFromWorkspaceContext(ctx, organization, wsName)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting variables for %s:%s\n%w", organization, wsName, err)
+	}
+	return FilterVariables(vars, m)
+}
+
+// FindVarsInWorkspaces returns the variables that match m in each of the named workspaces, as a map of variable
+// lists with the workspace name as the key. Workspaces are searched in parallel. If the search stops early, the
+// variables found so far are returned with an *IncompleteError.
+func (c *Client) FindVarsInWorkspaces(organization string, wsNames []string, m VariableMatch) (map[string][]Var, error) {
+	return c.FindVarsInWorkspacesContext(context.Background(), organization, wsNames, m)
+}
+
+// FindVarsInWorkspacesContext is like FindVarsInWorkspaces but uses ctx for its API calls
+func (c *Client) FindVarsInWorkspacesContext(ctx context.Context, organization string, wsNames []string, m VariableMatch) (map[string][]Var, error) {
+	if _, err := m.compile(); err != nil {
+		return nil, err
+	}
+
+	allVars := map[string][]Var{}
+	var mu sync.Mutex
+	err := c.ForEachWorkspace(ctx, wsNames, nil, func(ctx context.Context, wsName string, _ io.Writer) error {
+		wsVars, err := c.FindVariablesContext(ctx, organization, wsName, m)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		allVars[wsName] = wsVars
+		return nil
+	})
+	return allVars, err
+}
+
+// PlanVariableDelete returns the deletion of each variable that matches m in the named workspaces. The changes are
+// made with ApplyManifestPlan. Nothing is changed.
+func (c *Client) PlanVariableDelete(organization string, wsNames []string, m VariableMatch) (*ManifestPlan, error) {
+	return c.PlanVariableDeleteContext(context.Background(), organization, wsNames, m)
+}
+
+// PlanVariableDeleteContext is like PlanVariableDelete but uses ctx for its API calls
+func (c *Client) PlanVariableDeleteContext(ctx context.Context, organization string, wsNames []string, m VariableMatch) (*ManifestPlan, error) {
+	wsVars, err := c.FindVarsInWorkspacesContext(ctx, organization, wsNames, m)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &ManifestPlan{Organization: organization}
+	for _, ws := range wsNames {
+		for _, v := range wsVars[ws] {
+			id := v.ID
+			plan.Changes = append(plan.Changes, variablePlanChange(ws, ManifestActionDelete, v.Key, varCategory(v),
+				func(ctx context.Context, c *Client) error {
+					return c.DeleteVariableContext(ctx, id)
+				}))
+		}
+	}
+	return plan, nil
+}
@@ -0,0 +1,78 @@
+package lib
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterVariables(t *testing.T) {
+	vars := []Var{
+		{Key: "aws_region", Value: "us-east-1", Category: "terraform"},
+		{Key: "AWS_PROFILE", Value: "prod", Category: "env"},
+		{Key: "tags", Value: `{ app = "prod" }`, Category: "terraform", Hcl: true},
+		{Key: "db_password", Sensitive: true, Category: "terraform"},
+	}
+	yes, no := true, false
+
+	tests := []struct {
+		name  string
+		match VariableMatch
+		want  []string
+	}{
+		{name: "no criteria", match: VariableMatch{}, want: []string{"aws_region", "AWS_PROFILE", "tags", "db_password"}},
+		{name: "key regex", match: VariableMatch{KeyRegex: "(?i)^aws_"}, want: []string{"aws_region", "AWS_PROFILE"}},
+		{name: "key and value", match: VariableMatch{KeyRegex: "(?i)^aws_", ValueRegex: "prod"}, want: []string{"AWS_PROFILE"}},
+		{name: "key or value", match: VariableMatch{KeyRegex: "^aws_", ValueRegex: "prod", Any: true}, want: []string{"aws_region", "AWS_PROFILE", "tags"}},
+		{name: "any with only a key", match: VariableMatch{KeyContains: "_", Any: true}, want: []string{"aws_region", "AWS_PROFILE", "db_password"}},
+		{name: "contains and regex", match: VariableMatch{KeyContains: "region", KeyRegex: "^aws"}, want: []string{"aws_region"}},
+		{name: "category", match: VariableMatch{Category: "env"}, want: []string{"AWS_PROFILE"}},
+		{name: "sensitive", match: VariableMatch{Sensitive: &yes}, want: []string{"db_password"}},
+		{name: "not hcl", match: VariableMatch{ValueContains: "prod", Hcl: &no}, want: []string{"AWS_PROFILE"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FilterVariables(vars, tt.match)
+			require.NoError(t, err)
+			var keys []string
+			for _, v := range got {
+				keys = append(keys, v.Key)
+			}
+			require.Equal(t, tt.want, keys)
+		})
+	}
+
+	_, err := FilterVariables(vars, VariableMatch{KeyRegex: "("})
+	require.ErrorContains(t, err, "invalid key regex")
+	_, err = FilterVariables(vars, VariableMatch{Category: "other"})
+	require.ErrorContains(t, err, "invalid category 'other'")
+}
+
+func Test_exactKeyMatch(t *testing.T) {
+	vars := []Var{{Key: "a.b", Value: "X"}, {Key: "A.B", Value: "y"}, {Key: "aXb", Value: "x"}}
+
+	got, err := FilterVariables(vars, exactKeyMatch("a.b", false))
+	require.NoError(t, err)
+	require.Equal(t, []Var{vars[0], vars[1]}, got)
+
+	got, err = FilterVariables(vars, exactKeyMatch("x", true))
+	require.NoError(t, err)
+	require.Equal(t, []Var{vars[0], vars[2]}, got)
+}
+
+func TestMatchWorkspaces(t *testing.T) {
+	require.True(t, IsWorkspacePattern("app-*"))
+	require.False(t, IsWorkspacePattern("app-prod"))
+
+	names := []string{"app-prod", "app-dev", "web-prod"}
+	got, err := MatchWorkspaces("app-*", names)
+	require.NoError(t, err)
+	require.Equal(t, []string{"app-prod", "app-dev"}, got)
+
+	got, err = MatchWorkspaces("*-prod", names)
+	require.NoError(t, err)
+	require.Equal(t, []string{"app-prod", "web-prod"}, got)
+
+	_, err = MatchWorkspaces("[app", names)
+	require.ErrorContains(t, err, "invalid workspace pattern")
+}
@@ -0,0 +1,293 @@
+package lib
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// VariableSetConfig holds the attributes of a variable set to create or update. Attributes that are nil are not
+// changed by an update, and have their default value in a new variable set.
+type VariableSetConfig struct {
+	Name        *string
+	Description *string
+	Global      *bool // apply the variable set to all the workspaces in the organization
+	Priority    *bool // let the variables in the set override variables with the same key in a workspace
+}
+
+// buildVariableSetPayload returns the JSON needed to create or update a variable set
+func buildVariableSetPayload(cfg VariableSetConfig) string {
+	attributes := map[string]any{}
+	if cfg.Name != nil {
+		attributes["name"] = *cfg.Name
+	}
+	if cfg.Description != nil {
+		attributes["description"] = *cfg.Description
+	}
+	if cfg.Global != nil {
+		attributes["global"] = *cfg.Global
+	}
+	if cfg.Priority != nil {
+		attributes["priority"] = *cfg.Priority
+	}
+	payload, _ := json.Marshal(map[string]any{
+		"data": map[string]any{
+			"type":       "varsets",
+			"attributes": attributes,
+		},
+	})
+	return string(payload)
+}
+
+// CreateVariableSet creates a variable set in an organization, and returns the new variable set. The name is required.
+// https://developer.hashicorp.com/terraform/cloud-docs/api-docs/variable-sets#create-a-variable-set
+func (c *Client) CreateVariableSet(organization string, cfg VariableSetConfig) (VariableSet, error) {
+	return c.CreateVariableSetContext(context.Background(), organization, cfg)
+}
+
+// CreateVariableSetContext is like CreateVariableSet but uses ctx for its API calls
+func (c *Client) CreateVariableSetContext(ctx context.Context, organization string, cfg VariableSetConfig) (VariableSet, error) {
+	if cfg.Name == nil || *cfg.Name == "" {
+		return VariableSet{}, fmt.Errorf("a name is required to create a variable set")
+	}
+	u := c.NewTfcUrl(fmt.Sprintf("/organizations/%s/varsets", organization))
+	return c.writeVariableSet(ctx, http.MethodPost, u.String(), cfg)
+}
+
+// UpdateVariableSet changes the attributes of a variable set that are not nil in cfg, and returns the updated
+// variable set
+// https://developer.hashicorp.com/terraform/cloud-docs/api-docs/variable-sets#update-a-variable-set
+func (c *Client) UpdateVariableSet(varsetID string, cfg VariableSetConfig) (VariableSet, error) {
+	return c.UpdateVariableSetContext(context.Background(), varsetID, cfg)
+}
+
+// UpdateVariableSetContext is like UpdateVariableSet but uses ctx for its API calls
+func (c *Client) UpdateVariableSetContext(ctx context.Context, varsetID string, cfg VariableSetConfig) (VariableSet, error) {
+	u := c.NewTfcUrl("/varsets/" + varsetID)
+	return c.writeVariableSet(ctx, http.MethodPatch, u.String(), cfg)
+}
+
+// writeVariableSet creates or updates a variable set, and returns it
+func (c *Client) writeVariableSet(ctx context.Context, method, url string, cfg VariableSetConfig) (VariableSet, error) {
+	resp, err := c.callAPI(ctx, method, url, buildVariableSetPayload(cfg), nil)
+	if err != nil {
+		return VariableSet{}, err
+	}
+	defer resp.Body.Close()
+
+	var setData struct {
+		Data VariableSet `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&setData); err != nil {
+		return VariableSet{}, fmt.Errorf("unexpected content in variable set response: %w", err)
+	}
+	return setData.Data, nil
+}
+
+// DeleteVariableSet deletes a variable set and the variables in it
+// https://developer.hashicorp.com/terraform/cloud-docs/api-docs/variable-sets#delete-a-variable-set
+func (c *Client) DeleteVariableSet(varsetID string) error {
+	return c.DeleteVariableSetContext(context.Background(), varsetID)
+}
+
+// DeleteVariableSetContext is like DeleteVariableSet but uses ctx for its API calls
+func (c *Client) DeleteVariableSetContext(ctx context.Context, varsetID string) error {
+	u := c.NewTfcUrl("/varsets/" + varsetID)
+
+	resp, err := c.callAPI(ctx, http.MethodDelete, u.String(), "", nil)
+	if err != nil {
+		return fmt.Errorf("failed to delete variable set %s: %w", varsetID, err)
+	}
+	return resp.Body.Close()
+}
+
+// GetVariableSetByID returns a variable set, including the IDs of its variables and of the workspaces it is
+// applied to
+// https://developer.hashicorp.com/terraform/cloud-docs/api-docs/variable-sets#show-variable-set
+func (c *Client) GetVariableSetByID(varsetID string) (VariableSet, error) {
+	return c.GetVariableSetByIDContext(context.Background(), varsetID)
+}
+
+// GetVariableSetByIDContext is like GetVariableSetByID but uses ctx for its API calls
+func (c *Client) GetVariableSetByIDContext(ctx context.Context, varsetID string) (VariableSet, error) {
+	u := c.NewTfcUrl("/varsets/" + varsetID)
+
+	resp, err := c.callAPI(ctx, http.MethodGet, u.String(), "", nil)
+	if err != nil {
+		return VariableSet{}, err
+	}
+	defer resp.Body.Close()
+
+	var setData struct {
+		Data VariableSet `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&setData); err != nil {
+		return VariableSet{}, fmt.Errorf("unexpected content retrieving variable set %s: %w", varsetID, err)
+	}
+	return setData.Data, nil
+}
+
+// getVariableSetVariablePayload returns the JSON needed to create or update a variable in a variable set
+func getVariableSetVariablePayload(tfVar Var) string {
+	payload, _ := json.Marshal(map[string]any{
+		"data": map[string]any{
+			"type": "vars",
+			"attributes": map[string]any{
+				"key":         tfVar.Key,
+				"value":       tfVar.Value,
+				"description": tfVar.Description,
+				"category":    varCategory(tfVar),
+				"hcl":         tfVar.Hcl,
+				"sensitive":   tfVar.Sensitive,
+			},
+		},
+	})
+	return string(payload)
+}
+
+// CreateVariableSetVariable adds a variable to a variable set, and returns the ID of the new variable
+// https://developer.hashicorp.com/terraform/cloud-docs/api-docs/variable-sets#add-variable
+func (c *Client) CreateVariableSetVariable(varsetID string, tfVar Var) (string, error) {
+	return c.CreateVariableSetVariableContext(context.Background(), varsetID, tfVar)
+}
+
+// CreateVariableSetVariableContext is like CreateVariableSetVariable but uses ctx for its API calls
+func (c *Client) CreateVariableSetVariableContext(ctx context.Context, varsetID string, tfVar Var) (string, error) {
+	u := c.NewTfcUrl(fmt.Sprintf("/varsets/%s/relationships/vars", varsetID))
+
+	resp, err := c.callAPI(ctx, http.MethodPost, u.String(), getVariableSetVariablePayload(tfVar), nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create variable %s in variable set %s: %w", tfVar.Key, varsetID, err)
+	}
+	defer resp.Body.Close()
+
+	return parseCreatedID(resp.Body)
+}
+
+// UpdateVariableSetVariable changes a variable in a variable set
+// https://developer.hashicorp.com/terraform/cloud-docs/api-docs/variable-sets#update-a-variable-in-a-variable-set
+func (c *Client) UpdateVariableSetVariable(varsetID, variableID string, tfVar Var) error {
+	return c.UpdateVariableSetVariableContext(context.Background(), varsetID, variableID, tfVar)
+}
+
+// UpdateVariableSetVariableContext is like UpdateVariableSetVariable but uses ctx for its API calls
+func (c *Client) UpdateVariableSetVariableContext(ctx context.Context, varsetID, variableID string, tfVar Var) error {
+	u := c.NewTfcUrl(fmt.Sprintf("/varsets/%s/relationships/vars/%s", varsetID, variableID))
+
+	resp, err := c.callAPI(ctx, http.MethodPatch, u.String(), getVariableSetVariablePayload(tfVar), nil)
+	if err != nil {
+		return fmt.Errorf("failed to update variable %s in variable set %s: %w", tfVar.Key, varsetID, err)
+	}
+	return resp.Body.Close()
+}
+
+// DeleteVariableSetVariable deletes a variable from a variable set
+// https://developer.hashicorp.com/terraform/cloud-docs/api-docs/variable-sets#delete-a-variable-in-a-variable-set
+func (c *Client) DeleteVariableSetVariable(varsetID, variableID string) error {
+	return c.DeleteVariableSetVariableContext(context.Background(), varsetID, variableID)
+}
+
+// DeleteVariableSetVariableContext is like DeleteVariableSetVariable but uses ctx for its API calls
+func (c *Client) DeleteVariableSetVariableContext(ctx context.Context, varsetID, variableID string) error {
+	u := c.NewTfcUrl(fmt.Sprintf("/varsets/%s/relationships/vars/%s", varsetID, variableID))
+
+	resp, err := c.callAPI(ctx, http.MethodDelete, u.String(), "", nil)
+	if err != nil {
+		return fmt.Errorf("failed to delete variable %s from variable set %s: %w", variableID, varsetID, err)
+	}
+	return resp.Body.Close()
+}
+
+// RemoveVariableSet removes a variable set from workspaces, undoing ApplyVariableSet
+// https://developer.hashicorp.com/terraform/cloud-docs/api-docs/variable-sets#remove-a-variable-set-from-workspaces
+func (c *Client) RemoveVariableSet(varsetID string, workspaceIDs []string) error {
+	return c.RemoveVariableSetContext(context.Background(), varsetID, workspaceIDs)
+}
+
+// RemoveVariableSetContext is like RemoveVariableSet but uses ctx for its API calls
+func (c *Client) RemoveVariableSetContext(ctx context.Context, varsetID string, workspaceIDs []string) error {
+	return c.setVariableSetRelationship(ctx, http.MethodDelete, varsetID, "workspaces", workspaceIDs)
+}
+
+// ApplyVariableSetToProjects applies a variable set to all the workspaces in projects
+// https://developer.hashicorp.com/terraform/cloud-docs/api-docs/variable-sets#apply-variable-set-to-projects
+func (c *Client) ApplyVariableSetToProjects(varsetID string, projectIDs []string) error {
+	return c.ApplyVariableSetToProjectsContext(context.Background(), varsetID, projectIDs)
+}
+
+// ApplyVariableSetToProjectsContext is like ApplyVariableSetToProjects but uses ctx for its API calls
+func (c *Client) ApplyVariableSetToProjectsContext(ctx context.Context, varsetID string, projectIDs []string) error {
+	return c.setVariableSetRelationship(ctx, http.MethodPost, varsetID, "projects", projectIDs)
+}
+
+// RemoveVariableSetFromProjects removes a variable set from projects, undoing ApplyVariableSetToProjects
+// https://developer.hashicorp.com/terraform/cloud-docs/api-docs/variable-sets#remove-a-variable-set-from-projects
+func (c *Client) RemoveVariableSetFromProjects(varsetID string, projectIDs []string) error {
+	return c.RemoveVariableSetFromProjectsContext(context.Background(), varsetID, projectIDs)
+}
+
+// RemoveVariableSetFromProjectsContext is like RemoveVariableSetFromProjects but uses ctx for its API calls
+func (c *Client) RemoveVariableSetFromProjectsContext(ctx context.Context, varsetID string, projectIDs []string) error {
+	return c.setVariableSetRelationship(ctx, http.MethodDelete, varsetID, "projects", projectIDs)
+}
+
+// setVariableSetRelationship adds (POST) or removes (DELETE) workspaces or projects in the relationship of a variable
+// set, which is "workspaces" or "projects". Nothing is changed in read-only mode.
+func (c *Client) setVariableSetRelationship(ctx context.Context, method, varsetID, relationship string, ids []string) error {
+	u := c.NewTfcUrl(fmt.Sprintf("/varsets/%s/relationships/%s", varsetID, relationship))
+
+	data := make([]map[string]string, len(ids))
+	for i, id := range ids {
+		data[i] = map[string]string{"type": relationship, "id": id}
+	}
+	payload, _ := json.Marshal(map[string]any{"data": data})
+
+	if c.debug {
+		fmt.Printf("request body:\n    %s\n", payload)
+	}
+	if c.readOnly {
+		return nil
+	}
+	resp, err := c.callAPI(ctx, method, u.String(), string(payload), nil)
+	if err != nil {
+		return fmt.Errorf("failed to change the %s of variable set %s: %w", relationship, varsetID, err)
+	}
+	return resp.Body.Close()
+}
+
+// GetProjectID returns the ID of the project with the given name. The error matches ErrNotFound if there is none.
+// https://developer.hashicorp.com/terraform/cloud-docs/api-docs/projects#list-projects
+func (c *Client) GetProjectID(organization, name string) (string, error) {
+	return c.GetProjectIDContext(context.Background(), organization, name)
+}
+
+// GetProjectIDContext is like GetProjectID but uses ctx for its API calls
+func (c *Client) GetProjectIDContext(ctx context.Context, organization, name string) (string, error) {
+	u := c.NewTfcUrl(fmt.Sprintf("/organizations/%s/projects", organization))
+	u.SetParam(paramFilterNames, name)
+
+	resp, err := c.callAPI(ctx, http.MethodGet, u.String(), "", nil)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var projects struct {
+		Data []struct {
+			ID         string `json:"id"`
+			Attributes struct {
+				Name string `json:"name"`
+			} `json:"attributes"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&projects); err != nil {
+		return "", fmt.Errorf("unexpected content retrieving project list: %w", err)
+	}
+	for _, p := range projects.Data {
+		if p.Attributes.Name == name {
+			return p.ID, nil
+		}
+	}
+	return "", fmt.Errorf("project %s in organization %s: %w", name, organization, ErrNotFound)
+}
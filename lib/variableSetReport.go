@@ -0,0 +1,181 @@
+package lib
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Kind of a VariableSetIssue
+const (
+	// VariableSetUnused is a variable set that is not global and not applied to any workspace or project
+	VariableSetUnused = "unused"
+
+	// VariableSetOverlap is a key that is defined in more than one variable set applied to the same workspace
+	VariableSetOverlap = "overlap"
+
+	// VariableSetShadowed is a variable of a variable set that a workspace variable overrides
+	VariableSetShadowed = "shadowed"
+)
+
+// VariableSetIssue is a problem with a variable set, found by GetVariableSetReport
+type VariableSetIssue struct {
+	Kind        string   `json:"kind" yaml:"kind"`
+	VariableSet string   `json:"variable-set" yaml:"variable-set"`
+	Key         string   `json:"key,omitempty" yaml:"key,omitempty"`
+	Category    string   `json:"category,omitempty" yaml:"category,omitempty"`
+	OtherSets   []string `json:"other-sets,omitempty" yaml:"other-sets,omitempty"` // the other sets defining the key
+	Workspaces  []string `json:"workspaces,omitempty" yaml:"workspaces,omitempty"` // where the issue occurs
+}
+
+// VariableSetReport is the usage of the variable sets in an organization
+type VariableSetReport struct {
+	VariableSets []VariableSet
+	Issues       []VariableSetIssue // sorted by variable set, kind, key and category
+}
+
+// GetVariableSetReport lists all the variable sets in an organization, with the issues found in them: sets that
+// are not used, keys defined in more than one set applied to the same workspace, and set variables that are
+// overridden by workspace variables. Workspaces are searched in parallel. If the search stops early, the report is
+// returned with the issues found so far and an *IncompleteError.
+func (c *Client) GetVariableSetReport(organization string) (VariableSetReport, error) {
+	return c.GetVariableSetReportContext(context.Background(), organization)
+}
+
+// GetVariableSetReportContext is like GetVariableSetReport but uses ctx for its API calls
+func (c *Client) GetVariableSetReportContext(ctx context.Context, organization string) (VariableSetReport, error) {
+	sets, err := c.GetAllVariableSetsContext(ctx, organization)
+	if err != nil {
+		return VariableSetReport{}, fmt.Errorf("error getting the variable sets of %s: %w", organization, err)
+	}
+	setVars := map[string][]Var{}
+	for _, vs := range sets.Data {
+		if setVars[vs.ID], err = c.ListVariableSetVariablesContext(ctx, vs.ID); err != nil {
+			return VariableSetReport{}, fmt.Errorf("error getting the variables of variable set %s: %w", vs.Attributes.Name, err)
+		}
+	}
+
+	workspaces, err := c.GetAllWorkspacesContext(ctx, organization)
+	if err != nil {
+		return VariableSetReport{}, err
+	}
+	names := make([]string, len(workspaces))
+	idByName := make(map[string]string, len(workspaces))
+	for i, ws := range workspaces {
+		names[i] = ws.Attributes.Name
+		idByName[ws.Attributes.Name] = ws.ID
+	}
+
+	wsVars := map[string][]EffectiveVariable{}
+	var mu sync.Mutex
+	err = c.ForEachWorkspace(ctx, names, nil, func(ctx context.Context, name string, _ io.Writer) error {
+		applied, err := c.ListWorkspaceVariableSetsContext(ctx, idByName[name])
+		if err != nil {
+			return err
+		}
+		if len(applied.Data) == 0 {
+			return nil
+		}
+		vars, err := c.GetVarsFromWorkspaceContext(ctx, organization, name)
+		if err != nil {
+			return err
+		}
+		effective := EffectiveVariables(idByName[name], vars, applied.Data, setVars)
+		mu.Lock()
+		defer mu.Unlock()
+		wsVars[name] = effective
+		return nil
+	})
+
+	report := VariableSetReport{
+		VariableSets: sets.Data,
+		Issues:       VariableSetIssues(sets.Data, wsVars),
+	}
+	return report, err
+}
+
+// VariableSetIssues returns the issues in variable sets, given the effective variables of workspaces as a map with
+// the workspace name as the key. Issues of the same kind with the same variable set, key and other sets are merged,
+// with a list of the workspaces where they occur.
+func VariableSetIssues(sets []VariableSet, wsVars map[string][]EffectiveVariable) []VariableSetIssue {
+	var issues []VariableSetIssue
+	for _, vs := range sets {
+		if !vs.Attributes.Global && len(vs.Relationships.Workspaces.Data) == 0 &&
+			len(vs.Relationships.Projects.Data) == 0 && vs.Attributes.WorkspaceCount == 0 {
+			issues = append(issues, VariableSetIssue{Kind: VariableSetUnused, VariableSet: vs.Attributes.Name})
+		}
+	}
+
+	index := map[string]int{}
+	add := func(issue VariableSetIssue, ws string) {
+		id := strings.Join([]string{issue.Kind, issue.VariableSet, issue.Key, issue.Category,
+			strings.Join(issue.OtherSets, ",")}, "\x00")
+		i, ok := index[id]
+		if !ok {
+			i = len(issues)
+			index[id] = i
+			issues = append(issues, issue)
+		}
+		issues[i].Workspaces = append(issues[i].Workspaces, ws)
+	}
+
+	for ws, vars := range wsVars {
+		for _, v := range vars {
+			var setNames []string
+			workspaceValue := false
+			for _, value := range v.Values {
+				if value.VariableSet == "" {
+					workspaceValue = true
+					continue
+				}
+				setNames = append(setNames, value.VariableSet)
+				if workspaceValue {
+					add(VariableSetIssue{
+						Kind:        VariableSetShadowed,
+						VariableSet: value.VariableSet,
+						Key:         v.Key,
+						Category:    v.Category,
+					}, ws)
+				}
+			}
+			if len(setNames) < 2 {
+				continue
+			}
+			for i, name := range setNames {
+				others := append(append([]string{}, setNames[:i]...), setNames[i+1:]...)
+				sort.Strings(others)
+				add(VariableSetIssue{
+					Kind:        VariableSetOverlap,
+					VariableSet: name,
+					Key:         v.Key,
+					Category:    v.Category,
+					OtherSets:   others,
+				}, ws)
+			}
+		}
+	}
+
+	for i := range issues {
+		sort.Strings(issues[i].Workspaces)
+	}
+	sort.SliceStable(issues, func(i, j int) bool {
+		a, b := issues[i], issues[j]
+		if a.VariableSet != b.VariableSet {
+			return a.VariableSet < b.VariableSet
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.Key != b.Key {
+			return a.Key < b.Key
+		}
+		if a.Category != b.Category {
+			return a.Category < b.Category
+		}
+		return strings.Join(a.OtherSets, ",") < strings.Join(b.OtherSets, ",")
+	})
+	return issues
+}
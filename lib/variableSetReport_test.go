@@ -0,0 +1,39 @@
+package lib
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVariableSetIssues(t *testing.T) {
+	sets := []VariableSet{
+		newTestVariableSet("vs-a", "a-shared", true, false, nil, nil),
+		newTestVariableSet("vs-b", "b-app", false, false, []string{"ws-1", "ws-2"}, nil),
+		newTestVariableSet("vs-c", "c-unused", false, false, nil, nil),
+		newTestVariableSet("vs-d", "d-project", false, false, nil, []string{"prj-1"}),
+	}
+	setVars := map[string][]Var{
+		"vs-a": {{Key: "region", Value: "us-east-1"}, {Key: "owner", Value: "ops"}},
+		"vs-b": {{Key: "region", Value: "us-west-2"}},
+		"vs-d": {{Key: "REGION", Value: "x", Category: "env"}},
+	}
+	applied := []VariableSet{sets[0], sets[1]}
+	wsVars := map[string][]EffectiveVariable{
+		"app-1": EffectiveVariables("ws-1", []Var{{Key: "owner", Value: "dev"}}, applied, setVars),
+		"app-2": EffectiveVariables("ws-2", nil, applied, setVars),
+		"other": EffectiveVariables("ws-3", []Var{{Key: "owner", Value: "qa"}}, sets[:1], setVars),
+	}
+
+	require.Equal(t, []VariableSetIssue{
+		{Kind: VariableSetOverlap, VariableSet: "a-shared", Key: "region", Category: "terraform",
+			OtherSets: []string{"b-app"}, Workspaces: []string{"app-1", "app-2"}},
+		{Kind: VariableSetShadowed, VariableSet: "a-shared", Key: "owner", Category: "terraform",
+			Workspaces: []string{"app-1", "other"}},
+		{Kind: VariableSetOverlap, VariableSet: "b-app", Key: "region", Category: "terraform",
+			OtherSets: []string{"a-shared"}, Workspaces: []string{"app-1", "app-2"}},
+		{Kind: VariableSetUnused, VariableSet: "c-unused"},
+	}, VariableSetIssues(sets, wsVars))
+
+	require.Empty(t, VariableSetIssues(sets[:2], nil))
+}
@@ -0,0 +1,106 @@
+package lib
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_buildVariableSetPayload(t *testing.T) {
+	name, global := "shared", false
+	require.JSONEq(t, `{"data":{"type":"varsets","attributes":{"name":"shared","global":false}}}`,
+		buildVariableSetPayload(VariableSetConfig{Name: &name, Global: &global}))
+	require.JSONEq(t, `{"data":{"type":"varsets","attributes":{}}}`, buildVariableSetPayload(VariableSetConfig{}))
+}
+
+func Test_getVariableSetVariablePayload(t *testing.T) {
+	got := getVariableSetVariablePayload(Var{Key: "tags", Value: "{\n  a = \"b\"\n}", Hcl: true})
+	require.JSONEq(t, `{"data":{"type":"vars","attributes":{"key":"tags","value":"{\n  a = \"b\"\n}",
+		"description":"","category":"terraform","hcl":true,"sensitive":false}}}`, got)
+}
+
+func TestClient_VariableSet(t *testing.T) {
+	var calls []string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		calls = append(calls, r.Method+" "+strings.TrimPrefix(r.URL.Path, apiPath)+" "+string(body))
+		switch r.Method {
+		case http.MethodPost, http.MethodPatch:
+			_, _ = w.Write([]byte(`{"data":{"id":"varset-1","attributes":{"name":"shared","priority":true}}}`))
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+	c := newTestClient(server, "token")
+
+	_, err := c.CreateVariableSet("org", VariableSetConfig{})
+	require.ErrorContains(t, err, "name is required")
+	require.Empty(t, calls)
+
+	name, priority := "shared", true
+	vs, err := c.CreateVariableSet("org", VariableSetConfig{Name: &name, Priority: &priority})
+	require.NoError(t, err)
+	require.Equal(t, "varset-1", vs.ID)
+	require.True(t, vs.Attributes.Priority)
+	require.True(t, strings.HasPrefix(calls[0], "POST /organizations/org/varsets "))
+
+	_, err = c.UpdateVariableSet("varset-1", VariableSetConfig{Priority: &priority})
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(calls[1], "PATCH /varsets/varset-1 "))
+
+	require.NoError(t, c.UpdateVariableSetVariable("varset-1", "var-1", Var{Key: "region", Value: "us-east-1"}))
+	require.True(t, strings.HasPrefix(calls[2], "PATCH /varsets/varset-1/relationships/vars/var-1 "))
+
+	require.NoError(t, c.DeleteVariableSetVariable("varset-1", "var-1"))
+	require.Equal(t, "DELETE /varsets/varset-1/relationships/vars/var-1 ", calls[3])
+
+	require.NoError(t, c.DeleteVariableSet("varset-1"))
+	require.Equal(t, "DELETE /varsets/varset-1 ", calls[4])
+}
+
+func TestClient_RemoveVariableSet(t *testing.T) {
+	var calls []string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			if r.URL.Query().Get(paramFilterNames) == "prod" {
+				_, _ = w.Write([]byte(`{"data":[{"id":"prj-1","attributes":{"name":"prod"}}]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"data":[]}`))
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		calls = append(calls, r.Method+" "+strings.TrimPrefix(r.URL.Path, apiPath)+" "+string(body))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+	c := newTestClient(server, "token")
+
+	require.NoError(t, c.RemoveVariableSet("varset-1", []string{"ws-1", "ws-2"}))
+	require.Equal(t, `DELETE /varsets/varset-1/relationships/workspaces {"data":[{"id":"ws-1","type":"workspaces"},{"id":"ws-2","type":"workspaces"}]}`, calls[0])
+
+	id, err := c.GetProjectID("org", "prod")
+	require.NoError(t, err)
+	require.Equal(t, "prj-1", id)
+	_, err = c.GetProjectID("org", "other")
+	require.ErrorIs(t, err, ErrNotFound)
+
+	require.NoError(t, c.ApplyVariableSetToProjects("varset-1", []string{id}))
+	require.Equal(t, `POST /varsets/varset-1/relationships/projects {"data":[{"id":"prj-1","type":"projects"}]}`, calls[1])
+	require.NoError(t, c.RemoveVariableSetFromProjects("varset-1", []string{id}))
+	require.True(t, strings.HasPrefix(calls[2], "DELETE /varsets/varset-1/relationships/projects "))
+
+	readOnly := NewClient(ClientConfig{
+		Token:      "token",
+		Hostname:   strings.TrimPrefix(server.URL, "https://"),
+		HTTPClient: server.Client(),
+		ReadOnly:   true,
+	})
+	require.NoError(t, readOnly.RemoveVariableSet("varset-1", []string{"ws-1"}))
+	require.Len(t, calls, 3)
+}
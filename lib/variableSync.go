@@ -0,0 +1,182 @@
+package lib
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"sync"
+)
+
+// VariableSyncConfig selects the variables copied by a variable sync
+type VariableSyncConfig struct {
+	// Only lists glob patterns, as used by path.Match, of the keys to sync. If it is empty, all keys are synced.
+	Only []string
+
+	// Exclude lists glob patterns of keys not to sync
+	Exclude []string
+
+	// Prune deletes the variables in a target workspace that are not in the source workspace
+	Prune bool
+}
+
+// validate returns an error if any of the patterns is malformed
+func (cfg VariableSyncConfig) validate() error {
+	for _, patterns := range [][]string{cfg.Only, cfg.Exclude} {
+		for _, pattern := range patterns {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("invalid key pattern '%s': %w", pattern, err)
+			}
+		}
+	}
+	return nil
+}
+
+// selects returns true if a variable with the given key is synced
+func (cfg VariableSyncConfig) selects(key string) bool {
+	for _, pattern := range cfg.Exclude {
+		if ok, _ := path.Match(pattern, key); ok {
+			return false
+		}
+	}
+	if len(cfg.Only) == 0 {
+		return true
+	}
+	for _, pattern := range cfg.Only {
+		if ok, _ := path.Match(pattern, key); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// PlanVariableSync compares the variables of the source workspace with those of each of the target workspaces in
+// organization, and returns the changes needed to make the targets match the source. The changes are made
+// with ApplyManifestPlan. The value of a sensitive variable in the source can't be read, so it is not copied. The
+// sensitive variables that are missing or different in a target are returned as "workspace: key". Nothing is changed.
+func (c *Client) PlanVariableSync(sourceOrg, source, organization string, targets []string, cfg VariableSyncConfig) (*ManifestPlan, []string, error) {
+	return c.PlanVariableSyncContext(context.Background(), sourceOrg, source, organization, targets, cfg)
+}
+
+// PlanVariableSyncContext is like PlanVariableSync but uses ctx for its API calls
+func (c *Client) PlanVariableSyncContext(ctx context.Context, sourceOrg, source, organization string, targets []string, cfg VariableSyncConfig) (*ManifestPlan, []string, error) {
+	if err := cfg.validate(); err != nil {
+		return nil, nil, err
+	}
+
+	sourceVars, err := c.GetVarsFromWorkspaceContext(ctx, sourceOrg, source)
+	if err != nil {
+		return nil, nil, err
+	}
+	sourceVars = selectVariables(sourceVars, cfg)
+
+	changes := make([][]ManifestChange, len(targets))
+	skipped := make([][]string, len(targets))
+	index := map[string]int{}
+	for i, name := range targets {
+		index[name] = i
+	}
+	var mu sync.Mutex
+	err = c.ForEachWorkspace(ctx, targets, nil, func(ctx context.Context, name string, _ io.Writer) error {
+		targetVars, err := c.GetVarsFromWorkspaceContext(ctx, organization, name)
+		if err != nil {
+			return fmt.Errorf("workspace %s: %w", name, err)
+		}
+		wsChanges, wsSkipped := planVariableSync(organization, name, sourceVars, selectVariables(targetVars, cfg), cfg.Prune)
+		mu.Lock()
+		defer mu.Unlock()
+		changes[index[name]] = wsChanges
+		skipped[index[name]] = wsSkipped
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	plan := &ManifestPlan{Organization: organization}
+	var allSkipped []string
+	for i := range targets {
+		plan.Changes = append(plan.Changes, changes[i]...)
+		allSkipped = append(allSkipped, skipped[i]...)
+	}
+	return plan, allSkipped, nil
+}
+
+// selectVariables returns the variables that are synced with cfg
+func selectVariables(vars []Var, cfg VariableSyncConfig) []Var {
+	var selected []Var
+	for _, v := range vars {
+		if cfg.selects(v.Key) {
+			selected = append(selected, v)
+		}
+	}
+	return selected
+}
+
+// planVariableSync returns the changes needed to make the variables of one target workspace match the source, and
+// the keys of the sensitive source variables that can't be copied
+func planVariableSync(organization, workspace string, source, target []Var, prune bool) ([]ManifestChange, []string) {
+	var changes []ManifestChange
+	var skipped []string
+	add := func(action, name, detail string, apply func(ctx context.Context, c *Client) error) {
+		changes = append(changes, variablePlanChange(workspace, action, name, detail, apply))
+	}
+
+	pairs, targetUsed := pairVariables(source, target)
+	for i, s := range source {
+		s := s
+		if pairs[i] < 0 {
+			if s.Sensitive {
+				skipped = append(skipped, workspace+": "+s.Key)
+				continue
+			}
+			add(ManifestActionCreate, s.Key, variableDetail(s), func(ctx context.Context, c *Client) error {
+				return c.CreateVariableContext(ctx, organization, workspace, s)
+			})
+			continue
+		}
+
+		t := target[pairs[i]]
+		d, changed := diffVariable(t, s)
+		if !changed {
+			continue
+		}
+		if s.Sensitive {
+			if d.Status != VariableUnknown {
+				skipped = append(skipped, workspace+": "+s.Key)
+			}
+			continue
+		}
+		add(ManifestActionUpdate, s.Key, strings.Join(d.Changes, ", "), func(ctx context.Context, c *Client) error {
+			return c.UpdateVariableContext(ctx, organization, workspace, t.ID, s)
+		})
+	}
+
+	if prune {
+		for j, t := range target {
+			if targetUsed[j] {
+				continue
+			}
+			id := t.ID
+			add(ManifestActionDelete, t.Key, "not in the source workspace", func(ctx context.Context, c *Client) error {
+				return c.DeleteVariableContext(ctx, id)
+			})
+		}
+	}
+	return changes, skipped
+}
+
+// variablePlanChange returns a change to a variable in a workspace, which is made by calling apply
+func variablePlanChange(workspace, action, key, detail string, apply func(ctx context.Context, c *Client) error) ManifestChange {
+	return ManifestChange{
+		Workspace: workspace,
+		Action:    action,
+		Resource:  ManifestResourceVariable,
+		Name:      key,
+		Detail:    detail,
+		apply: func(ctx context.Context, c *Client, _ *workspaceIDs) error {
+			return apply(ctx, c)
+		},
+	}
+}
@@ -0,0 +1,97 @@
+package lib
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVariableSyncConfig_selects(t *testing.T) {
+	cfg := VariableSyncConfig{Only: []string{"aws_*", "region"}, Exclude: []string{"aws_secret*"}}
+	require.True(t, cfg.selects("aws_region"))
+	require.True(t, cfg.selects("region"))
+	require.False(t, cfg.selects("aws_secret_key"))
+	require.False(t, cfg.selects("name"))
+
+	require.True(t, VariableSyncConfig{}.selects("name"))
+	require.ErrorContains(t, VariableSyncConfig{Exclude: []string{"[a"}}.validate(), "invalid key pattern '[a'")
+}
+
+func Test_planVariableSync(t *testing.T) {
+	source := []Var{
+		{Key: "region", Value: "us-west-2", Category: "terraform"},
+		{Key: "new", Value: "n", Category: "terraform"},
+		{Key: "same", Value: "s", Category: "terraform"},
+		{Key: "TOKEN", Sensitive: true, Category: "env"},
+		{Key: "PASSWORD", Sensitive: true, Category: "env"},
+		{Key: "db_password", Sensitive: true, Category: "terraform"},
+	}
+	target := []Var{
+		{ID: "var-1", Key: "region", Value: "us-east-1", Category: "terraform"},
+		{ID: "var-2", Key: "same", Value: "s", Category: "terraform"},
+		{ID: "var-3", Key: "old", Value: "o", Category: "env"},
+		{ID: "var-4", Key: "TOKEN", Sensitive: true, Category: "env"},
+		{ID: "var-5", Key: "db_password", Value: "visible", Category: "terraform"},
+	}
+
+	changes, skipped := planVariableSync("org", "ws", source, target, false)
+	require.Equal(t, []string{"ws: PASSWORD", "ws: db_password"}, skipped)
+	require.Len(t, changes, 2)
+	require.Equal(t, ManifestChange{Workspace: "ws", Action: ManifestActionUpdate, Resource: ManifestResourceVariable,
+		Name: "region", Detail: `value: "us-east-1" -> "us-west-2"`}, withoutApply(changes[0]))
+	require.Equal(t, ManifestChange{Workspace: "ws", Action: ManifestActionCreate, Resource: ManifestResourceVariable,
+		Name: "new", Detail: `"n"`}, withoutApply(changes[1]))
+
+	changes, _ = planVariableSync("org", "ws", source, target, true)
+	require.Len(t, changes, 3)
+	require.Equal(t, ManifestChange{Workspace: "ws", Action: ManifestActionDelete, Resource: ManifestResourceVariable,
+		Name: "old", Detail: "not in the source workspace"}, withoutApply(changes[2]))
+}
+
+func withoutApply(c ManifestChange) ManifestChange {
+	c.apply = nil
+	return c
+}
+
+func TestClient_PlanVariableSync(t *testing.T) {
+	var calls []string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		call := r.Method + " " + strings.TrimPrefix(r.URL.Path, apiPath)
+		switch call {
+		case "GET /organizations/org":
+			_, _ = w.Write([]byte(`{"data":{"id":"org"}}`))
+		case "GET /vars":
+			if r.URL.Query().Get(paramFilterWorkspaceName) == "source" {
+				_, _ = w.Write([]byte(`{"data":[
+					{"id":"var-1","attributes":{"key":"aws_region","value":"us-west-2","category":"terraform"}},
+					{"id":"var-2","attributes":{"key":"name","value":"source","category":"terraform"}}]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"data":[
+				{"id":"var-3","attributes":{"key":"aws_region","value":"us-east-1","category":"terraform"}},
+				{"id":"var-4","attributes":{"key":"aws_profile","value":"x","category":"terraform"}}]}`))
+		default:
+			body, _ := io.ReadAll(r.Body)
+			calls = append(calls, call+" "+string(body))
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	defer server.Close()
+
+	c := newTestClient(server, "token")
+	cfg := VariableSyncConfig{Only: []string{"aws_*"}, Prune: true}
+	plan, skipped, err := c.PlanVariableSync("org", "source", "org", []string{"target"}, cfg)
+	require.NoError(t, err)
+	require.Empty(t, skipped)
+	require.Len(t, plan.Changes, 2)
+
+	require.NoError(t, c.ApplyManifestPlan(plan, io.Discard))
+	require.Len(t, calls, 2)
+	require.True(t, strings.HasPrefix(calls[0], "PATCH /vars/var-3 "))
+	require.Contains(t, calls[0], `"value":"us-west-2"`)
+	require.Equal(t, "DELETE /vars/var-4 ", calls[1])
+}
@@ -0,0 +1,131 @@
+// Package output writes lists of records as a table, CSV, JSON, or YAML, so that every command that lists things
+// supports the same output formats.
+package output
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Format is the name of an output format
+type Format string
+
+const (
+	FormatTable Format = "table"
+	FormatJSON  Format = "json"
+	FormatYAML  Format = "yaml"
+	FormatCSV   Format = "csv"
+)
+
+// Formats lists the names of all supported output formats
+var Formats = []string{string(FormatTable), string(FormatJSON), string(FormatYAML), string(FormatCSV)}
+
+// ParseFormat returns the Format with the given name, which is not case-sensitive
+func ParseFormat(name string) (Format, error) {
+	f := Format(strings.ToLower(name))
+	switch f {
+	case FormatTable, FormatJSON, FormatYAML, FormatCSV:
+		return f, nil
+	}
+	return "", fmt.Errorf("invalid output format %q, must be one of: %s", name, strings.Join(Formats, ", "))
+}
+
+// Table is a list of records to be written in one of the output formats
+type Table struct {
+	// Columns are the column names, which are the headings for table and CSV output
+	Columns []string
+
+	// Rows are the values of each record for table and CSV output, in the same order as Columns
+	Rows [][]string
+
+	// Records is the value to write for JSON and YAML output. If nil, each row is written as an object keyed by
+	// column name.
+	Records any
+}
+
+// Write writes the table to w in the given format
+func (f Format) Write(w io.Writer, t Table) error {
+	switch f {
+	case FormatJSON:
+		return writeJSON(w, t.records())
+	case FormatYAML:
+		return writeYAML(w, t.records())
+	case FormatCSV:
+		return writeCSV(w, t)
+	case FormatTable, "":
+		return writeTable(w, t)
+	}
+	return fmt.Errorf("invalid output format %q", f)
+}
+
+// records returns the value to use for JSON and YAML output, never nil so that an empty list is written as `[]`
+func (t Table) records() any {
+	if t.Records != nil {
+		return t.Records
+	}
+	records := make([]map[string]string, len(t.Rows))
+	for i, row := range t.Rows {
+		records[i] = make(map[string]string, len(t.Columns))
+		for j, column := range t.Columns {
+			if j < len(row) {
+				records[i][column] = row[j]
+			}
+		}
+	}
+	return records
+}
+
+func writeJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func writeYAML(w io.Writer, v any) error {
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(v); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// writeCSV writes the column names and rows in RFC 4180 format
+func writeCSV(w io.Writer, t Table) error {
+	cw := csv.NewWriter(w)
+	cw.UseCRLF = true
+	if err := cw.Write(t.Columns); err != nil {
+		return err
+	}
+	if err := cw.WriteAll(t.Rows); err != nil {
+		return err
+	}
+	return cw.Error()
+}
+
+// cellReplacer keeps each table row on one line and in its columns
+var cellReplacer = strings.NewReplacer("\n", `\n`, "\t", " ")
+
+// writeTable writes the rows in aligned columns, under a heading of the upper-cased column names
+func writeTable(w io.Writer, t Table) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+	headings := make([]string, len(t.Columns))
+	for i, c := range t.Columns {
+		headings[i] = strings.ToUpper(c)
+	}
+	fmt.Fprintln(tw, strings.Join(headings, "\t"))
+	for _, row := range t.Rows {
+		cells := make([]string, len(row))
+		for i, cell := range row {
+			cells[i] = cellReplacer.Replace(cell)
+		}
+		fmt.Fprintln(tw, strings.Join(cells, "\t"))
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,102 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFormat(t *testing.T) {
+	f, err := ParseFormat("JSON")
+	require.NoError(t, err)
+	require.Equal(t, FormatJSON, f)
+
+	_, err = ParseFormat("xml")
+	require.Error(t, err)
+}
+
+func TestFormat_Write(t *testing.T) {
+	type record struct {
+		Name  string `json:"name" yaml:"name"`
+		Value string `json:"value" yaml:"value"`
+	}
+	table := Table{
+		Columns: []string{"name", "value"},
+		Rows:    [][]string{{"a", `say "hi"`}, {"b", "one, two\nthree"}},
+		Records: []record{{"a", `say "hi"`}, {"b", "one, two\nthree"}},
+	}
+
+	tests := []struct {
+		name   string
+		format Format
+		table  Table
+		want   string
+	}{
+		{
+			name:   "table",
+			format: FormatTable,
+			table:  table,
+			want:   "NAME   VALUE\na      say \"hi\"\nb      one, two\\nthree\n",
+		},
+		{
+			name:   "csv",
+			format: FormatCSV,
+			table:  table,
+			want:   "name,value\r\na,\"say \"\"hi\"\"\"\r\nb,\"one, two\r\nthree\"\r\n",
+		},
+		{
+			name:   "json",
+			format: FormatJSON,
+			table:  table,
+			want: `[
+  {
+    "name": "a",
+    "value": "say \"hi\""
+  },
+  {
+    "name": "b",
+    "value": "one, two\nthree"
+  }
+]
+`,
+		},
+		{
+			name:   "json without records",
+			format: FormatJSON,
+			table:  Table{Columns: table.Columns, Rows: table.Rows[:1]},
+			want: `[
+  {
+    "name": "a",
+    "value": "say \"hi\""
+  }
+]
+`,
+		},
+		{
+			name:   "empty json",
+			format: FormatJSON,
+			table:  Table{Columns: table.Columns},
+			want:   "[]\n",
+		},
+		{
+			name:   "yaml",
+			format: FormatYAML,
+			table:  table,
+			want: `- name: a
+  value: say "hi"
+- name: b
+  value: |-
+    one, two
+    three
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, tt.format.Write(&buf, tt.table))
+			require.Equal(t, tt.want, buf.String())
+		})
+	}
+}